@@ -21,9 +21,11 @@ import (
 	"github.com/ChainSafe/sygma-relayer/chains/evm"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/contracts/bridge"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/events"
+	multiClient "github.com/ChainSafe/sygma-relayer/chains/evm/client"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/executor"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
 	evmEventHandlers "github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/plugins"
 	"github.com/ChainSafe/sygma-relayer/chains/substrate"
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
@@ -35,15 +37,17 @@ import (
 	"github.com/sygmaprotocol/sygma-core/relayer"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/store"
-	"github.com/sygmaprotocol/sygma-core/store/lvldb"
 
 	btcConfig "github.com/ChainSafe/sygma-relayer/chains/btc/config"
 	btcConnection "github.com/ChainSafe/sygma-relayer/chains/btc/connection"
 	btcExecutor "github.com/ChainSafe/sygma-relayer/chains/btc/executor"
 	btcListener "github.com/ChainSafe/sygma-relayer/chains/btc/listener"
+	substrateClient "github.com/ChainSafe/sygma-relayer/chains/substrate/client"
+	substrateConnection "github.com/ChainSafe/sygma-relayer/chains/substrate/connection"
 	substrateExecutor "github.com/ChainSafe/sygma-relayer/chains/substrate/executor"
 	substrateListener "github.com/ChainSafe/sygma-relayer/chains/substrate/listener"
 	substratePallet "github.com/ChainSafe/sygma-relayer/chains/substrate/pallet"
+	substrateRuntime "github.com/ChainSafe/sygma-relayer/chains/substrate/runtime"
 	"github.com/ChainSafe/sygma-relayer/metrics"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	coreEvm "github.com/sygmaprotocol/sygma-core/chains/evm"
@@ -51,7 +55,6 @@ import (
 	"github.com/sygmaprotocol/sygma-core/chains/evm/listener"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/monitored"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/transaction"
-	substrateClient "github.com/sygmaprotocol/sygma-core/chains/substrate/client"
 	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
 	coreSubstrateListener "github.com/sygmaprotocol/sygma-core/chains/substrate/listener"
 
@@ -63,6 +66,7 @@ import (
 	"github.com/ChainSafe/sygma-relayer/keyshare"
 	"github.com/ChainSafe/sygma-relayer/topology"
 	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/refresh"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/rs/zerolog/log"
@@ -95,7 +99,7 @@ func Run() error {
 
 	log.Info().Msg("Successfully loaded configuration")
 
-	topologyProvider, err := topology.NewNetworkTopologyProvider(configuration.RelayerConfig.MpcConfig.TopologyConfiguration, http.DefaultClient)
+	topologyProvider, err := topology.NewNetworkTopologyProvider(configuration.RelayerConfig.MpcConfig.TopologyConfiguration, &http.Client{Timeout: topology.FetchTimeout})
 	panicOnError(err)
 	topologyStore := topology.NewTopologyStore(configuration.RelayerConfig.MpcConfig.TopologyConfiguration.Path)
 	networkTopology, err := topologyStore.Topology()
@@ -120,7 +124,9 @@ func Run() error {
 	panicOnError(err)
 	log.Info().Str("peerID", host.ID().String()).Msg("Successfully created libp2p host")
 
-	go health.StartHealthEndpoint(configuration.RelayerConfig.HealthPort)
+	keyshareVerifier := keyshare.NewVerifier()
+	healthChecks := health.NewChecks(keyshareVerifier)
+	go health.StartHealthEndpoint(configuration.RelayerConfig.HealthPort, healthChecks)
 
 	communication := p2p.NewCommunication(host, "p2p/sygma")
 	electorFactory := elector.NewCoordinatorElectorFactory(host, configuration.RelayerConfig.BullyConfig)
@@ -128,9 +134,9 @@ func Run() error {
 
 	// this is temporary solution related to specifics of aws deployment
 	// effectively it waits until old instance is killed
-	var db *lvldb.LVLDB
+	var db *propStore.LvlDB
 	for {
-		db, err = lvldb.NewLvlDB(viper.GetString(config.BlockstoreFlagName))
+		db, err = propStore.NewLvlDB(viper.GetString(config.BlockstoreFlagName))
 		if err != nil {
 			log.Error().Err(err).Msg("Unable to connect to blockstore file, retry in 10 seconds")
 			time.Sleep(10 * time.Second)
@@ -140,9 +146,17 @@ func Run() error {
 		}
 	}
 	blockstore := store.NewBlockStore(db)
-	keyshareStore := keyshare.NewECDSAKeyshareStore(configuration.RelayerConfig.MpcConfig.KeysharePath)
-	frostKeyshareStore := keyshare.NewFrostKeyshareStore(configuration.RelayerConfig.MpcConfig.FrostKeysharePath)
+	keyWrapper, err := keyshare.NewKeyWrapper(configuration.RelayerConfig.MpcConfig.KeyshareEncryption)
+	panicOnError(err)
+	keyshareStore := keyshare.NewECDSAKeyshareStore(configuration.RelayerConfig.MpcConfig.KeysharePath, keyWrapper)
+	frostKeyshareStore := keyshare.NewFrostKeyshareStore(configuration.RelayerConfig.MpcConfig.FrostKeysharePath, keyWrapper)
+	// reading existing keyshares migrates them to encrypted storage
+	_, _ = keyshareStore.GetKeyshare()
+	_, _ = frostKeyshareStore.GetKeyshare()
+	quarantineStore := propStore.NewQuarantineStore(db)
 	propStore := propStore.NewPropStore(db)
+	err = propStore.MigrateIndexes()
+	panicOnError(err)
 
 	// wait until executions are done and then stop further executions before exiting
 	exitLock := &sync.RWMutex{}
@@ -165,7 +179,11 @@ func Run() error {
 	if err != nil {
 		panic(err)
 	}
+	keyRefresher := refresh.NewRefresher(coordinator, host, communication, keyshareStore, frostKeyshareStore, topologyStore, sygmaMetrics)
+	healthChecks.Add(keyRefresher)
 	msgChan := make(chan []*message.Message)
+	resourceDecimals := chains.NewResourceDecimals()
+	handlerPlugins := plugins.NewRegistry()
 
 	domains := make(map[uint8]relayer.RelayedChain)
 	for _, chainConfig := range configuration.ChainConfigs {
@@ -177,10 +195,20 @@ func Run() error {
 				kp, err := secp256k1.NewKeypairFromString(config.GeneralChainConfig.Key)
 				panicOnError(err)
 
-				client, err := evmClient.NewEVMClient(config.GeneralChainConfig.Endpoint, kp)
+				endpoints, err := multiClient.DialEndpoints(
+					append([]string{config.GeneralChainConfig.Endpoint}, config.FallbackEndpoints...),
+					func(url string) (multiClient.EndpointClient, error) { return evmClient.NewEVMClient(url, kp) },
+					config.EndpointCooldown,
+					config.EndpointQuorum,
+				)
+				panicOnError(err)
+				client, err := multiClient.NewMultiEndpointClient(*config.GeneralChainConfig.Id, kp.CommonAddress(), endpoints, config.EndpointQuorum, sygmaMetrics)
 				panicOnError(err)
 
 				log.Info().Str("domain", config.String()).Msgf("Registering EVM domain")
+				for _, resource := range config.Resources {
+					resourceDecimals.Register(*config.GeneralChainConfig.Id, resource.ResourceID, resource.Decimals)
+				}
 
 				bridgeAddress := common.HexToAddress(config.Bridge)
 				frostAddress := common.HexToAddress(config.FrostKeygen)
@@ -193,26 +221,9 @@ func Run() error {
 				bridgeContract := bridge.NewBridgeContract(client, bridgeAddress, t)
 
 				depositHandler := depositHandlers.NewETHDepositHandler(bridgeContract)
-				for _, handler := range config.Handlers {
-					switch handler.Type {
-					case "erc20", "native":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc20DepositHandler{})
-						}
-					case "permissionlessGeneric":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.PermissionlessGenericDepositHandler{})
-						}
-					case "erc721":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc721DepositHandler{})
-						}
-					case "erc1155":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc1155DepositHandler{})
-						}
-					}
-				}
+				transferMessageHandler := executor.NewTransferMessageHandler(resourceDecimals, config.GenericPolicy, quarantineStore)
+				handlerPlugins.RegisterDepositHandlers(config.Handlers, depositHandler)
+				handlerPlugins.RegisterTransferHandlers(transferMessageHandler)
 				depositListener := events.NewListener(client)
 				tssListener := events.NewListener(client)
 				eventHandlers := make([]listener.EventHandler, 0)
@@ -222,7 +233,7 @@ func Run() error {
 				eventHandlers = append(eventHandlers, depositEventHandler)
 				eventHandlers = append(eventHandlers, evmEventHandlers.NewKeygenEventHandler(l, tssListener, coordinator, host, communication, keyshareStore, bridgeAddress, networkTopology.Threshold))
 				eventHandlers = append(eventHandlers, evmEventHandlers.NewFrostKeygenEventHandler(l, tssListener, coordinator, host, communication, frostKeyshareStore, frostAddress, networkTopology.Threshold))
-				eventHandlers = append(eventHandlers, evmEventHandlers.NewRefreshEventHandler(l, topologyProvider, topologyStore, tssListener, coordinator, host, communication, connectionGate, keyshareStore, frostKeyshareStore, bridgeAddress))
+				eventHandlers = append(eventHandlers, evmEventHandlers.NewRefreshEventHandler(l, topologyProvider, topologyStore, tssListener, coordinator, host, communication, connectionGate, keyshareStore, frostKeyshareStore, keyRefresher, bridgeAddress))
 				eventHandlers = append(eventHandlers, evmEventHandlers.NewRetryV1EventHandler(l, tssListener, depositHandler, propStore, bridgeAddress, *config.GeneralChainConfig.Id, config.BlockConfirmations, msgChan))
 				if config.Retry != "" {
 					eventHandlers = append(eventHandlers, evmEventHandlers.NewRetryV2EventHandler(l, tssListener, common.HexToAddress(config.Retry), *config.GeneralChainConfig.Id, msgChan))
@@ -231,8 +242,10 @@ func Run() error {
 
 				mh := message.NewMessageHandler()
 				mh.RegisterMessageHandler(retry.RetryMessageType, executor.NewRetryMessageHandler(depositEventHandler, client, propStore, config.BlockConfirmations, msgChan))
-				mh.RegisterMessageHandler(transfer.TransferMessageType, &executor.TransferMessageHandler{})
-				executor := executor.NewExecutor(host, communication, coordinator, bridgeContract, keyshareStore, exitLock, config.GasLimit.Uint64(), config.TransferGas)
+				mh.RegisterMessageHandler(transfer.TransferMessageType, transferMessageHandler)
+				gasEstimator := executor.NewProposalGasEstimator(client, bridgeContract, bridgeAddress, config.GasEstimationMargin, config.TransferGas)
+				keyshareVerifier.AddCheck(keyshare.NewECDSAPublicKeyCheck(*config.GeneralChainConfig.Id, bridgeContract, keyshareStore))
+				executor := executor.NewExecutor(host, communication, coordinator, bridgeContract, keyshareVerifier.ECDSAFetcher(*config.GeneralChainConfig.Id, keyshareStore), exitLock, config.GasLimit.Uint64(), config.TransferGas, gasEstimator, propStore)
 
 				startBlock, err := blockstore.GetStartBlock(*config.GeneralChainConfig.Id, config.StartBlock, config.GeneralChainConfig.LatestBlock, config.GeneralChainConfig.FreshStart)
 				if err != nil {
@@ -269,32 +282,61 @@ func Run() error {
 					panic(err)
 				}
 
-				substrateClient := substrateClient.NewSubstrateClient(conn, &keyPair, config.ChainID, config.Tip)
-				bridgePallet := substratePallet.NewPallet(substrateClient)
+				submitter := substrateClient.NewSubmitter(substrateClient.NewRPCConnection(conn), &keyPair, config.Tip, config.MaxTip, config.TipIncrease, config.EraPeriod)
+				bridgePallet := substratePallet.NewPallet(conn, submitter, config.ChainID)
+				runtimeGuard, err := substrateRuntime.NewRuntimeGuard(*config.GeneralChainConfig.Id, conn, sygmaMetrics)
+				panicOnError(err)
+				healthChecks.Add(runtimeGuard)
 
 				log.Info().Str("domain", config.String()).Msgf("Registering substrate domain")
+				for _, resource := range config.Resources {
+					resourceDecimals.Register(*config.GeneralChainConfig.Id, resource.ResourceID, resource.Decimals)
+				}
+
+				var listenerConn substrateListener.Connection = conn
+				if config.VerificationQuorum > 1 {
+					endpoints := []substrateConnection.Endpoint{substrateConnection.NewRPCEndpoint(conn)}
+					for _, url := range config.VerificationEndpoints {
+						endpoints = append(endpoints, substrateConnection.NewWitnessEndpoint(url, func(url string) (substrateConnection.Endpoint, error) {
+							witness, err := connection.NewSubstrateConnection(url)
+							if err != nil {
+								return nil, err
+							}
+							return substrateConnection.NewRPCEndpoint(witness), nil
+						}))
+					}
+					listenerConn, err = substrateConnection.NewVerifiedConnection(*config.GeneralChainConfig.Id, conn, endpoints, config.VerificationQuorum)
+					panicOnError(err)
+				} else {
+					listenerConn, err = substrateConnection.NewBatchedConnection(*config.GeneralChainConfig.Id, conn, config.EventBatchSize, config.EventFetchParallelism, sygmaMetrics)
+					panicOnError(err)
+				}
 
 				l := log.With().Str("chain", fmt.Sprintf("%v", config.GeneralChainConfig.Name)).Uint8("domainID", *config.GeneralChainConfig.Id)
 				depositHandler := substrateListener.NewSubstrateDepositHandler()
 				depositHandler.RegisterDepositHandler(transfer.FungibleTransfer, substrateListener.FungibleTransferHandler)
+				depositHandler.RegisterDepositHandler(transfer.NonFungibleTransfer, substrateListener.NonFungibleTransferHandler)
+				depositHandler.RegisterDepositHandler(transfer.PermissionlessGenericTransfer, substrateListener.GenericTransferHandler)
 				eventHandlers := make([]coreSubstrateListener.EventHandler, 0)
-				depositEventHandler := substrateListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgChan, conn)
-				eventHandlers = append(eventHandlers, substrateListener.NewRetryEventHandler(l, conn, depositHandler, *config.GeneralChainConfig.Id, msgChan))
+				depositEventHandler := substrateListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgChan, listenerConn)
+				eventHandlers = append(eventHandlers, substrateListener.NewSystemUpdateEventHandler(listenerConn, runtimeGuard))
+				eventHandlers = append(eventHandlers, substrateListener.NewRetryEventHandler(l, listenerConn, *config.GeneralChainConfig.Id, msgChan))
 				eventHandlers = append(eventHandlers, depositEventHandler)
-				substrateListener := coreSubstrateListener.NewSubstrateListener(conn, eventHandlers, blockstore, sygmaMetrics, *config.GeneralChainConfig.Id, config.BlockRetryInterval, config.BlockInterval)
+				substrateListener := coreSubstrateListener.NewSubstrateListener(listenerConn, eventHandlers, blockstore, sygmaMetrics, *config.GeneralChainConfig.Id, config.BlockRetryInterval, config.BlockInterval)
 
 				mh := message.NewMessageHandler()
-				mh.RegisterMessageHandler(transfer.TransferMessageType, &substrateExecutor.SubstrateMessageHandler{})
-				mh.RegisterMessageHandler(retry.RetryMessageType, substrateExecutor.NewRetryMessageHandler(depositEventHandler, conn, propStore, msgChan))
+				mh.RegisterMessageHandler(transfer.TransferMessageType, substrateExecutor.NewSubstrateMessageHandler(resourceDecimals, config.Resources, config.ParachainTopology, quarantineStore, propStore))
+				mh.RegisterMessageHandler(retry.RetryMessageType, substrateExecutor.NewRetryMessageHandler(depositEventHandler, listenerConn, propStore, msgChan))
 
-				sExecutor := substrateExecutor.NewExecutor(host, communication, coordinator, bridgePallet, keyshareStore, conn, exitLock)
+				keyshareVerifier.AddCheck(keyshare.NewECDSAPublicKeyCheck(*config.GeneralChainConfig.Id, bridgePallet, keyshareStore))
+				sExecutor := substrateExecutor.NewExecutor(host, communication, coordinator, bridgePallet, keyshareVerifier.ECDSAFetcher(*config.GeneralChainConfig.Id, keyshareStore), conn, exitLock, runtimeGuard, propStore)
 
 				startBlock, err := blockstore.GetStartBlock(*config.GeneralChainConfig.Id, config.StartBlock, config.GeneralChainConfig.LatestBlock, config.GeneralChainConfig.FreshStart)
 				if err != nil {
 					panic(err)
 				}
 				if startBlock == nil {
-					head, err := substrateClient.LatestBlock()
+					head, err := bridgePallet.LatestBlock()
 					if err != nil {
 						panic(err)
 					}
@@ -327,9 +369,12 @@ func Run() error {
 
 				l := log.With().Str("chain", fmt.Sprintf("%v", config.GeneralChainConfig.Name)).Uint8("domainID", *config.GeneralChainConfig.Id)
 				resources := make(map[[32]byte]btcConfig.Resource)
+				taprootResources := make([]keyshare.TaprootResource, 0)
 				for _, resource := range config.Resources {
 					resources[resource.ResourceID] = resource
+					taprootResources = append(taprootResources, keyshare.TaprootResource{Address: resource.Address, Tweak: resource.Tweak})
 				}
+				keyshareVerifier.AddCheck(keyshare.NewFrostPublicKeyCheck(*config.GeneralChainConfig.Id, &config.Network, taprootResources, frostKeyshareStore))
 				depositHandler := &btcListener.BtcDepositHandler{}
 				depositEventHandler := btcListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgChan, conn, resources, config.FeeAddress)
 				eventHandlers := make([]btcListener.EventHandler, 0)
@@ -347,7 +392,7 @@ func Run() error {
 					host,
 					communication,
 					coordinator,
-					frostKeyshareStore,
+					keyshareVerifier.FrostFetcher(*config.GeneralChainConfig.Id, frostKeyshareStore),
 					conn,
 					mempool,
 					resources,
@@ -365,6 +410,18 @@ func Run() error {
 	}
 
 	go jobs.StartCommunicationHealthCheckJob(host, configuration.RelayerConfig.MpcConfig.CommHealthCheckInterval, sygmaMetrics)
+	if configuration.RelayerConfig.MpcConfig.KeyRefreshInterval > 0 {
+		go jobs.StartKeyRefreshJob(ctx, keyRefresher, configuration.RelayerConfig.MpcConfig.KeyRefreshInterval)
+	}
+	domainIDs := make([]uint8, 0, len(domains))
+	for id := range domains {
+		domainIDs = append(domainIDs, id)
+	}
+	go jobs.StartStuckProposalsJob(ctx, propStore, domainIDs, jobs.StuckCheckInterval)
+
+	// keyshares are verified before the relayer starts so that stale keyshares are never used for signing
+	keyshareVerifier.Verify()
+	go keyshareVerifier.Start(ctx, configuration.RelayerConfig.MpcConfig.KeyshareCheckInterval, sygmaMetrics)
 
 	r := relayer.NewRelayer(domains, sygmaMetrics)
 	go r.Start(ctx, msgChan)
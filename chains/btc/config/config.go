@@ -16,6 +16,13 @@ import (
 	"github.com/mitchellh/mapstructure"
 )
 
+const (
+	// BtcDecimals are the decimals of amounts on the Bitcoin network
+	BtcDecimals = 8
+	// BridgedDecimals are the decimals of bridged bitcoin amounts on other domains
+	BridgedDecimals = 18
+)
+
 type RawResource struct {
 	Address    string
 	ResourceID string
@@ -100,7 +107,7 @@ func NewBtcConfig(chainConfig map[string]interface{}) (*BtcConfig, error) {
 		return nil, err
 	}
 
-	networkParams, err := networkParams(c.Network)
+	networkParams, err := NetworkParams(c.Network)
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +163,8 @@ func NewBtcConfig(chainConfig map[string]interface{}) (*BtcConfig, error) {
 	return config, nil
 }
 
-func networkParams(network string) (chaincfg.Params, error) {
+// NetworkParams returns bitcoin network parameters by network name
+func NetworkParams(network string) (chaincfg.Params, error) {
 	switch network {
 	case "mainnet":
 		return chaincfg.MainNetParams, nil
@@ -128,6 +128,7 @@ func (e *Executor) executeResourceProps(props []*BtcTransferProposal, resource c
 
 	tx, utxos, err := e.rawTx(props, resource)
 	if err != nil {
+		e.updateProposals(props, store.ProposalUpdate{Reason: err.Error()})
 		return err
 	}
 
@@ -136,6 +137,7 @@ func (e *Executor) executeResourceProps(props []*BtcTransferProposal, resource c
 	executionContext, cancelExecution := context.WithCancel(context.Background())
 	watchContext, cancelWatch := context.WithCancel(context.Background())
 	sessionID := fmt.Sprintf("%s-%s", messageID, hex.EncodeToString(resource.ResourceID[:]))
+	e.updateProposals(props, store.ProposalUpdate{SessionID: sessionID})
 	defer cancelWatch()
 	p.Go(func() error {
 		return e.watchExecution(watchContext, cancelExecution, tx, props, sigChn, sessionID, messageID)
@@ -180,7 +182,11 @@ func (e *Executor) executeResourceProps(props []*BtcTransferProposal, resource c
 		tssProcesses[i] = signing
 	}
 	p.Go(func() error {
-		return e.coordinator.Execute(executionContext, tssProcesses, sigChn)
+		err := e.coordinator.Execute(executionContext, tssProcesses, sigChn)
+		if err != nil {
+			e.updateProposals(props, store.ProposalUpdate{Reason: err.Error()})
+		}
+		return err
 	})
 	return p.Wait()
 }
@@ -215,17 +221,19 @@ func (e *Executor) watchExecution(
 				hash, err := e.sendTx(tx, signatures, messageID)
 				if err != nil {
 					_ = e.comm.Broadcast(e.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, sessionID)
-					e.storeProposalsStatus(proposals, store.FailedProp)
+					e.updateProposals(proposals, store.ProposalUpdate{Status: store.FailedProp, Reason: err.Error()})
 					return err
 				}
 
-				e.storeProposalsStatus(proposals, store.ExecutedProp)
+				e.updateProposals(proposals, store.ProposalUpdate{Status: store.ExecutedProp, TxHash: hash.String()})
 				log.Info().Str("messageID", messageID).Msgf("Sent proposals execution with hash: %s", hash)
 				return nil
 			}
 		case <-timeout.C:
 			{
-				return fmt.Errorf("execution timed out in %s", signingTimeout)
+				err := fmt.Errorf("execution timed out in %s", signingTimeout)
+				e.updateProposals(proposals, store.ProposalUpdate{Reason: err.Error()})
+				return err
 			}
 		case <-ctx.Done():
 			{
@@ -388,7 +396,13 @@ func (e *Executor) proposalsForExecution(proposals []*proposal.Proposal, message
 			continue
 		}
 
-		err = e.propStorer.StorePropStatus(prop.Source, prop.Destination, prop.Data.(BtcTransferProposalData).DepositNonce, store.PendingProp)
+		err = e.propStorer.UpdateProposal(store.ProposalUpdate{
+			Source:       prop.Source,
+			Destination:  prop.Destination,
+			DepositNonce: prop.Data.(BtcTransferProposalData).DepositNonce,
+			MessageID:    prop.MessageID,
+			Status:       store.PendingProp,
+		})
 		if err != nil {
 			return props, err
 		}
@@ -414,16 +428,15 @@ func (e *Executor) isExecuted(prop *proposal.Proposal) (bool, error) {
 	return true, err
 }
 
-func (e *Executor) storeProposalsStatus(props []*BtcTransferProposal, status store.PropStatus) {
+func (e *Executor) updateProposals(props []*BtcTransferProposal, update store.ProposalUpdate) {
 	e.propMutex.Lock()
 	for _, prop := range props {
-		err := e.propStorer.StorePropStatus(
-			prop.Source,
-			prop.Destination,
-			prop.Data.DepositNonce,
-			status)
+		update.Source = prop.Source
+		update.Destination = prop.Destination
+		update.DepositNonce = prop.Data.DepositNonce
+		err := e.propStorer.UpdateProposal(update)
 		if err != nil {
-			log.Err(err).Msgf("Failed storing proposal %+v status %s", prop, status)
+			log.Err(err).Msgf("Failed storing proposal %+v update %+v", prop, update)
 		}
 	}
 	e.propMutex.Unlock()
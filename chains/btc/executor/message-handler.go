@@ -13,6 +13,8 @@ import (
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 
+	"github.com/ChainSafe/sygma-relayer/chains"
+	"github.com/ChainSafe/sygma-relayer/chains/btc/config"
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/store"
@@ -61,12 +63,8 @@ func ERC20MessageHandler(msg *transfer.TransferMessage) (*proposal.Proposal, err
 	if !ok {
 		return nil, errors.New("wrong payload recipient format")
 	}
-	bigAmount := new(big.Int).SetBytes(amount)
-
-	// remove 10 decimal places to match Bitcoin network
-	divisor := new(big.Int)
-	divisor.Exp(big.NewInt(10), big.NewInt(10), nil)
-	bigAmount.Div(bigAmount, divisor)
+	// dust below Bitcoin network precision is dropped
+	bigAmount, _ := chains.ScaleAmount(new(big.Int).SetBytes(amount), config.BridgedDecimals, config.BtcDecimals)
 
 	return proposal.NewProposal(msg.Source, msg.Destination, BtcTransferProposalData{
 		Amount:       bigAmount.Uint64(),
@@ -84,6 +82,7 @@ type BlockFetcher interface {
 type PropStorer interface {
 	StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error
 	PropStatus(source, destination uint8, depositNonce uint64) (store.PropStatus, error)
+	UpdateProposal(update store.ProposalUpdate) error
 }
 
 type DepositProcessor interface {
@@ -136,7 +135,7 @@ func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Pro
 	if err != nil {
 		return nil, err
 	}
-	filteredDeposits, err := retry.FilterDeposits(h.propStorer, domainDeposits, retryData.ResourceID, retryData.DestinationDomainID)
+	filteredDeposits, err := retry.FilterDeposits(h.propStorer, domainDeposits, retryData.ResourceID, retryData.AllResources, retryData.DestinationDomainID)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +120,20 @@ func (mr *MockPropStorerMockRecorder) StorePropStatus(source, destination, depos
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePropStatus", reflect.TypeOf((*MockPropStorer)(nil).StorePropStatus), source, destination, depositNonce, status)
 }
 
+// UpdateProposal mocks base method.
+func (m *MockPropStorer) UpdateProposal(update store.ProposalUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProposal", update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProposal indicates an expected call of UpdateProposal.
+func (mr *MockPropStorerMockRecorder) UpdateProposal(update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProposal", reflect.TypeOf((*MockPropStorer)(nil).UpdateProposal), update)
+}
+
 // MockDepositProcessor is a mock of DepositProcessor interface.
 type MockDepositProcessor struct {
 	ctrl     *gomock.Controller
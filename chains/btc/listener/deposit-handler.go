@@ -10,6 +10,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ChainSafe/sygma-relayer/chains"
+	"github.com/ChainSafe/sygma-relayer/chains/btc/config"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
@@ -40,10 +42,7 @@ func (e *BtcDepositHandler) HandleDeposit(
 		return nil, err
 	}
 
-	// add 10 decimal places (8->18)
-	multiplier := new(big.Int)
-	multiplier.Exp(big.NewInt(10), big.NewInt(10), nil)
-	amount.Mul(amount, multiplier)
+	amount, _ = chains.ScaleAmount(amount, config.BtcDecimals, config.BridgedDecimals)
 	payload := []interface{}{
 		amount.Bytes(),
 		evmAdd,
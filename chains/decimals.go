@@ -0,0 +1,128 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package chains
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+)
+
+const AmountConversionMetadataKey = "amountConversion"
+
+var ErrLossyConversion = errors.New("amount conversion would truncate dust")
+var ErrMissingDecimals = errors.New("resource decimals configured on only one side of the route")
+
+// AmountConversion describes conversion of a fungible amount between domains
+// and is recorded in the proposal metadata
+type AmountConversion struct {
+	SourceDecimals      uint8
+	DestinationDecimals uint8
+	SourceAmount        *big.Int
+	DestinationAmount   *big.Int
+}
+
+// ResourceDecimals holds token decimals for each resource per domain
+type ResourceDecimals struct {
+	decimals map[uint8]map[[32]byte]uint8
+}
+
+func NewResourceDecimals() *ResourceDecimals {
+	return &ResourceDecimals{
+		decimals: make(map[uint8]map[[32]byte]uint8),
+	}
+}
+
+// Register sets decimals of the resource on the provided domain
+func (d *ResourceDecimals) Register(domainID uint8, resourceID [32]byte, decimals uint8) {
+	if _, ok := d.decimals[domainID]; !ok {
+		d.decimals[domainID] = make(map[[32]byte]uint8)
+	}
+	d.decimals[domainID][resourceID] = decimals
+}
+
+// Decimals returns decimals of the resource on the provided domain
+func (d *ResourceDecimals) Decimals(domainID uint8, resourceID [32]byte) (uint8, bool) {
+	resources, ok := d.decimals[domainID]
+	if !ok {
+		return 0, false
+	}
+	decimals, ok := resources[resourceID]
+	return decimals, ok
+}
+
+// ConvertAmount converts amount from source domain decimals to destination domain decimals.
+// Returns nil conversion if decimals are not configured for either domain or are equal and
+// an error if decimals are configured for only one of the domains.
+func (d *ResourceDecimals) ConvertAmount(source, destination uint8, resourceID [32]byte, amount *big.Int) (*AmountConversion, error) {
+	if d == nil {
+		return nil, nil
+	}
+	sourceDecimals, sourceOk := d.Decimals(source, resourceID)
+	destinationDecimals, destinationOk := d.Decimals(destination, resourceID)
+	if sourceOk != destinationOk {
+		return nil, fmt.Errorf("%w: resource %x from domain %d to domain %d", ErrMissingDecimals, resourceID, source, destination)
+	}
+	if !sourceOk || sourceDecimals == destinationDecimals {
+		return nil, nil
+	}
+
+	destinationAmount, dust := ScaleAmount(amount, sourceDecimals, destinationDecimals)
+	if dust.Sign() != 0 {
+		return nil, fmt.Errorf("%w: amount %s from %d to %d decimals", ErrLossyConversion, amount, sourceDecimals, destinationDecimals)
+	}
+	return &AmountConversion{
+		SourceDecimals:      sourceDecimals,
+		DestinationDecimals: destinationDecimals,
+		SourceAmount:        new(big.Int).Set(amount),
+		DestinationAmount:   destinationAmount,
+	}, nil
+}
+
+// ScaleAmount scales amount from source decimals to destination decimals.
+// Returns the scaled amount and the dust truncated when scaling to less decimals.
+func ScaleAmount(amount *big.Int, sourceDecimals, destinationDecimals uint8) (*big.Int, *big.Int) {
+	if destinationDecimals >= sourceDecimals {
+		multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(destinationDecimals-sourceDecimals)), nil)
+		return new(big.Int).Mul(amount, multiplier), big.NewInt(0)
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(sourceDecimals-destinationDecimals)), nil)
+	return new(big.Int).QuoRem(amount, divisor, new(big.Int))
+}
+
+// NormalizeFungibleAmount converts the fungible transfer amount to destination decimals
+// in place and records the conversion in message metadata
+func (d *ResourceDecimals) NormalizeFungibleAmount(msg *transfer.TransferMessage) error {
+	if len(msg.Data.Payload) == 0 {
+		return errors.New("malformed payload. Missing amount")
+	}
+	amount, ok := msg.Data.Payload[0].([]byte)
+	if !ok {
+		return errors.New("wrong payload amount format")
+	}
+
+	conversion, err := d.ConvertAmount(msg.Source, msg.Destination, msg.Data.ResourceId, new(big.Int).SetBytes(amount))
+	if err != nil {
+		return err
+	}
+	if conversion == nil {
+		return nil
+	}
+
+	payload := make([]interface{}, len(msg.Data.Payload))
+	copy(payload, msg.Data.Payload)
+	payload[0] = conversion.DestinationAmount.Bytes()
+	msg.Data.Payload = payload
+
+	metadata := make(map[string]interface{})
+	for k, v := range msg.Data.Metadata {
+		metadata[k] = v
+	}
+	metadata[AmountConversionMetadataKey] = conversion
+	msg.Data.Metadata = metadata
+	return nil
+}
@@ -0,0 +1,118 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package chains
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceDecimalsTestSuite struct {
+	suite.Suite
+	decimals   *ResourceDecimals
+	resourceID [32]byte
+}
+
+func TestRunResourceDecimalsTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceDecimalsTestSuite))
+}
+
+func (s *ResourceDecimalsTestSuite) SetupTest() {
+	s.resourceID = [32]byte{1}
+	s.decimals = NewResourceDecimals()
+	s.decimals.Register(1, s.resourceID, 18)
+	s.decimals.Register(2, s.resourceID, 6)
+	s.decimals.Register(3, s.resourceID, 18)
+}
+
+func (s *ResourceDecimalsTestSuite) Test_ConvertAmount_NotConfigured() {
+	conversion, err := s.decimals.ConvertAmount(1, 4, [32]byte{2}, big.NewInt(100))
+
+	s.Nil(err)
+	s.Nil(conversion)
+}
+
+func (s *ResourceDecimalsTestSuite) Test_ConvertAmount_ConfiguredOnOneSide() {
+	_, err := s.decimals.ConvertAmount(1, 4, s.resourceID, big.NewInt(100))
+
+	s.True(errors.Is(err, ErrMissingDecimals))
+}
+
+func (s *ResourceDecimalsTestSuite) Test_ConvertAmount_SameDecimals() {
+	conversion, err := s.decimals.ConvertAmount(1, 3, s.resourceID, big.NewInt(100))
+
+	s.Nil(err)
+	s.Nil(conversion)
+}
+
+func (s *ResourceDecimalsTestSuite) Test_ConvertAmount_ToMoreDecimals() {
+	conversion, err := s.decimals.ConvertAmount(2, 1, s.resourceID, big.NewInt(5))
+
+	s.Nil(err)
+	s.Equal(conversion.DestinationAmount, big.NewInt(5000000000000))
+}
+
+func (s *ResourceDecimalsTestSuite) Test_ConvertAmount_ToLessDecimals() {
+	conversion, err := s.decimals.ConvertAmount(1, 2, s.resourceID, big.NewInt(5000000000000))
+
+	s.Nil(err)
+	s.Equal(conversion.DestinationAmount, big.NewInt(5))
+}
+
+func (s *ResourceDecimalsTestSuite) Test_ConvertAmount_LossyConversion() {
+	_, err := s.decimals.ConvertAmount(1, 2, s.resourceID, big.NewInt(5000000000001))
+
+	s.True(errors.Is(err, ErrLossyConversion))
+}
+
+func (s *ResourceDecimalsTestSuite) Test_ScaleAmount_ReturnsDust() {
+	amount, dust := ScaleAmount(big.NewInt(12345678901), 18, 8)
+
+	s.Equal(amount, big.NewInt(1))
+	s.Equal(dust, big.NewInt(2345678901))
+}
+
+func (s *ResourceDecimalsTestSuite) Test_ScaleAmount_ToMoreDecimals() {
+	amount, dust := ScaleAmount(big.NewInt(100), 8, 18)
+
+	s.Equal(amount, big.NewInt(1000000000000))
+	s.Equal(dust.Sign(), 0)
+}
+
+func (s *ResourceDecimalsTestSuite) Test_NormalizeFungibleAmount_RecordsConversion() {
+	msg := &transfer.TransferMessage{
+		Source:      2,
+		Destination: 1,
+		Data: transfer.TransferMessageData{
+			ResourceId: s.resourceID,
+			Payload:    []interface{}{big.NewInt(5).Bytes(), []byte{1}},
+			Metadata:   map[string]interface{}{"gasLimit": uint64(100)},
+		},
+	}
+
+	err := s.decimals.NormalizeFungibleAmount(msg)
+
+	s.Nil(err)
+	s.Equal(msg.Data.Payload[0], big.NewInt(5000000000000).Bytes())
+	s.Equal(msg.Data.Metadata["gasLimit"], uint64(100))
+	s.Equal(msg.Data.Metadata[AmountConversionMetadataKey].(*AmountConversion).SourceAmount, big.NewInt(5))
+}
+
+func (s *ResourceDecimalsTestSuite) Test_NormalizeFungibleAmount_NilDecimals() {
+	var decimals *ResourceDecimals
+	msg := &transfer.TransferMessage{
+		Data: transfer.TransferMessageData{
+			Payload: []interface{}{big.NewInt(5).Bytes(), []byte{1}},
+		},
+	}
+
+	err := decimals.NormalizeFungibleAmount(msg)
+
+	s.Nil(err)
+	s.Equal(msg.Data.Payload[0], big.NewInt(5).Bytes())
+}
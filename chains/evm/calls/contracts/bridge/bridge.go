@@ -126,6 +126,17 @@ func (c *BridgeContract) GetHandlerAddressForResourceID(
 	return out, nil
 }
 
+// MPCAddress returns the address of the MPC key the bridge verifies proposal signatures with
+func (c *BridgeContract) MPCAddress() (common.Address, error) {
+	log.Debug().Msg("Getting MPC address")
+	res, err := c.CallContract("_MPCAddress")
+	if err != nil {
+		return common.Address{}, err
+	}
+	out := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	return out, nil
+}
+
 func (c *BridgeContract) Retry(hash common.Hash, opts transactor.TransactOptions) (*common.Hash, error) {
 	log.Debug().Msgf("Retrying deposit from transaction: %s", hash.Hex())
 	return c.ExecuteTransaction("retry", opts, hash.Hex())
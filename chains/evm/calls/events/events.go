@@ -27,6 +27,7 @@ const (
 	RetryV2Sig           EventSig = "Retry(uint8,uint8,uint256,bytes32)"
 	RetryV1Sig           EventSig = "Retry(string)"
 	FeeHandlerChanged    EventSig = "FeeHandlerChanged(address)"
+	FeeCollectedSig      EventSig = "FeeCollected(address,uint8,uint8,bytes32,uint256,address)"
 )
 
 // Refresh struct holds key refresh event data
@@ -63,4 +64,15 @@ type Deposit struct {
 	HandlerResponse []byte
 	// Timestamp is the timestamp of the block that the deposit event is in
 	Timestamp time.Time
+	// Fee is the native fee the fee handler collected for the deposit, nil if unknown
+	Fee *big.Int
+}
+
+type FeeCollected struct {
+	Sender              common.Address
+	FromDomainID        uint8
+	DestinationDomainID uint8
+	ResourceID          [32]byte
+	Fee                 *big.Int
+	TokenAddress        common.Address
 }
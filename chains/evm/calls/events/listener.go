@@ -23,21 +23,25 @@ type ChainClient interface {
 	WaitAndReturnTxReceipt(h common.Hash) (*ethTypes.Receipt, error)
 	LatestBlock() (*big.Int, error)
 	BlockByNumber(ctx context.Context, number *big.Int) (*ethTypes.Block, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethTypes.Receipt, error)
 }
 
 type Listener struct {
-	client   ChainClient
-	abi      abi.ABI
-	retryAbi abi.ABI
+	client        ChainClient
+	abi           abi.ABI
+	retryAbi      abi.ABI
+	feeHandlerAbi abi.ABI
 }
 
 func NewListener(client ChainClient) *Listener {
 	retryAbi, _ := abi.JSON(strings.NewReader(consts.RetryABI))
+	feeHandlerAbi, _ := abi.JSON(strings.NewReader(consts.FeeHandlerABI))
 	abi, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
 	return &Listener{
-		client:   client,
-		abi:      abi,
-		retryAbi: retryAbi,
+		client:        client,
+		abi:           abi,
+		retryAbi:      retryAbi,
+		feeHandlerAbi: feeHandlerAbi,
 	}
 }
 
@@ -47,6 +51,7 @@ func (l *Listener) FetchDeposits(ctx context.Context, contractAddress common.Add
 		return nil, err
 	}
 	deposits := make([]*Deposit, 0)
+	txLogs := make(map[common.Hash][]*ethTypes.Log)
 
 	for _, dl := range logs {
 		d, err := l.parseDeposit(ctx, dl)
@@ -55,6 +60,18 @@ func (l *Listener) FetchDeposits(ctx context.Context, contractAddress common.Add
 			continue
 		}
 
+		receiptLogs, ok := txLogs[dl.TxHash]
+		if !ok {
+			receipt, err := l.client.TransactionReceipt(ctx, dl.TxHash)
+			if err != nil {
+				log.Warn().Msgf("Failed fetching receipt of deposit tx %s because of: %+v", dl.TxHash, err)
+			} else {
+				receiptLogs = receipt.Logs
+				txLogs[dl.TxHash] = receiptLogs
+			}
+		}
+		d.Fee = l.depositFee(d, dl, receiptLogs)
+
 		log.Debug().Msgf("Found deposit log in block: %d, TxHash: %s, contractAddress: %s, sender: %s", dl.BlockNumber, dl.TxHash, dl.Address, d.SenderAddress)
 		deposits = append(deposits, d)
 	}
@@ -81,6 +98,44 @@ func (l *Listener) parseDeposit(ctx context.Context, dl ethTypes.Log) (*Deposit,
 	return &d, nil
 }
 
+// depositFee returns the native fee collected for the deposit. The fee handler emits FeeCollected
+// during the bridge deposit call, so the fee of the deposit is the last matching FeeCollected event
+// emitted after the previous bridge event of the transaction and before the deposit event.
+// Returns nil if the fee is unknown or was not paid in the native token.
+func (l *Listener) depositFee(d *Deposit, dl ethTypes.Log, logs []*ethTypes.Log) *big.Int {
+	var collected *FeeCollected
+	for _, lg := range logs {
+		if lg.Index >= dl.Index {
+			break
+		}
+		if lg.Address == dl.Address {
+			collected = nil
+			continue
+		}
+		if len(lg.Topics) == 0 || lg.Topics[0] != FeeCollectedSig.GetTopic() {
+			continue
+		}
+
+		var event FeeCollected
+		err := l.feeHandlerAbi.UnpackIntoInterface(&event, "FeeCollected", lg.Data)
+		if err != nil {
+			log.Warn().Msgf("Failed unpacking fee collected event in tx %s because of: %+v", dl.TxHash, err)
+			continue
+		}
+		if event.Sender != d.SenderAddress ||
+			event.DestinationDomainID != d.DestinationDomainID ||
+			event.ResourceID != d.ResourceID {
+			continue
+		}
+		collected = &event
+	}
+
+	if collected == nil || collected.TokenAddress != (common.Address{}) {
+		return nil
+	}
+	return collected.Fee
+}
+
 func (l *Listener) FetchRetryDepositEvents(event RetryV1Event, bridgeAddress common.Address, blockConfirmations *big.Int) ([]Deposit, error) {
 	depositEvents := make([]Deposit, 0)
 	retryDepositTxHash := common.HexToHash(event.TxHash)
@@ -112,6 +167,7 @@ func (l *Listener) FetchRetryDepositEvents(event RetryV1Event, bridgeAddress com
 			log.Error().Msgf("failed unpacking deposit event log: %v", err)
 			continue
 		}
+		d.Fee = l.depositFee(d, *lg, receipt.Logs)
 		depositEvents = append(depositEvents, *d)
 	}
 
@@ -4,15 +4,19 @@
 package events_test
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/consts"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/events"
 	mock_listener "github.com/ChainSafe/sygma-relayer/chains/evm/calls/events/mock"
 )
@@ -135,3 +139,92 @@ func (s *ListenerTestSuite) Test_FetchRetryDepositEvents_ValidEvent() {
 	s.Nil(err)
 	s.Equal(deposits[0].DestinationDomainID, uint8(2))
 }
+
+func (s *ListenerTestSuite) depositLog(index uint, nonce uint64) *types.Log {
+	bridgeAbi, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
+	data, err := bridgeAbi.Events["Deposit"].Inputs.NonIndexed().Pack(uint8(2), [32]byte{3}, nonce, []byte{}, []byte{})
+	s.Nil(err)
+	return &types.Log{
+		Address:     common.HexToAddress("0x5798e01f4b1d8f6a5d91167414f3a915d021bc4a"),
+		Data:        data,
+		Topics:      []common.Hash{events.DepositSig.GetTopic(), common.BytesToHash(common.HexToAddress("0x1").Bytes())},
+		BlockNumber: 14,
+		Index:       index,
+	}
+}
+
+func (s *ListenerTestSuite) feeCollectedLog(index uint, fee int64, token common.Address) *types.Log {
+	feeHandlerAbi, _ := abi.JSON(strings.NewReader(consts.FeeHandlerABI))
+	data, err := feeHandlerAbi.Events["FeeCollected"].Inputs.Pack(common.HexToAddress("0x1"), uint8(1), uint8(2), [32]byte{3}, big.NewInt(fee), token)
+	s.Nil(err)
+	return &types.Log{
+		Address:     common.HexToAddress("0x8dA96a8C2b2d3e5ae7e668d0C94393aa8D5D3B94"),
+		Data:        data,
+		Topics:      []common.Hash{events.FeeCollectedSig.GetTopic()},
+		BlockNumber: 14,
+		Index:       index,
+	}
+}
+
+func (s *ListenerTestSuite) Test_FetchRetryDepositEvents_MatchesFeePerDeposit() {
+	s.mockClient.EXPECT().WaitAndReturnTxReceipt(gomock.Any()).Return(&types.Receipt{
+		BlockNumber: big.NewInt(14),
+		Logs: []*types.Log{
+			s.feeCollectedLog(0, 100, common.Address{}),
+			s.depositLog(1, 1),
+			s.feeCollectedLog(2, 200, common.Address{}),
+			s.depositLog(3, 2),
+			s.depositLog(4, 3),
+		},
+	}, nil)
+	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(20), nil)
+	s.mockClient.EXPECT().BlockByNumber(gomock.Any(), big.NewInt(14)).Return(nil, fmt.Errorf("error")).Times(3)
+
+	deposits, err := s.listener.FetchRetryDepositEvents(
+		events.RetryV1Event{TxHash: "0xf25ed4a14bf7ad20354b46fe38d7d4525f2ea3042db9a9954ef8d73c558b500c"},
+		common.HexToAddress("0x5798e01f4b1d8f6a5d91167414f3a915d021bc4a"),
+		big.NewInt(5),
+	)
+
+	s.Nil(err)
+	s.Equal(len(deposits), 3)
+	s.Equal(deposits[0].Fee, big.NewInt(100))
+	s.Equal(deposits[1].Fee, big.NewInt(200))
+	s.Nil(deposits[2].Fee)
+}
+
+func (s *ListenerTestSuite) Test_FetchDeposits_TokenFeeIsUnknown() {
+	deposit := s.depositLog(1, 1)
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), deposit.Address, string(events.DepositSig), big.NewInt(14), big.NewInt(14)).Return([]types.Log{*deposit}, nil)
+	s.mockClient.EXPECT().BlockByNumber(gomock.Any(), big.NewInt(14)).Return(nil, fmt.Errorf("error"))
+	s.mockClient.EXPECT().TransactionReceipt(gomock.Any(), deposit.TxHash).Return(&types.Receipt{
+		Logs: []*types.Log{
+			s.feeCollectedLog(0, 100, common.HexToAddress("0x2")),
+			deposit,
+		},
+	}, nil)
+
+	deposits, err := s.listener.FetchDeposits(context.Background(), deposit.Address, big.NewInt(14), big.NewInt(14))
+
+	s.Nil(err)
+	s.Equal(len(deposits), 1)
+	s.Nil(deposits[0].Fee)
+}
+
+func (s *ListenerTestSuite) Test_FetchDeposits_NativeFee() {
+	deposit := s.depositLog(1, 1)
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), deposit.Address, string(events.DepositSig), big.NewInt(14), big.NewInt(14)).Return([]types.Log{*deposit}, nil)
+	s.mockClient.EXPECT().BlockByNumber(gomock.Any(), big.NewInt(14)).Return(nil, fmt.Errorf("error"))
+	s.mockClient.EXPECT().TransactionReceipt(gomock.Any(), deposit.TxHash).Return(&types.Receipt{
+		Logs: []*types.Log{
+			s.feeCollectedLog(0, 100, common.Address{}),
+			deposit,
+		},
+	}, nil)
+
+	deposits, err := s.listener.FetchDeposits(context.Background(), deposit.Address, big.NewInt(14), big.NewInt(14))
+
+	s.Nil(err)
+	s.Equal(len(deposits), 1)
+	s.Equal(deposits[0].Fee, big.NewInt(100))
+}
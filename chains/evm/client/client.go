@@ -0,0 +1,530 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
+)
+
+var (
+	ErrQuorumNotReached = errors.New("endpoint quorum not reached")
+
+	// QuorumCallTimeout is the time limit of a single endpoint call made for a quorum read
+	QuorumCallTimeout = 30 * time.Second
+)
+
+type EndpointClient interface {
+	client.Client
+	ChainID(ctx context.Context) (*big.Int, error)
+	LatestBlock() (*big.Int, error)
+	FetchEventLogs(ctx context.Context, contractAddress common.Address, event string, startBlock *big.Int, endBlock *big.Int) ([]ethTypes.Log, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*ethTypes.Block, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	BaseFee() (*big.Int, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+type EndpointMeter interface {
+	TrackEndpointCall(domainID uint8, endpoint string, latency time.Duration, failed bool)
+}
+
+type Endpoint struct {
+	Name   string
+	Health *EndpointHealth
+
+	client EndpointClient
+	dial   func() (EndpointClient, error)
+	lock   sync.Mutex
+}
+
+// NewEndpoint wraps endpoint client with health tracking. Name is derived from
+// the endpoint URL host so that API keys in paths do not end up in logs and metrics.
+func NewEndpoint(rawURL string, client EndpointClient, cooldown time.Duration) *Endpoint {
+	return &Endpoint{
+		Name:   endpointName(rawURL),
+		Health: NewEndpointHealth(cooldown),
+		client: client,
+	}
+}
+
+// NewUnreachableEndpoint creates endpoint that could not be dialed. The endpoint
+// starts in cooldown and is dialed again once the cooldown expires.
+func NewUnreachableEndpoint(rawURL string, dial func() (EndpointClient, error), cooldown time.Duration) *Endpoint {
+	health := NewEndpointHealth(cooldown)
+	health.MarkUnhealthy()
+	return &Endpoint{
+		Name:   endpointName(rawURL),
+		Health: health,
+		dial:   dial,
+	}
+}
+
+// DialEndpoints dials endpoints with the provided urls where the first url is the primary endpoint.
+// Endpoints that can not be dialed are logged and added as unreachable endpoints.
+// Error is returned only if neither the primary endpoint nor a quorum of endpoints can be dialed.
+func DialEndpoints(
+	urls []string,
+	dial func(url string) (EndpointClient, error),
+	cooldown time.Duration,
+	quorum int,
+) ([]*Endpoint, error) {
+	endpoints := make([]*Endpoint, len(urls))
+	primaryDialed := false
+	dialed := 0
+	for i, url := range urls {
+		url := url
+		client, err := dial(url)
+		if err != nil {
+			log.Warn().Err(err).Str("endpoint", endpointName(url)).Msgf("Unable to dial endpoint, retrying after cooldown")
+			endpoints[i] = NewUnreachableEndpoint(url, func() (EndpointClient, error) { return dial(url) }, cooldown)
+			continue
+		}
+
+		endpoints[i] = NewEndpoint(url, client, cooldown)
+		dialed++
+		if i == 0 {
+			primaryDialed = true
+		}
+	}
+
+	if !primaryDialed && (dialed == 0 || dialed < quorum) {
+		return nil, fmt.Errorf("unable to dial primary endpoint or quorum of endpoints, dialed %d of %d", dialed, len(urls))
+	}
+	return endpoints, nil
+}
+
+func endpointName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err == nil && u.Host != "" {
+		return u.Host
+	}
+	return rawURL
+}
+
+// connect returns the endpoint client, dialing endpoints that were unreachable
+func (e *Endpoint) connect() (EndpointClient, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.client != nil {
+		return e.client, nil
+	}
+	client, err := e.dial()
+	if err != nil {
+		return nil, err
+	}
+	e.client = client
+	return client, nil
+}
+
+// MultiEndpointClient is an EVM client that spreads calls over multiple RPC endpoints.
+// Calls are sent to the healthiest endpoint first and fail over to the next one on error.
+// Deposit log fetching and contract calls can optionally require matching responses
+// from a quorum of endpoints so a single faulty endpoint can not inject or hide data.
+type MultiEndpointClient struct {
+	endpoints []*Endpoint
+	quorum    int
+	domainID  uint8
+	metrics   EndpointMeter
+	log       zerolog.Logger
+
+	from      common.Address
+	nonce     *big.Int
+	nonceLock sync.Mutex
+}
+
+// NewMultiEndpointClient creates a client that fails over between provided endpoints.
+// Quorum of 0 or 1 disables quorum reads.
+func NewMultiEndpointClient(
+	domainID uint8,
+	from common.Address,
+	endpoints []*Endpoint,
+	quorum int,
+	metrics EndpointMeter,
+) (*MultiEndpointClient, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("at least one endpoint required")
+	}
+	if quorum > len(endpoints) {
+		return nil, fmt.Errorf("quorum %d larger than number of endpoints %d", quorum, len(endpoints))
+	}
+
+	return &MultiEndpointClient{
+		endpoints: endpoints,
+		quorum:    quorum,
+		domainID:  domainID,
+		metrics:   metrics,
+		from:      from,
+		log:       log.With().Uint8("domainID", domainID).Logger(),
+	}, nil
+}
+
+// rankedEndpoints returns available endpoints sorted by health score with
+// endpoints in cooldown appended at the end as a last resort
+func (c *MultiEndpointClient) rankedEndpoints() []*Endpoint {
+	available := make([]*Endpoint, 0, len(c.endpoints))
+	cooldown := make([]*Endpoint, 0)
+	for _, e := range c.endpoints {
+		if e.Health.Available() {
+			available = append(available, e)
+		} else {
+			cooldown = append(cooldown, e)
+		}
+	}
+	sort.SliceStable(available, func(i, j int) bool {
+		return available[i].Health.Score() < available[j].Health.Score()
+	})
+	return append(available, cooldown...)
+}
+
+func (c *MultiEndpointClient) track(e *Endpoint, f func(client EndpointClient) error) error {
+	start := time.Now()
+	err := c.execute(e, f)
+	c.record(e, time.Since(start), err)
+	return err
+}
+
+func (c *MultiEndpointClient) execute(e *Endpoint, f func(client EndpointClient) error) error {
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+	return f(client)
+}
+
+func (c *MultiEndpointClient) record(e *Endpoint, latency time.Duration, err error) {
+	e.Health.Record(latency, err)
+	if c.metrics != nil {
+		c.metrics.TrackEndpointCall(c.domainID, e.Name, latency, err != nil)
+	}
+}
+
+// call executes f against endpoints in order of their health until one succeeds
+func (c *MultiEndpointClient) call(f func(client EndpointClient) error) error {
+	var err error
+	for _, e := range c.rankedEndpoints() {
+		err = c.track(e, f)
+		if err == nil {
+			return nil
+		}
+
+		c.log.Warn().Err(err).Str("endpoint", e.Name).Msgf("Endpoint call failed, failing over")
+	}
+	return fmt.Errorf("all endpoints failed: %w", err)
+}
+
+type quorumResult struct {
+	endpoint *Endpoint
+	value    interface{}
+	err      error
+}
+
+// quorumCall executes f in parallel against endpoints that are not in cooldown and returns
+// the result as soon as quorum endpoints agree upon it. Each endpoint call is limited by
+// QuorumCallTimeout so a hanging endpoint can not stall the call.
+func (c *MultiEndpointClient) quorumCall(ctx context.Context, f func(ctx context.Context, client EndpointClient) (interface{}, error)) (interface{}, error) {
+	endpoints := make([]*Endpoint, 0, len(c.endpoints))
+	for _, e := range c.endpoints {
+		if e.Health.Available() {
+			endpoints = append(endpoints, e)
+		}
+	}
+	if len(endpoints) < c.quorum {
+		return nil, fmt.Errorf("%w: %d of %d endpoints available, required %d", ErrQuorumNotReached, len(endpoints), len(c.endpoints), c.quorum)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan quorumResult, len(endpoints))
+	for _, e := range endpoints {
+		go func(e *Endpoint) {
+			callCtx, cancelCall := context.WithTimeout(ctx, QuorumCallTimeout)
+			defer cancelCall()
+
+			var value interface{}
+			start := time.Now()
+			err := c.execute(e, func(client EndpointClient) error {
+				var err error
+				value, err = f(callCtx, client)
+				return err
+			})
+			// calls cancelled after the quorum was reached do not affect endpoint health
+			if ctx.Err() == nil {
+				c.record(e, time.Since(start), err)
+			}
+			results <- quorumResult{endpoint: e, value: value, err: err}
+		}(e)
+	}
+
+	votes := make(map[common.Hash]int)
+	for i := 0; i < len(endpoints); i++ {
+		r := <-results
+		if r.err != nil {
+			c.log.Warn().Err(r.err).Str("endpoint", r.endpoint.Name).Msgf("Endpoint quorum call failed")
+			continue
+		}
+
+		hash, err := resultHash(r.value)
+		if err != nil {
+			return nil, err
+		}
+		votes[hash]++
+		if votes[hash] >= c.quorum {
+			return r.value, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: required %d matching responses from %d endpoints", ErrQuorumNotReached, c.quorum, len(endpoints))
+}
+
+func resultHash(value interface{}) (common.Hash, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(bytes), nil
+}
+
+func (c *MultiEndpointClient) quorumEnabled() bool {
+	return c.quorum > 1
+}
+
+// FetchEventLogs fetches logs for the provided event, requiring quorum if it is enabled
+func (c *MultiEndpointClient) FetchEventLogs(ctx context.Context, contractAddress common.Address, event string, startBlock *big.Int, endBlock *big.Int) ([]ethTypes.Log, error) {
+	if c.quorumEnabled() {
+		logs, err := c.quorumCall(ctx, func(ctx context.Context, client EndpointClient) (interface{}, error) {
+			return client.FetchEventLogs(ctx, contractAddress, event, startBlock, endBlock)
+		})
+		if err != nil {
+			return []ethTypes.Log{}, err
+		}
+		return logs.([]ethTypes.Log), nil
+	}
+
+	var logs []ethTypes.Log
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		logs, err = client.FetchEventLogs(ctx, contractAddress, event, startBlock, endBlock)
+		return err
+	})
+	return logs, err
+}
+
+// CallContract executes eth_call, requiring quorum if it is enabled
+func (c *MultiEndpointClient) CallContract(ctx context.Context, callArgs map[string]interface{}, blockNumber *big.Int) ([]byte, error) {
+	if c.quorumEnabled() {
+		res, err := c.quorumCall(ctx, func(ctx context.Context, client EndpointClient) (interface{}, error) {
+			return client.CallContract(ctx, callArgs, blockNumber)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.([]byte), nil
+	}
+
+	var res []byte
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		res, err = client.CallContract(ctx, callArgs, blockNumber)
+		return err
+	})
+	return res, err
+}
+
+func (c *MultiEndpointClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code []byte
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		code, err = client.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (c *MultiEndpointClient) LatestBlock() (*big.Int, error) {
+	var head *big.Int
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		head, err = client.LatestBlock()
+		return err
+	})
+	return head, err
+}
+
+func (c *MultiEndpointClient) BlockByNumber(ctx context.Context, number *big.Int) (*ethTypes.Block, error) {
+	var block *ethTypes.Block
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		block, err = client.BlockByNumber(ctx, number)
+		return err
+	})
+	return block, err
+}
+
+func (c *MultiEndpointClient) ChainID(ctx context.Context) (*big.Int, error) {
+	var id *big.Int
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		id, err = client.ChainID(ctx)
+		return err
+	})
+	return id, err
+}
+
+func (c *MultiEndpointClient) BaseFee() (*big.Int, error) {
+	var fee *big.Int
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		fee, err = client.BaseFee()
+		return err
+	})
+	return fee, err
+}
+
+func (c *MultiEndpointClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var price *big.Int
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		price, err = client.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+func (c *MultiEndpointClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var tip *big.Int
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		tip, err = client.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+func (c *MultiEndpointClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var gas uint64
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		gas, err = client.EstimateGas(ctx, msg)
+		return err
+	})
+	return gas, err
+}
+
+func (c *MultiEndpointClient) SignAndSendTransaction(ctx context.Context, tx client.CommonTransaction) (common.Hash, error) {
+	var hash common.Hash
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		hash, err = client.SignAndSendTransaction(ctx, tx)
+		return err
+	})
+	return hash, err
+}
+
+// TransactionReceipt fetches the transaction receipt, requiring quorum if it is enabled
+func (c *MultiEndpointClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethTypes.Receipt, error) {
+	if c.quorumEnabled() {
+		res, err := c.quorumCall(ctx, func(ctx context.Context, client EndpointClient) (interface{}, error) {
+			return client.TransactionReceipt(ctx, txHash)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.(*ethTypes.Receipt), nil
+	}
+
+	var receipt *ethTypes.Receipt
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		receipt, err = client.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+func (c *MultiEndpointClient) GetTransactionByHash(h common.Hash) (*ethTypes.Transaction, bool, error) {
+	var tx *ethTypes.Transaction
+	var isPending bool
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		tx, isPending, err = client.GetTransactionByHash(h)
+		return err
+	})
+	return tx, isPending, err
+}
+
+func (c *MultiEndpointClient) WaitAndReturnTxReceipt(h common.Hash) (*ethTypes.Receipt, error) {
+	retry := 50
+	for retry > 0 {
+		receipt, err := c.TransactionReceipt(context.Background(), h)
+		if err != nil {
+			retry--
+			time.Sleep(5 * time.Second)
+			continue
+		}
+		if receipt.Status != 1 {
+			return receipt, fmt.Errorf("transaction failed on chain. Receipt status %v", receipt.Status)
+		}
+		return receipt, nil
+	}
+	return nil, errors.New("tx did not appear")
+}
+
+func (c *MultiEndpointClient) From() common.Address {
+	return c.from
+}
+
+func (c *MultiEndpointClient) LockNonce() {
+	c.nonceLock.Lock()
+}
+
+func (c *MultiEndpointClient) UnlockNonce() {
+	c.nonceLock.Unlock()
+}
+
+// UnsafeNonce returns locally tracked nonce. Nonce is tracked by the wrapper
+// instead of individual endpoint clients so it stays consistent between failovers.
+func (c *MultiEndpointClient) UnsafeNonce() (*big.Int, error) {
+	if c.nonce != nil {
+		return c.nonce, nil
+	}
+
+	var nonce uint64
+	err := c.call(func(client EndpointClient) error {
+		var err error
+		nonce, err = client.PendingNonceAt(context.Background(), c.from)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.nonce = new(big.Int).SetUint64(nonce)
+	return c.nonce, nil
+}
+
+func (c *MultiEndpointClient) UnsafeIncreaseNonce() error {
+	nonce, err := c.UnsafeNonce()
+	if err != nil {
+		return err
+	}
+	c.nonce = nonce.Add(nonce, big.NewInt(1))
+	return nil
+}
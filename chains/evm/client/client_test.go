@@ -0,0 +1,281 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/client"
+	mock_client "github.com/ChainSafe/sygma-relayer/chains/evm/client/mock"
+)
+
+type MultiEndpointClientTestSuite struct {
+	suite.Suite
+	mockEndpoint1 *mock_client.MockEndpointClient
+	mockEndpoint2 *mock_client.MockEndpointClient
+	mockEndpoint3 *mock_client.MockEndpointClient
+	endpoints     []*client.Endpoint
+}
+
+func TestRunMultiEndpointClientTestSuite(t *testing.T) {
+	suite.Run(t, new(MultiEndpointClientTestSuite))
+}
+
+func (s *MultiEndpointClientTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockEndpoint1 = mock_client.NewMockEndpointClient(ctrl)
+	s.mockEndpoint2 = mock_client.NewMockEndpointClient(ctrl)
+	s.mockEndpoint3 = mock_client.NewMockEndpointClient(ctrl)
+	s.endpoints = []*client.Endpoint{
+		client.NewEndpoint("https://endpoint1.com/key", s.mockEndpoint1, time.Minute),
+		client.NewEndpoint("https://endpoint2.com/key", s.mockEndpoint2, time.Minute),
+		client.NewEndpoint("https://endpoint3.com/key", s.mockEndpoint3, time.Minute),
+	}
+}
+
+func (s *MultiEndpointClientTestSuite) Test_NewEndpoint_RedactsURL() {
+	s.Equal(s.endpoints[0].Name, "endpoint1.com")
+}
+
+func (s *MultiEndpointClientTestSuite) Test_NewMultiEndpointClient_InvalidQuorum() {
+	_, err := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 4, nil)
+
+	s.NotNil(err)
+}
+
+func (s *MultiEndpointClientTestSuite) Test_LatestBlock_FailsOver() {
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 0, nil)
+	s.mockEndpoint1.EXPECT().LatestBlock().Return(nil, fmt.Errorf("error"))
+	s.mockEndpoint2.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+
+	head, err := c.LatestBlock()
+
+	s.Nil(err)
+	s.Equal(head, big.NewInt(100))
+}
+
+func (s *MultiEndpointClientTestSuite) Test_LatestBlock_AllEndpointsFail() {
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 0, nil)
+	s.mockEndpoint1.EXPECT().LatestBlock().Return(nil, fmt.Errorf("error"))
+	s.mockEndpoint2.EXPECT().LatestBlock().Return(nil, fmt.Errorf("error"))
+	s.mockEndpoint3.EXPECT().LatestBlock().Return(nil, fmt.Errorf("error"))
+
+	_, err := c.LatestBlock()
+
+	s.NotNil(err)
+}
+
+func (s *MultiEndpointClientTestSuite) Test_LatestBlock_PrefersHealthyEndpoint() {
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints[:2], 0, nil)
+	s.mockEndpoint1.EXPECT().LatestBlock().Return(nil, fmt.Errorf("error"))
+	s.mockEndpoint2.EXPECT().LatestBlock().Return(big.NewInt(100), nil).Times(2)
+
+	_, err := c.LatestBlock()
+	s.Nil(err)
+	_, err = c.LatestBlock()
+	s.Nil(err)
+}
+
+func (s *MultiEndpointClientTestSuite) Test_FetchEventLogs_QuorumReached() {
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 2, nil)
+	logs := []ethTypes.Log{{BlockNumber: 1, TxHash: common.HexToHash("0x1")}}
+	s.mockEndpoint1.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, nil)
+	s.mockEndpoint2.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]ethTypes.Log{}, nil).MaxTimes(1)
+	s.mockEndpoint3.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, nil)
+
+	fetchedLogs, err := c.FetchEventLogs(context.Background(), common.Address{}, "Deposit", big.NewInt(0), big.NewInt(1))
+
+	s.Nil(err)
+	s.Equal(fetchedLogs, logs)
+}
+
+func (s *MultiEndpointClientTestSuite) Test_FetchEventLogs_QuorumNotReached() {
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 2, nil)
+	s.mockEndpoint1.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		[]ethTypes.Log{{BlockNumber: 1, TxHash: common.HexToHash("0x1")}}, nil)
+	s.mockEndpoint2.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]ethTypes.Log{}, nil)
+	s.mockEndpoint3.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+
+	_, err := c.FetchEventLogs(context.Background(), common.Address{}, "Deposit", big.NewInt(0), big.NewInt(1))
+
+	s.True(errors.Is(err, client.ErrQuorumNotReached))
+}
+
+func (s *MultiEndpointClientTestSuite) Test_TransactionReceipt_QuorumReached() {
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 2, nil)
+	receipt := &ethTypes.Receipt{Status: 1, TxHash: common.HexToHash("0x1"), Logs: []*ethTypes.Log{{Data: []byte{1}}}}
+	s.mockEndpoint1.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x1")).Return(receipt, nil)
+	s.mockEndpoint2.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x1")).Return(&ethTypes.Receipt{Status: 1, TxHash: common.HexToHash("0x1")}, nil).MaxTimes(1)
+	s.mockEndpoint3.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x1")).Return(receipt, nil)
+
+	fetchedReceipt, err := c.TransactionReceipt(context.Background(), common.HexToHash("0x1"))
+
+	s.Nil(err)
+	s.Equal(fetchedReceipt, receipt)
+}
+
+func (s *MultiEndpointClientTestSuite) Test_TransactionReceipt_QuorumNotReached() {
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 2, nil)
+	s.mockEndpoint1.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x1")).Return(
+		&ethTypes.Receipt{Status: 1, TxHash: common.HexToHash("0x1"), Logs: []*ethTypes.Log{{Data: []byte{1}}}}, nil)
+	s.mockEndpoint2.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x1")).Return(&ethTypes.Receipt{Status: 1, TxHash: common.HexToHash("0x1")}, nil)
+	s.mockEndpoint3.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x1")).Return(nil, fmt.Errorf("error"))
+
+	_, err := c.TransactionReceipt(context.Background(), common.HexToHash("0x1"))
+
+	s.True(errors.Is(err, client.ErrQuorumNotReached))
+}
+
+func (s *MultiEndpointClientTestSuite) Test_FetchEventLogs_ReturnsOnQuorum() {
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 2, nil)
+	logs := []ethTypes.Log{{BlockNumber: 1, TxHash: common.HexToHash("0x1")}}
+	s.mockEndpoint1.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, nil)
+	s.mockEndpoint2.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, nil)
+	s.mockEndpoint3.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, address common.Address, event string, startBlock *big.Int, endBlock *big.Int) ([]ethTypes.Log, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).MaxTimes(1)
+
+	start := time.Now()
+	fetchedLogs, err := c.FetchEventLogs(context.Background(), common.Address{}, "Deposit", big.NewInt(0), big.NewInt(1))
+
+	s.Nil(err)
+	s.Equal(fetchedLogs, logs)
+	s.Less(time.Since(start), client.QuorumCallTimeout)
+	s.True(s.endpoints[2].Health.Available())
+}
+
+func (s *MultiEndpointClientTestSuite) Test_FetchEventLogs_HangingEndpointTimesOut() {
+	timeout := client.QuorumCallTimeout
+	client.QuorumCallTimeout = 50 * time.Millisecond
+	defer func() { client.QuorumCallTimeout = timeout }()
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 2, nil)
+	s.mockEndpoint1.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]ethTypes.Log{}, nil)
+	s.mockEndpoint2.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+	s.mockEndpoint3.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, address common.Address, event string, startBlock *big.Int, endBlock *big.Int) ([]ethTypes.Log, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+
+	_, err := c.FetchEventLogs(context.Background(), common.Address{}, "Deposit", big.NewInt(0), big.NewInt(1))
+
+	s.True(errors.Is(err, client.ErrQuorumNotReached))
+}
+
+func (s *MultiEndpointClientTestSuite) Test_FetchEventLogs_SkipsUnhealthyEndpoints() {
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 2, nil)
+	s.endpoints[0].Health.MarkUnhealthy()
+	logs := []ethTypes.Log{{BlockNumber: 1, TxHash: common.HexToHash("0x1")}}
+	s.mockEndpoint2.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, nil)
+	s.mockEndpoint3.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, nil)
+
+	fetchedLogs, err := c.FetchEventLogs(context.Background(), common.Address{}, "Deposit", big.NewInt(0), big.NewInt(1))
+
+	s.Nil(err)
+	s.Equal(fetchedLogs, logs)
+}
+
+func (s *MultiEndpointClientTestSuite) Test_FetchEventLogs_NotEnoughHealthyEndpoints() {
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 2, nil)
+	s.endpoints[0].Health.MarkUnhealthy()
+	s.endpoints[1].Health.MarkUnhealthy()
+
+	_, err := c.FetchEventLogs(context.Background(), common.Address{}, "Deposit", big.NewInt(0), big.NewInt(1))
+
+	s.True(errors.Is(err, client.ErrQuorumNotReached))
+}
+
+func (s *MultiEndpointClientTestSuite) Test_DialEndpoints_FallbackUnreachable() {
+	endpoints, err := client.DialEndpoints(
+		[]string{"https://endpoint1.com", "https://endpoint2.com"},
+		func(url string) (client.EndpointClient, error) {
+			if url == "https://endpoint2.com" {
+				return nil, fmt.Errorf("error")
+			}
+			return s.mockEndpoint1, nil
+		},
+		time.Minute,
+		0,
+	)
+
+	s.Nil(err)
+	s.Equal(len(endpoints), 2)
+	s.True(endpoints[0].Health.Available())
+	s.False(endpoints[1].Health.Available())
+}
+
+func (s *MultiEndpointClientTestSuite) Test_DialEndpoints_PrimaryAndQuorumUnreachable() {
+	_, err := client.DialEndpoints(
+		[]string{"https://endpoint1.com", "https://endpoint2.com", "https://endpoint3.com"},
+		func(url string) (client.EndpointClient, error) {
+			if url == "https://endpoint3.com" {
+				return s.mockEndpoint3, nil
+			}
+			return nil, fmt.Errorf("error")
+		},
+		time.Minute,
+		2,
+	)
+
+	s.NotNil(err)
+}
+
+func (s *MultiEndpointClientTestSuite) Test_DialEndpoints_PrimaryUnreachableQuorumDialed() {
+	endpoints, err := client.DialEndpoints(
+		[]string{"https://endpoint1.com", "https://endpoint2.com", "https://endpoint3.com"},
+		func(url string) (client.EndpointClient, error) {
+			if url == "https://endpoint1.com" {
+				return nil, fmt.Errorf("error")
+			}
+			return s.mockEndpoint2, nil
+		},
+		time.Minute,
+		2,
+	)
+
+	s.Nil(err)
+	s.Equal(len(endpoints), 3)
+}
+
+func (s *MultiEndpointClientTestSuite) Test_UnreachableEndpoint_RedialedAfterCooldown() {
+	dials := 0
+	endpoint := client.NewUnreachableEndpoint("https://endpoint1.com", func() (client.EndpointClient, error) {
+		dials++
+		return s.mockEndpoint1, nil
+	}, 0)
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, []*client.Endpoint{endpoint}, 0, nil)
+	s.mockEndpoint1.EXPECT().LatestBlock().Return(big.NewInt(100), nil).Times(2)
+
+	_, err := c.LatestBlock()
+	s.Nil(err)
+	head, err := c.LatestBlock()
+
+	s.Nil(err)
+	s.Equal(head, big.NewInt(100))
+	s.Equal(dials, 1)
+}
+
+func (s *MultiEndpointClientTestSuite) Test_UnsafeNonce_TrackedAcrossEndpoints() {
+	c, _ := client.NewMultiEndpointClient(1, common.Address{}, s.endpoints, 0, nil)
+	s.mockEndpoint1.EXPECT().PendingNonceAt(gomock.Any(), gomock.Any()).Return(uint64(5), nil)
+
+	err := c.UnsafeIncreaseNonce()
+	s.Nil(err)
+	nonce, err := c.UnsafeNonce()
+
+	s.Nil(err)
+	s.Equal(nonce, big.NewInt(6))
+}
@@ -0,0 +1,102 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// smoothingFactor is the weight of the newest sample in the moving averages
+	smoothingFactor = 0.2
+	// maxConsecutiveFailures is the number of failures in a row after which
+	// the endpoint is put in cooldown
+	maxConsecutiveFailures = 3
+	// errorPenalty is the latency penalty applied per unit of error rate when scoring
+	errorPenalty = 10 * time.Second
+)
+
+// EndpointHealth tracks latency and error rate of a single RPC endpoint
+type EndpointHealth struct {
+	lock                sync.RWMutex
+	latency             time.Duration
+	errorRate           float64
+	consecutiveFailures int
+	lastFailure         time.Time
+	cooldown            time.Duration
+}
+
+func NewEndpointHealth(cooldown time.Duration) *EndpointHealth {
+	return &EndpointHealth{
+		cooldown: cooldown,
+	}
+}
+
+// Record updates moving averages with the outcome of a single call
+func (h *EndpointHealth) Record(latency time.Duration, err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.latency == 0 {
+		h.latency = latency
+	} else {
+		h.latency = time.Duration(smoothingFactor*float64(latency) + (1-smoothingFactor)*float64(h.latency))
+	}
+
+	sample := 0.0
+	if err != nil {
+		sample = 1.0
+		h.consecutiveFailures++
+		h.lastFailure = time.Now()
+	} else {
+		h.consecutiveFailures = 0
+	}
+	h.errorRate = smoothingFactor*sample + (1-smoothingFactor)*h.errorRate
+}
+
+// MarkUnhealthy puts the endpoint in cooldown
+func (h *EndpointHealth) MarkUnhealthy() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.consecutiveFailures = maxConsecutiveFailures
+	h.lastFailure = time.Now()
+	h.errorRate = 1
+}
+
+// Available returns false if the endpoint failed too many times in a row
+// and the cooldown period has not yet expired
+func (h *EndpointHealth) Available() bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if h.consecutiveFailures < maxConsecutiveFailures {
+		return true
+	}
+	return time.Since(h.lastFailure) > h.cooldown
+}
+
+// Score returns the endpoint score where lower is better.
+// Score is latency penalized by the recent error rate.
+func (h *EndpointHealth) Score() time.Duration {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.latency + time.Duration(h.errorRate*float64(errorPenalty))
+}
+
+func (h *EndpointHealth) Latency() time.Duration {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.latency
+}
+
+func (h *EndpointHealth) ErrorRate() float64 {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.errorRate
+}
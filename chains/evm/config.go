@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/mitchellh/mapstructure"
 
+	"github.com/ChainSafe/sygma-relayer/chains/evm/policy"
 	"github.com/ChainSafe/sygma-relayer/config/chain"
 	"github.com/sygmaprotocol/sygma-core/crypto/secp256k1"
 )
@@ -31,17 +32,23 @@ type EVMConfig struct {
 	GasMultiplier         *big.Float
 	GasLimit              *big.Int
 	TransferGas           uint64
+	GasEstimationMargin   uint64
 	GasIncreasePercentage *big.Int
 	StartBlock            *big.Int
 	BlockConfirmations    *big.Int
 	BlockInterval         *big.Int
 	BlockRetryInterval    time.Duration
+	FallbackEndpoints     []string
+	EndpointQuorum        int
+	EndpointCooldown      time.Duration
+	Resources             []chain.ResourceConfig
+	GenericPolicy         *policy.GenericPolicy
 }
 
 func (c *EVMConfig) String() string {
 	privateKey, _ := crypto.HexToECDSA(c.GeneralChainConfig.Key)
 	kp := secp256k1.NewKeypair(*privateKey)
-	return fmt.Sprintf(`Name: '%s', Id: '%d', Type: '%s', BlockstorePath: '%s', FreshStart: '%t', LatestBlock: '%t', Key address: '%s', Bridge: '%s', Retry: '%s', Handlers: %+v, MaxGasPrice: '%s', GasMultiplier: '%s', GasLimit: '%s', TransferGas: '%d', StartBlock: '%s', BlockConfirmations: '%s', BlockInterval: '%s', BlockRetryInterval: '%s'`,
+	return fmt.Sprintf(`Name: '%s', Id: '%d', Type: '%s', BlockstorePath: '%s', FreshStart: '%t', LatestBlock: '%t', Key address: '%s', Bridge: '%s', Retry: '%s', Handlers: %+v, MaxGasPrice: '%s', GasMultiplier: '%s', GasLimit: '%s', TransferGas: '%d', GasEstimationMargin: '%d', StartBlock: '%s', BlockConfirmations: '%s', BlockInterval: '%s', BlockRetryInterval: '%s', FallbackEndpoints: '%d', EndpointQuorum: '%d'`,
 		c.GeneralChainConfig.Name,
 		*c.GeneralChainConfig.Id,
 		c.GeneralChainConfig.Type,
@@ -56,28 +63,37 @@ func (c *EVMConfig) String() string {
 		c.GasMultiplier,
 		c.GasLimit,
 		c.TransferGas,
+		c.GasEstimationMargin,
 		c.StartBlock,
 		c.BlockConfirmations,
 		c.BlockInterval,
 		c.BlockRetryInterval,
+		len(c.FallbackEndpoints),
+		c.EndpointQuorum,
 	)
 }
 
 type RawEVMConfig struct {
 	chain.GeneralChainConfig `mapstructure:",squash"`
-	Bridge                   string          `mapstructure:"bridge"`
-	Retry                    string          `mapstructure:"retry"`
-	FrostKeygen              string          `mapstructure:"frostKeygen"`
-	Handlers                 []HandlerConfig `mapstrcture:"handlers"`
-	MaxGasPrice              int64           `mapstructure:"maxGasPrice" default:"500000000000"`
-	GasMultiplier            float64         `mapstructure:"gasMultiplier" default:"1"`
-	GasIncreasePercentage    int64           `mapstructure:"gasIncreasePercentage" default:"15"`
-	GasLimit                 int64           `mapstructure:"gasLimit" default:"15000000"`
-	TransferGas              uint64          `mapstructure:"transferGas" default:"250000"`
-	StartBlock               int64           `mapstructure:"startBlock"`
-	BlockConfirmations       int64           `mapstructure:"blockConfirmations" default:"10"`
-	BlockInterval            int64           `mapstructure:"blockInterval" default:"5"`
-	BlockRetryInterval       uint64          `mapstructure:"blockRetryInterval" default:"5"`
+	Bridge                   string                    `mapstructure:"bridge"`
+	Retry                    string                    `mapstructure:"retry"`
+	FrostKeygen              string                    `mapstructure:"frostKeygen"`
+	Handlers                 []HandlerConfig           `mapstrcture:"handlers"`
+	MaxGasPrice              int64                     `mapstructure:"maxGasPrice" default:"500000000000"`
+	GasMultiplier            float64                   `mapstructure:"gasMultiplier" default:"1"`
+	GasIncreasePercentage    int64                     `mapstructure:"gasIncreasePercentage" default:"15"`
+	GasLimit                 int64                     `mapstructure:"gasLimit" default:"15000000"`
+	TransferGas              uint64                    `mapstructure:"transferGas" default:"250000"`
+	GasEstimationMargin      uint64                    `mapstructure:"gasEstimationMargin" default:"20"`
+	StartBlock               int64                     `mapstructure:"startBlock"`
+	BlockConfirmations       int64                     `mapstructure:"blockConfirmations" default:"10"`
+	BlockInterval            int64                     `mapstructure:"blockInterval" default:"5"`
+	BlockRetryInterval       uint64                    `mapstructure:"blockRetryInterval" default:"5"`
+	FallbackEndpoints        []string                  `mapstructure:"fallbackEndpoints"`
+	EndpointQuorum           int                       `mapstructure:"endpointQuorum"`
+	EndpointCooldown         uint64                    `mapstructure:"endpointCooldown" default:"60"`
+	Resources                []chain.RawResourceConfig `mapstructure:"resources"`
+	GenericPolicy            *policy.RawGenericPolicy  `mapstructure:"genericPolicy"`
 }
 
 func (c *RawEVMConfig) Validate() error {
@@ -90,6 +106,9 @@ func (c *RawEVMConfig) Validate() error {
 	if c.BlockConfirmations < 1 {
 		return fmt.Errorf("blockConfirmations has to be >=1")
 	}
+	if c.EndpointQuorum > len(c.FallbackEndpoints)+1 {
+		return fmt.Errorf("endpointQuorum can not be larger than the number of endpoints")
+	}
 	return nil
 }
 
@@ -112,6 +131,16 @@ func NewEVMConfig(chainConfig map[string]interface{}) (*EVMConfig, error) {
 		return nil, err
 	}
 
+	resources, err := chain.NewResourceConfigs(c.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	genericPolicy, err := policy.NewGenericPolicy(c.GenericPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	c.GeneralChainConfig.ParseFlags()
 	config := &EVMConfig{
 		GeneralChainConfig:    c.GeneralChainConfig,
@@ -122,12 +151,18 @@ func NewEVMConfig(chainConfig map[string]interface{}) (*EVMConfig, error) {
 		BlockRetryInterval:    time.Duration(c.BlockRetryInterval) * time.Second,
 		GasLimit:              big.NewInt(c.GasLimit),
 		TransferGas:           c.TransferGas,
+		GasEstimationMargin:   c.GasEstimationMargin,
 		MaxGasPrice:           big.NewInt(c.MaxGasPrice),
 		GasIncreasePercentage: big.NewInt(c.GasIncreasePercentage),
 		GasMultiplier:         big.NewFloat(c.GasMultiplier),
 		StartBlock:            big.NewInt(c.StartBlock),
 		BlockConfirmations:    big.NewInt(c.BlockConfirmations),
 		BlockInterval:         big.NewInt(c.BlockInterval),
+		FallbackEndpoints:     c.FallbackEndpoints,
+		EndpointQuorum:        c.EndpointQuorum,
+		EndpointCooldown:      time.Duration(c.EndpointCooldown) * time.Second,
+		Resources:             resources,
+		GenericPolicy:         genericPolicy,
 	}
 
 	return config, nil
@@ -60,6 +60,21 @@ func (s *NewEVMConfigTestSuite) Test_InvalidBlockConfirmation() {
 	s.Equal(err.Error(), "blockConfirmations has to be >=1")
 }
 
+func (s *NewEVMConfigTestSuite) Test_InvalidEndpointQuorum() {
+	_, err := evm.NewEVMConfig(map[string]interface{}{
+		"id":                1,
+		"endpoint":          "ws://domain.com",
+		"name":              "evm1",
+		"from":              "address",
+		"bridge":            "bridgeAddress",
+		"fallbackEndpoints": []string{"ws://fallback.com"},
+		"endpointQuorum":    3,
+	})
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "endpointQuorum can not be larger than the number of endpoints")
+}
+
 func (s *NewEVMConfigTestSuite) Test_ValidConfig() {
 	rawConfig := map[string]interface{}{
 		"id":          1,
@@ -85,6 +100,7 @@ func (s *NewEVMConfigTestSuite) Test_ValidConfig() {
 		FrostKeygen:           "frostKeygen",
 		GasLimit:              big.NewInt(15000000),
 		TransferGas:           250000,
+		GasEstimationMargin:   20,
 		MaxGasPrice:           big.NewInt(500000000000),
 		GasMultiplier:         big.NewFloat(1),
 		GasIncreasePercentage: big.NewInt(15),
@@ -92,6 +108,7 @@ func (s *NewEVMConfigTestSuite) Test_ValidConfig() {
 		BlockConfirmations:    big.NewInt(10),
 		BlockInterval:         big.NewInt(5),
 		BlockRetryInterval:    time.Duration(5) * time.Second,
+		EndpointCooldown:      time.Duration(60) * time.Second,
 	})
 }
 
@@ -123,6 +140,16 @@ func (s *NewEVMConfigTestSuite) Test_ValidConfigWithCustomTxParams() {
 		"blockConfirmations":    10,
 		"blockRetryInterval":    10,
 		"blockInterval":         2,
+		"fallbackEndpoints":     []string{"ws://fallback1.com", "ws://fallback2.com"},
+		"endpointQuorum":        2,
+		"endpointCooldown":      30,
+		"gasEstimationMargin":   30,
+		"resources": []map[string]interface{}{
+			{
+				"resourceID": "0x0000000000000000000000000000000000000000000000000000000000000001",
+				"decimals":   6,
+			},
+		},
 	}
 
 	actualConfig, err := evm.NewEVMConfig(rawConfig)
@@ -151,6 +178,7 @@ func (s *NewEVMConfigTestSuite) Test_ValidConfigWithCustomTxParams() {
 		},
 		GasLimit:              big.NewInt(1000),
 		TransferGas:           300000,
+		GasEstimationMargin:   30,
 		MaxGasPrice:           big.NewInt(1000),
 		GasMultiplier:         big.NewFloat(1000),
 		GasIncreasePercentage: big.NewInt(20),
@@ -158,5 +186,14 @@ func (s *NewEVMConfigTestSuite) Test_ValidConfigWithCustomTxParams() {
 		BlockConfirmations:    big.NewInt(10),
 		BlockInterval:         big.NewInt(2),
 		BlockRetryInterval:    time.Duration(10) * time.Second,
+		FallbackEndpoints:     []string{"ws://fallback1.com", "ws://fallback2.com"},
+		EndpointQuorum:        2,
+		EndpointCooldown:      time.Duration(30) * time.Second,
+		Resources: []chain.ResourceConfig{
+			{
+				ResourceID: [32]byte{31: 1},
+				Decimals:   6,
+			},
+		},
 	})
 }
@@ -19,6 +19,7 @@ import (
 
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store"
 	"github.com/ChainSafe/sygma-relayer/tss"
 	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/signing"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
@@ -27,7 +28,10 @@ import (
 
 type Batch struct {
 	proposals []*transfer.TransferProposal
-	gasLimit  uint64
+	// splitGas is the gas batches are split by, which is the same for all relayers
+	// so that each relayer signs the same batches
+	splitGas uint64
+	gasLimit uint64
 }
 
 var (
@@ -41,6 +45,10 @@ type BridgeContract interface {
 	ProposalsHash(proposals []*transfer.TransferProposal) ([]byte, error)
 }
 
+type GasEstimator interface {
+	EstimateProposalGas(p *transfer.TransferProposal) uint64
+}
+
 type Executor struct {
 	coordinator       *tss.Coordinator
 	host              host.Host
@@ -50,6 +58,8 @@ type Executor struct {
 	exitLock          *sync.RWMutex
 	transactionMaxGas uint64
 	transferGasCost   uint64
+	gasEstimator      GasEstimator
+	propStorer        PropStorer
 }
 
 func NewExecutor(
@@ -61,6 +71,8 @@ func NewExecutor(
 	exitLock *sync.RWMutex,
 	transactionMaxGas uint64,
 	transferGasCost uint64,
+	gasEstimator GasEstimator,
+	propStorer PropStorer,
 ) *Executor {
 	return &Executor{
 		host:              host,
@@ -71,6 +83,8 @@ func NewExecutor(
 		exitLock:          exitLock,
 		transactionMaxGas: transactionMaxGas,
 		transferGasCost:   transferGasCost,
+		gasEstimator:      gasEstimator,
+		propStorer:        propStorer,
 	}
 }
 
@@ -99,6 +113,7 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 
 			sessionID := fmt.Sprintf("%s-%d", messageID, i)
 			log.Info().Str("messageID", batch.proposals[0].MessageID).Msgf("Starting session with ID: %s", sessionID)
+			e.updateProposals(b.proposals, store.ProposalUpdate{Status: store.PendingProp, SessionID: sessionID})
 
 			msg := big.NewInt(0)
 			msg.SetBytes(propHash)
@@ -120,6 +135,7 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 			ep.Go(func() error {
 				err := e.coordinator.Execute(executionContext, []tss.TssProcess{signing}, sigChn)
 				if err != nil {
+					e.updateProposals(b.proposals, store.ProposalUpdate{Reason: err.Error()})
 					cancelWatch()
 				}
 
@@ -158,9 +174,11 @@ func (e *Executor) watchExecution(
 				hash, err := e.executeBatch(batch, signatureData)
 				if err != nil {
 					_ = e.comm.Broadcast(e.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, sessionID)
+					e.updateProposals(batch.proposals, store.ProposalUpdate{Reason: err.Error()})
 					return err
 				}
 
+				e.updateProposals(batch.proposals, store.ProposalUpdate{TxHash: hash.Hex()})
 				log.Info().Str("messageID", messageID).Msgf("Sent proposals execution with hash: %s", hash)
 			}
 		case <-ticker.C:
@@ -170,11 +188,14 @@ func (e *Executor) watchExecution(
 				}
 
 				log.Info().Str("messageID", messageID).Msgf("Successfully executed proposals")
+				e.updateProposals(batch.proposals, store.ProposalUpdate{Status: store.ExecutedProp})
 				return nil
 			}
 		case <-timeout.C:
 			{
-				return fmt.Errorf("execution timed out in %s", signingTimeout)
+				err := fmt.Errorf("execution timed out in %s", signingTimeout)
+				e.updateProposals(batch.proposals, store.ProposalUpdate{Reason: err.Error()})
+				return err
 			}
 		case <-ctx.Done():
 			{
@@ -207,18 +228,23 @@ func (e *Executor) proposalBatches(proposals []*proposal.Proposal) ([]*Batch, er
 		}
 		if isExecuted {
 			log.Info().Str("messageID", transferProposal.MessageID).Msgf("Proposal %p already executed", transferProposal)
+			e.updateProposals([]*transfer.TransferProposal{transferProposal}, store.ProposalUpdate{Status: store.ExecutedProp})
 			continue
 		}
 
-		var propGasLimit uint64
+		// batches are split by configured gas as estimates depend on the state of each relayer,
+		// estimates are only used for the gas limit of the execution transaction
+		propSplitGas := e.transferGasCost
+		propGasLimit := e.gasEstimator.EstimateProposalGas(transferProposal)
 		l, ok := transferProposal.Data.Metadata["gasLimit"]
 		if ok {
-			propGasLimit = l.(uint64) + e.transferGasCost
-		} else {
-			propGasLimit = e.transferGasCost
+			propSplitGas += l.(uint64)
+			// estimates are cached per resource and can come from a generic call with a cheaper target call
+			if propGasLimit < propSplitGas {
+				propGasLimit = propSplitGas
+			}
 		}
-		currentBatch.gasLimit += propGasLimit
-		if currentBatch.gasLimit >= e.transactionMaxGas {
+		if len(currentBatch.proposals) > 0 && currentBatch.splitGas+propSplitGas > e.transactionMaxGas {
 			currentBatch = &Batch{
 				proposals: make([]*transfer.TransferProposal, 0),
 				gasLimit:  0,
@@ -226,6 +252,8 @@ func (e *Executor) proposalBatches(proposals []*proposal.Proposal) ([]*Batch, er
 			batches = append(batches, currentBatch)
 		}
 
+		currentBatch.splitGas += propSplitGas
+		currentBatch.gasLimit += propGasLimit
 		currentBatch.proposals = append(currentBatch.proposals, transferProposal)
 	}
 
@@ -259,3 +287,17 @@ func (e *Executor) areProposalsExecuted(proposals []*transfer.TransferProposal)
 
 	return true
 }
+
+// updateProposals records the update of each proposal in the batch
+func (e *Executor) updateProposals(proposals []*transfer.TransferProposal, update store.ProposalUpdate) {
+	for _, prop := range proposals {
+		update.Source = prop.Source
+		update.Destination = prop.Destination
+		update.DepositNonce = prop.Data.DepositNonce
+		update.MessageID = prop.MessageID
+		err := e.propStorer.UpdateProposal(update)
+		if err != nil {
+			log.Err(err).Str("messageID", prop.MessageID).Msgf("Failed recording proposal %+v update", prop)
+		}
+	}
+}
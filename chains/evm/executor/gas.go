@@ -0,0 +1,129 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package executor
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/consts"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+)
+
+var (
+	gasEstimateTTL = time.Hour
+	// BRIDGE_PROPOSAL_GAS covers bridge side costs of a proposal that are not part of the handler
+	// execution, like nonce bookkeeping and event emission
+	BRIDGE_PROPOSAL_GAS uint64 = 50000
+)
+
+type EstimateGasClient interface {
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+type HandlerMatcher interface {
+	GetHandlerAddressForResourceID(resourceID [32]byte) (ethCommon.Address, error)
+}
+
+type gasEstimateKey struct {
+	handler    ethCommon.Address
+	resourceID [32]byte
+}
+
+type gasEstimate struct {
+	gas       uint64
+	timestamp time.Time
+}
+
+// ProposalGasEstimator estimates execution gas of proposals by dry running
+// handler execution from the bridge address
+type ProposalGasEstimator struct {
+	client         EstimateGasClient
+	handlerMatcher HandlerMatcher
+	bridgeAddress  ethCommon.Address
+	handlerABI     abi.ABI
+	safetyMargin   uint64
+	fallbackGas    uint64
+
+	estimates map[gasEstimateKey]gasEstimate
+	lock      sync.Mutex
+}
+
+// NewProposalGasEstimator creates gas estimator that increases estimates by safety margin percentage
+// and falls back to static fallback gas when estimation fails
+func NewProposalGasEstimator(
+	client EstimateGasClient,
+	handlerMatcher HandlerMatcher,
+	bridgeAddress ethCommon.Address,
+	safetyMargin uint64,
+	fallbackGas uint64,
+) *ProposalGasEstimator {
+	a, _ := abi.JSON(strings.NewReader(consts.HandlerABI))
+	return &ProposalGasEstimator{
+		client:         client,
+		handlerMatcher: handlerMatcher,
+		bridgeAddress:  bridgeAddress,
+		handlerABI:     a,
+		safetyMargin:   safetyMargin,
+		fallbackGas:    fallbackGas,
+		estimates:      make(map[gasEstimateKey]gasEstimate),
+	}
+}
+
+// EstimateProposalGas returns gas needed to execute the proposal estimated by dry running the proposal
+// or the fallback gas if the estimation fails. Estimates are cached per handler and resource.
+func (e *ProposalGasEstimator) EstimateProposalGas(p *transfer.TransferProposal) uint64 {
+	handler, err := e.handlerMatcher.GetHandlerAddressForResourceID(p.Data.ResourceId)
+	if err != nil {
+		log.Warn().Str("messageID", p.MessageID).Err(err).Msgf("Failed fetching handler for resource, using fallback gas")
+		return e.fallbackGas
+	}
+
+	key := gasEstimateKey{handler: handler, resourceID: p.Data.ResourceId}
+	e.lock.Lock()
+	estimate, ok := e.estimates[key]
+	e.lock.Unlock()
+	if ok && time.Since(estimate.timestamp) < gasEstimateTTL {
+		return estimate.gas
+	}
+
+	gas, err := e.estimate(handler, p)
+	if err != nil {
+		log.Warn().Str("messageID", p.MessageID).Err(err).Msgf(
+			"Failed estimating gas for resource %s, using fallback gas", hexutil.Encode(p.Data.ResourceId[:]))
+		return e.fallbackGas
+	}
+
+	gas = gas*(100+e.safetyMargin)/100 + BRIDGE_PROPOSAL_GAS
+	e.lock.Lock()
+	for k, estimate := range e.estimates {
+		if time.Since(estimate.timestamp) >= gasEstimateTTL {
+			delete(e.estimates, k)
+		}
+	}
+	e.estimates[key] = gasEstimate{gas: gas, timestamp: time.Now()}
+	e.lock.Unlock()
+	return gas
+}
+
+func (e *ProposalGasEstimator) estimate(handler ethCommon.Address, p *transfer.TransferProposal) (uint64, error) {
+	data, err := e.handlerABI.Pack("executeProposal", p.Data.ResourceId, p.Data.Data)
+	if err != nil {
+		return 0, err
+	}
+
+	return e.client.EstimateGas(context.Background(), ethereum.CallMsg{
+		From: e.bridgeAddress,
+		To:   &handler,
+		Data: data,
+	})
+}
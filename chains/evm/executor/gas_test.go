@@ -0,0 +1,100 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package executor_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/executor"
+	mock_executor "github.com/ChainSafe/sygma-relayer/chains/evm/executor/mock"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+)
+
+type ProposalGasEstimatorTestSuite struct {
+	suite.Suite
+	mockClient         *mock_executor.MockEstimateGasClient
+	mockHandlerMatcher *mock_executor.MockHandlerMatcher
+	estimator          *executor.ProposalGasEstimator
+	handler            common.Address
+	proposal           *transfer.TransferProposal
+}
+
+func TestRunProposalGasEstimatorTestSuite(t *testing.T) {
+	suite.Run(t, new(ProposalGasEstimatorTestSuite))
+}
+
+func (s *ProposalGasEstimatorTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockClient = mock_executor.NewMockEstimateGasClient(ctrl)
+	s.mockHandlerMatcher = mock_executor.NewMockHandlerMatcher(ctrl)
+	s.estimator = executor.NewProposalGasEstimator(s.mockClient, s.mockHandlerMatcher, common.HexToAddress("0x1"), 20, 250000)
+	s.handler = common.HexToAddress("0x2")
+	s.proposal = &transfer.TransferProposal{
+		Data: transfer.TransferProposalData{
+			ResourceId: [32]byte{1},
+			Data:       []byte{1, 2, 3},
+		},
+	}
+}
+
+func (s *ProposalGasEstimatorTestSuite) Test_EstimateProposalGas_HandlerFetchFails() {
+	s.mockHandlerMatcher.EXPECT().GetHandlerAddressForResourceID(s.proposal.Data.ResourceId).Return(common.Address{}, errors.New("error"))
+
+	gas := s.estimator.EstimateProposalGas(s.proposal)
+
+	s.Equal(gas, uint64(250000))
+}
+
+func (s *ProposalGasEstimatorTestSuite) Test_EstimateProposalGas_EstimationFails() {
+	s.mockHandlerMatcher.EXPECT().GetHandlerAddressForResourceID(s.proposal.Data.ResourceId).Return(s.handler, nil)
+	s.mockClient.EXPECT().EstimateGas(gomock.Any(), gomock.Any()).Return(uint64(0), errors.New("execution reverted"))
+
+	gas := s.estimator.EstimateProposalGas(s.proposal)
+
+	s.Equal(gas, uint64(250000))
+}
+
+func (s *ProposalGasEstimatorTestSuite) Test_EstimateProposalGas_CachesEstimate() {
+	s.mockHandlerMatcher.EXPECT().GetHandlerAddressForResourceID(s.proposal.Data.ResourceId).Return(s.handler, nil).Times(2)
+	s.mockClient.EXPECT().EstimateGas(gomock.Any(), gomock.Any()).Return(uint64(100000), nil).Times(1)
+
+	gas := s.estimator.EstimateProposalGas(s.proposal)
+	s.Equal(gas, uint64(120000)+executor.BRIDGE_PROPOSAL_GAS)
+
+	gas = s.estimator.EstimateProposalGas(s.proposal)
+	s.Equal(gas, uint64(120000)+executor.BRIDGE_PROPOSAL_GAS)
+}
+
+func (s *ProposalGasEstimatorTestSuite) Test_EstimateProposalGas_CachesPerResource() {
+	s.mockHandlerMatcher.EXPECT().GetHandlerAddressForResourceID(gomock.Any()).Return(s.handler, nil).Times(3)
+	s.mockClient.EXPECT().EstimateGas(gomock.Any(), gomock.Any()).Return(uint64(100000), nil)
+	s.mockClient.EXPECT().EstimateGas(gomock.Any(), gomock.Any()).Return(uint64(200000), nil)
+
+	gas := s.estimator.EstimateProposalGas(s.proposal)
+	s.Equal(gas, uint64(120000)+executor.BRIDGE_PROPOSAL_GAS)
+
+	nextDeposit := &transfer.TransferProposal{
+		Data: transfer.TransferProposalData{
+			ResourceId:   s.proposal.Data.ResourceId,
+			DepositNonce: 2,
+			Data:         []byte{4, 5, 6},
+		},
+	}
+	gas = s.estimator.EstimateProposalGas(nextDeposit)
+	s.Equal(gas, uint64(120000)+executor.BRIDGE_PROPOSAL_GAS)
+
+	otherResource := &transfer.TransferProposal{
+		Data: transfer.TransferProposalData{
+			ResourceId: [32]byte{2},
+			Data:       []byte{1, 2, 3},
+		},
+	}
+	gas = s.estimator.EstimateProposalGas(otherResource)
+	s.Equal(gas, uint64(240000)+executor.BRIDGE_PROPOSAL_GAS)
+}
@@ -8,20 +8,62 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rs/zerolog/log"
 
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 
+	"github.com/ChainSafe/sygma-relayer/chains"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/policy"
 	"github.com/ChainSafe/sygma-relayer/store"
 
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 )
 
-type TransferMessageHandler struct{}
+type QuarantineStorer interface {
+	Quarantine(record store.QuarantineRecord) error
+	QuarantineRecord(source, destination uint8, depositNonce uint64) (*store.QuarantineRecord, error)
+}
+
+// TransferHandlerFunc encodes transfer message into destination proposal
+type TransferHandlerFunc func(msg *transfer.TransferMessage) (*proposal.Proposal, error)
+
+type TransferMessageHandler struct {
+	decimals         *chains.ResourceDecimals
+	genericPolicy    *policy.GenericPolicy
+	quarantine       QuarantineStorer
+	transferHandlers map[transfer.TransferType]TransferHandlerFunc
+}
+
+// NewTransferMessageHandler creates message handler that converts fungible
+// amounts between source and destination resource decimals and holds permissionless
+// generic proposals that violate the generic policy in quarantine
+func NewTransferMessageHandler(
+	decimals *chains.ResourceDecimals,
+	genericPolicy *policy.GenericPolicy,
+	quarantine QuarantineStorer,
+) *TransferMessageHandler {
+	return &TransferMessageHandler{
+		decimals:      decimals,
+		genericPolicy: genericPolicy,
+		quarantine:    quarantine,
+	}
+}
+
+// RegisterTransferHandler registers proposal encoder for transfer types
+// that are not natively supported by the relayer
+func (h *TransferMessageHandler) RegisterTransferHandler(transferType transfer.TransferType, handler TransferHandlerFunc) {
+	if h.transferHandlers == nil {
+		h.transferHandlers = make(map[transfer.TransferType]TransferHandlerFunc)
+	}
+	h.transferHandlers[transferType] = handler
+}
 
 func (h *TransferMessageHandler) HandleMessage(msg *message.Message) (*proposal.Proposal, error) {
 	transferMessage := &transfer.TransferMessage{
@@ -34,6 +76,10 @@ func (h *TransferMessageHandler) HandleMessage(msg *message.Message) (*proposal.
 
 	switch transferMessage.Data.Type {
 	case transfer.FungibleTransfer:
+		err := h.decimals.NormalizeFungibleAmount(transferMessage)
+		if err != nil {
+			return nil, err
+		}
 		return ERC20MessageHandler(transferMessage)
 	case transfer.SemiFungibleTransfer:
 		return ERC1155MessageHandler(transferMessage)
@@ -42,11 +88,76 @@ func (h *TransferMessageHandler) HandleMessage(msg *message.Message) (*proposal.
 	case transfer.PermissionedGenericTransfer:
 		return GenericMessageHandler(transferMessage)
 	case transfer.PermissionlessGenericTransfer:
+		err := h.checkGenericPolicy(transferMessage)
+		if err != nil {
+			return nil, err
+		}
 		return PermissionlessGenericMessageHandler(transferMessage)
 	}
+
+	handler, ok := h.transferHandlers[transferMessage.Data.Type]
+	if ok {
+		return handler(transferMessage)
+	}
 	return nil, errors.New("wrong message type passed while handling message")
 }
 
+// checkGenericPolicy quarantines permissionless generic transfers that violate the policy
+// unless they were already reviewed and approved. Existing quarantine records are kept as they are.
+func (h *TransferMessageHandler) checkGenericPolicy(msg *transfer.TransferMessage) error {
+	if h.genericPolicy == nil {
+		return nil
+	}
+	if len(msg.Data.Payload) != 5 {
+		return errors.New("malformed payload. Len  of payload should be 5")
+	}
+	executeFunctionSignature, ok := msg.Data.Payload[0].([]byte)
+	if !ok {
+		return errors.New("wrong function signature format")
+	}
+	executeContractAddress, ok := msg.Data.Payload[1].([]byte)
+	if !ok {
+		return errors.New("wrong contract address format")
+	}
+	maxFee, ok := msg.Data.Payload[2].([]byte)
+	if !ok {
+		return errors.New("wrong max fee format")
+	}
+	fee, _ := msg.Data.Metadata[policy.FeeMetadataKey].(*big.Int)
+
+	violation := h.genericPolicy.Check(executeContractAddress, executeFunctionSignature, new(big.Int).SetBytes(maxFee), fee)
+	if violation == nil {
+		return nil
+	}
+
+	record, err := h.quarantine.QuarantineRecord(msg.Source, msg.Destination, msg.Data.DepositNonce)
+	if err != nil {
+		return err
+	}
+	if record != nil {
+		if record.Status == store.ApprovedProp {
+			log.Warn().Str("messageID", msg.ID).Msgf("Executing approved proposal despite %s", violation)
+			return nil
+		}
+		return violation
+	}
+
+	err = h.quarantine.Quarantine(store.QuarantineRecord{
+		Source:       msg.Source,
+		Destination:  msg.Destination,
+		DepositNonce: msg.Data.DepositNonce,
+		ResourceID:   hexutil.Encode(msg.Data.ResourceId[:]),
+		MessageID:    msg.ID,
+		Reason:       violation.Reason,
+		Status:       store.QuarantinedProp,
+		Timestamp:    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return violation
+}
+
 func PermissionlessGenericMessageHandler(msg *transfer.TransferMessage) (*proposal.Proposal, error) {
 	executeFunctionSignature, ok := msg.Data.Payload[0].([]byte)
 	if !ok {
@@ -225,6 +336,7 @@ type BlockFetcher interface {
 type PropStorer interface {
 	StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error
 	PropStatus(source, destination uint8, depositNonce uint64) (store.PropStatus, error)
+	UpdateProposal(update store.ProposalUpdate) error
 }
 
 type DepositProcessor interface {
@@ -272,7 +384,7 @@ func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Pro
 	if err != nil {
 		return nil, err
 	}
-	filteredDeposits, err := retry.FilterDeposits(h.propStorer, domainDeposits, retryData.ResourceID, retryData.DestinationDomainID)
+	filteredDeposits, err := retry.FilterDeposits(h.propStorer, domainDeposits, retryData.ResourceID, retryData.AllResources, retryData.DestinationDomainID)
 	if err != nil {
 		return nil, err
 	}
@@ -9,7 +9,9 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ChainSafe/sygma-relayer/chains"
 	mock_executor "github.com/ChainSafe/sygma-relayer/chains/evm/executor/mock"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/policy"
 	"github.com/ChainSafe/sygma-relayer/store"
 	"github.com/golang/mock/gomock"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
@@ -469,6 +471,90 @@ func (s *PermissionlessGenericHandlerTestSuite) Test_HandleMessage() {
 	s.Equal(expected, prop)
 }
 
+func (s *PermissionlessGenericHandlerTestSuite) genericMessage() *message.Message {
+	return &message.Message{
+		Source:      1,
+		Destination: 2,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 3,
+			ResourceId:   [32]byte{0},
+			Payload: []interface{}{
+				[]byte{0x65, 0x4c, 0xf8, 0x8c},
+				common.HexToAddress("0x02091EefF969b33A5CE8A729DaE325879bf76f90").Bytes(),
+				common.LeftPadBytes(big.NewInt(200000).Bytes(), 32),
+				common.HexToAddress("0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b").Bytes(),
+				[]byte("0xhash"),
+			},
+			Metadata: map[string]interface{}{"gasLimit": uint64(200000)},
+			Type:     transfer.PermissionlessGenericTransfer,
+		},
+		Type: transfer.TransferMessageType,
+		ID:   "messageID",
+	}
+}
+
+func (s *PermissionlessGenericHandlerTestSuite) Test_HandleMessage_PolicyViolationQuarantined() {
+	ctrl := gomock.NewController(s.T())
+	mockQuarantine := mock_executor.NewMockQuarantineStorer(ctrl)
+	genericPolicy, _ := policy.NewGenericPolicy(&policy.RawGenericPolicy{
+		Targets: []policy.RawTargetPolicy{{Address: "0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b"}},
+	})
+	mockQuarantine.EXPECT().QuarantineRecord(uint8(1), uint8(2), uint64(3)).Return(nil, nil)
+	mockQuarantine.EXPECT().Quarantine(gomock.Any()).Return(nil)
+
+	mh := executor.NewTransferMessageHandler(nil, genericPolicy, mockQuarantine)
+	prop, err := mh.HandleMessage(s.genericMessage())
+
+	s.Nil(prop)
+	s.NotNil(err)
+}
+
+func (s *PermissionlessGenericHandlerTestSuite) Test_HandleMessage_ApprovedProposalExecuted() {
+	ctrl := gomock.NewController(s.T())
+	mockQuarantine := mock_executor.NewMockQuarantineStorer(ctrl)
+	genericPolicy, _ := policy.NewGenericPolicy(&policy.RawGenericPolicy{
+		Targets: []policy.RawTargetPolicy{{Address: "0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b"}},
+	})
+	mockQuarantine.EXPECT().QuarantineRecord(uint8(1), uint8(2), uint64(3)).Return(&store.QuarantineRecord{
+		Status: store.ApprovedProp,
+	}, nil)
+
+	mh := executor.NewTransferMessageHandler(nil, genericPolicy, mockQuarantine)
+	prop, err := mh.HandleMessage(s.genericMessage())
+
+	s.Nil(err)
+	s.NotNil(prop)
+}
+
+func (s *PermissionlessGenericHandlerTestSuite) Test_HandleMessage_AlreadyQuarantined() {
+	ctrl := gomock.NewController(s.T())
+	mockQuarantine := mock_executor.NewMockQuarantineStorer(ctrl)
+	genericPolicy, _ := policy.NewGenericPolicy(&policy.RawGenericPolicy{
+		Targets: []policy.RawTargetPolicy{{Address: "0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b"}},
+	})
+	mockQuarantine.EXPECT().QuarantineRecord(uint8(1), uint8(2), uint64(3)).Return(&store.QuarantineRecord{
+		Status: store.RejectedProp,
+	}, nil)
+
+	mh := executor.NewTransferMessageHandler(nil, genericPolicy, mockQuarantine)
+	prop, err := mh.HandleMessage(s.genericMessage())
+
+	s.Nil(prop)
+	s.NotNil(err)
+}
+
+func (s *PermissionlessGenericHandlerTestSuite) Test_HandleMessage_AllowedByPolicy() {
+	genericPolicy, _ := policy.NewGenericPolicy(&policy.RawGenericPolicy{
+		Targets: []policy.RawTargetPolicy{{Address: "0x02091EefF969b33A5CE8A729DaE325879bf76f90", MaxGas: 300000}},
+	})
+
+	mh := executor.NewTransferMessageHandler(nil, genericPolicy, nil)
+	prop, err := mh.HandleMessage(s.genericMessage())
+
+	s.Nil(err)
+	s.NotNil(prop)
+}
+
 // Erc1155
 type Erc1155HandlerTestSuite struct {
 	suite.Suite
@@ -824,3 +910,63 @@ func (s *RetryMessageHandlerTestSuite) Test_HandleMessage_ValidDeposits() {
 	s.Equal(msgs[0].Data.(transfer.TransferMessageData).DepositNonce, failedNonce)
 	s.Equal(msgs[0].Destination, validDomain)
 }
+
+func (s *ERC20HandlerTestSuite) TestERC20HandleMessage_ConvertsDecimals() {
+	decimals := chains.NewResourceDecimals()
+	decimals.Register(1, [32]byte{1}, 6)
+	decimals.Register(0, [32]byte{1}, 18)
+	recipient := []byte{241, 229, 143, 177, 119, 4, 194, 218, 132, 121, 165, 51, 249, 250, 212, 173, 9, 147, 202, 107}
+
+	expectedData := common.LeftPadBytes(big.NewInt(2000000000000).Bytes(), 32)
+	expectedData = append(expectedData, common.LeftPadBytes(big.NewInt(int64(len(recipient))).Bytes(), 32)...)
+	expectedData = append(expectedData, recipient...)
+
+	message := &message.Message{
+		Source:      1,
+		Destination: 0,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{1},
+			Payload: []interface{}{
+				[]byte{2},
+				recipient,
+			},
+			Type: transfer.FungibleTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+
+	mh := executor.NewTransferMessageHandler(decimals, nil, nil)
+	prop, err := mh.HandleMessage(message)
+
+	s.Nil(err)
+	s.Equal(prop.Data.(transfer.TransferProposalData).Data, expectedData)
+	s.NotNil(prop.Data.(transfer.TransferProposalData).Metadata[chains.AmountConversionMetadataKey])
+}
+
+func (s *ERC20HandlerTestSuite) TestERC20HandleMessage_LossyConversion() {
+	decimals := chains.NewResourceDecimals()
+	decimals.Register(1, [32]byte{1}, 18)
+	decimals.Register(0, [32]byte{1}, 6)
+
+	message := &message.Message{
+		Source:      1,
+		Destination: 0,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{1},
+			Payload: []interface{}{
+				big.NewInt(1000001).Bytes(),
+				[]byte{241, 229, 143, 177, 119, 4, 194, 218, 132, 121, 165, 51, 249, 250, 212, 173, 9, 147, 202, 107},
+			},
+			Type: transfer.FungibleTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+
+	mh := executor.NewTransferMessageHandler(decimals, nil, nil)
+	prop, err := mh.HandleMessage(message)
+
+	s.Nil(prop)
+	s.True(errors.Is(err, chains.ErrLossyConversion))
+}
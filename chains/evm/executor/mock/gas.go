@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/executor/gas.go
+
+// Package mock_executor is a generated GoMock package.
+package mock_executor
+
+import (
+	context "context"
+	reflect "reflect"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEstimateGasClient is a mock of EstimateGasClient interface.
+type MockEstimateGasClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockEstimateGasClientMockRecorder
+}
+
+// MockEstimateGasClientMockRecorder is the mock recorder for MockEstimateGasClient.
+type MockEstimateGasClientMockRecorder struct {
+	mock *MockEstimateGasClient
+}
+
+// NewMockEstimateGasClient creates a new mock instance.
+func NewMockEstimateGasClient(ctrl *gomock.Controller) *MockEstimateGasClient {
+	mock := &MockEstimateGasClient{ctrl: ctrl}
+	mock.recorder = &MockEstimateGasClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEstimateGasClient) EXPECT() *MockEstimateGasClientMockRecorder {
+	return m.recorder
+}
+
+// EstimateGas mocks base method.
+func (m *MockEstimateGasClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateGas", ctx, msg)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateGas indicates an expected call of EstimateGas.
+func (mr *MockEstimateGasClientMockRecorder) EstimateGas(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGas", reflect.TypeOf((*MockEstimateGasClient)(nil).EstimateGas), ctx, msg)
+}
+
+// MockHandlerMatcher is a mock of HandlerMatcher interface.
+type MockHandlerMatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlerMatcherMockRecorder
+}
+
+// MockHandlerMatcherMockRecorder is the mock recorder for MockHandlerMatcher.
+type MockHandlerMatcherMockRecorder struct {
+	mock *MockHandlerMatcher
+}
+
+// NewMockHandlerMatcher creates a new mock instance.
+func NewMockHandlerMatcher(ctrl *gomock.Controller) *MockHandlerMatcher {
+	mock := &MockHandlerMatcher{ctrl: ctrl}
+	mock.recorder = &MockHandlerMatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandlerMatcher) EXPECT() *MockHandlerMatcherMockRecorder {
+	return m.recorder
+}
+
+// GetHandlerAddressForResourceID mocks base method.
+func (m *MockHandlerMatcher) GetHandlerAddressForResourceID(resourceID [32]byte) (common.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHandlerAddressForResourceID", resourceID)
+	ret0, _ := ret[0].(common.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHandlerAddressForResourceID indicates an expected call of GetHandlerAddressForResourceID.
+func (mr *MockHandlerMatcherMockRecorder) GetHandlerAddressForResourceID(resourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHandlerAddressForResourceID", reflect.TypeOf((*MockHandlerMatcher)(nil).GetHandlerAddressForResourceID), resourceID)
+}
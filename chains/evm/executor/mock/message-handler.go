@@ -13,6 +13,58 @@ import (
 	message "github.com/sygmaprotocol/sygma-core/relayer/message"
 )
 
+// MockQuarantineStorer is a mock of QuarantineStorer interface.
+type MockQuarantineStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuarantineStorerMockRecorder
+}
+
+// MockQuarantineStorerMockRecorder is the mock recorder for MockQuarantineStorer.
+type MockQuarantineStorerMockRecorder struct {
+	mock *MockQuarantineStorer
+}
+
+// NewMockQuarantineStorer creates a new mock instance.
+func NewMockQuarantineStorer(ctrl *gomock.Controller) *MockQuarantineStorer {
+	mock := &MockQuarantineStorer{ctrl: ctrl}
+	mock.recorder = &MockQuarantineStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuarantineStorer) EXPECT() *MockQuarantineStorerMockRecorder {
+	return m.recorder
+}
+
+// Quarantine mocks base method.
+func (m *MockQuarantineStorer) Quarantine(record store.QuarantineRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quarantine", record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Quarantine indicates an expected call of Quarantine.
+func (mr *MockQuarantineStorerMockRecorder) Quarantine(record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quarantine", reflect.TypeOf((*MockQuarantineStorer)(nil).Quarantine), record)
+}
+
+// QuarantineRecord mocks base method.
+func (m *MockQuarantineStorer) QuarantineRecord(source, destination uint8, depositNonce uint64) (*store.QuarantineRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuarantineRecord", source, destination, depositNonce)
+	ret0, _ := ret[0].(*store.QuarantineRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuarantineRecord indicates an expected call of QuarantineRecord.
+func (mr *MockQuarantineStorerMockRecorder) QuarantineRecord(source, destination, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuarantineRecord", reflect.TypeOf((*MockQuarantineStorer)(nil).QuarantineRecord), source, destination, depositNonce)
+}
+
 // MockBlockFetcher is a mock of BlockFetcher interface.
 type MockBlockFetcher struct {
 	ctrl     *gomock.Controller
@@ -103,6 +155,20 @@ func (mr *MockPropStorerMockRecorder) StorePropStatus(source, destination, depos
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePropStatus", reflect.TypeOf((*MockPropStorer)(nil).StorePropStatus), source, destination, depositNonce, status)
 }
 
+// UpdateProposal mocks base method.
+func (m *MockPropStorer) UpdateProposal(update store.ProposalUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProposal", update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProposal indicates an expected call of UpdateProposal.
+func (mr *MockPropStorerMockRecorder) UpdateProposal(update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProposal", reflect.TypeOf((*MockPropStorer)(nil).UpdateProposal), update)
+}
+
 // MockDepositProcessor is a mock of DepositProcessor interface.
 type MockDepositProcessor struct {
 	ctrl     *gomock.Controller
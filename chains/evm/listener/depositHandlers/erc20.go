@@ -43,20 +43,34 @@ func (dh *Erc20DepositHandler) HandleDeposit(
 	}
 
 	recipientAddressLength := big.NewInt(0).SetBytes(calldata[32:64])
-	recipientAddress := calldata[64:(64 + recipientAddressLength.Int64())]
+	if !recipientAddressLength.IsInt64() || recipientAddressLength.Int64() > int64(len(calldata)-64) {
+		return nil, errors.New("invalid calldata: recipient length exceeds calldata")
+	}
+	recipientEnd := 64 + recipientAddressLength.Int64()
+	recipientAddress := calldata[64:recipientEnd]
 	payload := []interface{}{
 		amount,
 		recipientAddress,
 	}
 
 	metadata := make(map[string]interface{})
+	// optional message consists of 32 bytes max fee followed by the message itself
+	optionalMessage := calldata[recipientEnd:]
+	if len(optionalMessage) > 0 && len(optionalMessage) < 32 {
+		return nil, errors.New("invalid calldata: optional message shorter than max fee")
+	}
+	if len(optionalMessage) == 32 {
+		return nil, errors.New("invalid calldata: optional message missing after max fee")
+	}
 	// append optional message if it exists
-	if len(calldata) > int(96+recipientAddressLength.Int64()) {
-		maxFee := new(big.Int).Add(new(big.Int).SetBytes(calldata[64+recipientAddressLength.Int64():96+recipientAddressLength.Int64()]), big.NewInt(OPTIONAL_REVERT_GAS))
+	if len(optionalMessage) > 0 {
+		maxFee := new(big.Int).Add(new(big.Int).SetBytes(optionalMessage[:32]), big.NewInt(OPTIONAL_REVERT_GAS))
 		metadata["gasLimit"] = maxFee.Uint64()
 
-		copy(calldata[64+recipientAddressLength.Int64():96+recipientAddressLength.Int64()], common.LeftPadBytes(maxFee.Bytes(), 32))
-		payload = append(payload, calldata[64+recipientAddressLength.Int64():])
+		message := make([]byte, 0, len(optionalMessage))
+		message = append(message, common.LeftPadBytes(maxFee.Bytes(), 32)...)
+		message = append(message, optionalMessage[32:]...)
+		payload = append(payload, message)
 	}
 
 	return message.NewMessage(
@@ -127,3 +127,63 @@ func (s *Erc20HandlerTestSuite) TestErc20HandleEventIncorrectDataLen() {
 	s.Nil(message)
 	s.EqualError(err, errIncorrectDataLen.Error())
 }
+
+func (s *Erc20HandlerTestSuite) TestErc20HandleEventInvalidRecipientLength() {
+	calldata := evm.ConstructErc20DepositData([]byte{241, 229, 143, 177, 119, 4, 194, 218, 132, 121, 165, 51, 249, 250, 212, 173, 9, 147, 202, 107}, big.NewInt(2))
+	copy(calldata[32:64], math.PaddedBigBytes(big.NewInt(100), 32))
+
+	erc20DepositHandler := depositHandlers.Erc20DepositHandler{}
+	message, err := erc20DepositHandler.HandleDeposit(
+		1,
+		0,
+		1,
+		[32]byte{0},
+		calldata,
+		[]byte{},
+		"messageID",
+		time.Now(),
+	)
+
+	s.Nil(message)
+	s.NotNil(err)
+}
+
+func (s *Erc20HandlerTestSuite) TestErc20HandleEventTruncatedOptionalMessage() {
+	calldata := evm.ConstructErc20DepositData([]byte{241, 229, 143, 177, 119, 4, 194, 218, 132, 121, 165, 51, 249, 250, 212, 173, 9, 147, 202, 107}, big.NewInt(2))
+	calldata = append(calldata, []byte{1, 2, 3}...)
+
+	erc20DepositHandler := depositHandlers.Erc20DepositHandler{}
+	message, err := erc20DepositHandler.HandleDeposit(
+		1,
+		0,
+		1,
+		[32]byte{0},
+		calldata,
+		[]byte{},
+		"messageID",
+		time.Now(),
+	)
+
+	s.Nil(message)
+	s.NotNil(err)
+}
+
+func (s *Erc20HandlerTestSuite) TestErc20HandleEventOptionalMessageWithOnlyMaxFee() {
+	calldata := evm.ConstructErc20DepositData([]byte{241, 229, 143, 177, 119, 4, 194, 218, 132, 121, 165, 51, 249, 250, 212, 173, 9, 147, 202, 107}, big.NewInt(2))
+	calldata = append(calldata, common.LeftPadBytes(big.NewInt(200000).Bytes(), 32)...)
+
+	erc20DepositHandler := depositHandlers.Erc20DepositHandler{}
+	message, err := erc20DepositHandler.HandleDeposit(
+		1,
+		0,
+		1,
+		[32]byte{0},
+		calldata,
+		[]byte{},
+		"messageID",
+		time.Now(),
+	)
+
+	s.Nil(message)
+	s.NotNil(err)
+}
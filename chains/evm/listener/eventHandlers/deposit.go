@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/events"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/policy"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rs/zerolog/log"
@@ -82,6 +84,11 @@ func (eh *DepositEventHandler) ProcessDeposits(startBlock *big.Int, endBlock *bi
 				return
 			}
 
+			data, ok := m.Data.(transfer.TransferMessageData)
+			if ok && d.Fee != nil && data.Metadata != nil {
+				data.Metadata[policy.FeeMetadataKey] = d.Fee
+			}
+
 			log.Info().Str("messageID", m.ID).Msgf("Resolved message %+v in block range: %s-%s", m, startBlock.String(), endBlock.String())
 			domainDeposits[m.Destination] = append(domainDeposits[m.Destination], m)
 		}(d)
@@ -49,16 +49,16 @@ func (mr *MockPropStorerMockRecorder) PropStatus(source, destination, depositNon
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropStatus", reflect.TypeOf((*MockPropStorer)(nil).PropStatus), source, destination, depositNonce)
 }
 
-// StorePropStatus mocks base method.
-func (m *MockPropStorer) StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error {
+// UpdateProposal mocks base method.
+func (m *MockPropStorer) UpdateProposal(update store.ProposalUpdate) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StorePropStatus", source, destination, depositNonce, status)
+	ret := m.ctrl.Call(m, "UpdateProposal", update)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// StorePropStatus indicates an expected call of StorePropStatus.
-func (mr *MockPropStorerMockRecorder) StorePropStatus(source, destination, depositNonce, status interface{}) *gomock.Call {
+// UpdateProposal indicates an expected call of UpdateProposal.
+func (mr *MockPropStorerMockRecorder) UpdateProposal(update interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePropStatus", reflect.TypeOf((*MockPropStorer)(nil).StorePropStatus), source, destination, depositNonce, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProposal", reflect.TypeOf((*MockPropStorer)(nil).UpdateProposal), update)
 }
@@ -76,8 +76,8 @@ func (eh *RetryV2EventHandler) HandleEvents(
 }
 
 type PropStorer interface {
-	StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error
 	PropStatus(source, destination uint8, depositNonce uint64) (store.PropStatus, error)
+	UpdateProposal(update store.ProposalUpdate) error
 }
 
 type RetryV1EventHandler struct {
@@ -191,11 +191,13 @@ func (eh *RetryV1EventHandler) isExecuted(msg *message.Message) (bool, error) {
 
 	// change the status to failed if proposal is stuck to be able to retry it
 	if propStatus == store.PendingProp {
-		err = eh.propStorer.StorePropStatus(
-			msg.Source,
-			msg.Destination,
-			msg.Data.(transfer.TransferMessageData).DepositNonce,
-			store.FailedProp)
+		err = eh.propStorer.UpdateProposal(store.ProposalUpdate{
+			Source:       msg.Source,
+			Destination:  msg.Destination,
+			DepositNonce: msg.Data.(transfer.TransferMessageData).DepositNonce,
+			Status:       store.FailedProp,
+			Reason:       "retried while pending",
+		})
 	}
 	return false, err
 }
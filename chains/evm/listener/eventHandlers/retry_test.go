@@ -376,7 +376,11 @@ func (s *RetryV1EventHandlerTestSuite) Test_MultipleDeposits_ExecutedIgnored() {
 		DepositNonce: 2,
 	}}, nil)
 	s.mockPropStorer.EXPECT().PropStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(store.PendingProp, nil)
-	s.mockPropStorer.EXPECT().StorePropStatus(gomock.Any(), gomock.Any(), gomock.Any(), store.FailedProp).Return(nil)
+	s.mockPropStorer.EXPECT().UpdateProposal(store.ProposalUpdate{
+		DepositNonce: 2,
+		Status:       store.FailedProp,
+		Reason:       "retried while pending",
+	}).Return(nil)
 
 	err := s.retryEventHandler.HandleEvents(big.NewInt(0), big.NewInt(5))
 	msgs := <-s.msgChan
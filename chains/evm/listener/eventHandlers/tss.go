@@ -7,7 +7,10 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
@@ -157,6 +160,14 @@ func (eh *FrostKeygenEventHandler) sessionID(block *big.Int) string {
 	return fmt.Sprintf("frost-keygen-%s", block.String())
 }
 
+// maxTopologyRetryInterval is the maximum interval between retries of fetching
+// topology of a refresh event
+const maxTopologyRetryInterval = 5 * time.Minute
+
+type FrostRefresher interface {
+	RefreshFrost(sessionID string) error
+}
+
 type RefreshEventHandler struct {
 	log              zerolog.Logger
 	topologyProvider topology.NetworkTopologyProvider
@@ -169,6 +180,10 @@ type RefreshEventHandler struct {
 	connectionGate   *p2p.ConnectionGate
 	ecdsaStorer      resharing.SaveDataStorer
 	frostStorer      frostResharing.FrostKeyshareStorer
+	refresher        FrostRefresher
+
+	hashLock   sync.Mutex
+	latestHash string
 }
 
 func NewRefreshEventHandler(
@@ -182,6 +197,7 @@ func NewRefreshEventHandler(
 	connectionGate *p2p.ConnectionGate,
 	ecdsaStorer resharing.SaveDataStorer,
 	frostStorer frostResharing.FrostKeyshareStorer,
+	refresher FrostRefresher,
 	bridgeAddress common.Address,
 ) *RefreshEventHandler {
 	return &RefreshEventHandler{
@@ -194,6 +210,7 @@ func NewRefreshEventHandler(
 		communication:    communication,
 		ecdsaStorer:      ecdsaStorer,
 		frostStorer:      frostStorer,
+		refresher:        refresher,
 		connectionGate:   connectionGate,
 		bridgeAddress:    bridgeAddress,
 	}
@@ -220,35 +237,118 @@ func (eh *RefreshEventHandler) HandleEvents(
 		log.Error().Msgf("Hash cannot be empty string")
 		return nil
 	}
+	eh.hashLock.Lock()
+	eh.latestHash = hash
+	eh.hashLock.Unlock()
+
 	topology, err := eh.topologyProvider.NetworkTopology(hash)
 	if err != nil {
-		log.Error().Err(err).Msgf("Failed fetching network topology")
+		log.Warn().Err(err).Msgf("Failed fetching network topology, retrying in background")
+		go eh.retryRefresh(hash, startBlock, endBlock)
 		return nil
 	}
-	err = eh.topologyStore.StoreTopology(topology)
+
+	eh.refresh(topology, startBlock, endBlock)
+	return nil
+}
+
+// retryRefresh retries fetching topology with the refresh hash until it succeeds
+// or a newer refresh event is received
+func (eh *RefreshEventHandler) retryRefresh(hash string, startBlock *big.Int, endBlock *big.Int) {
+	var networkTopology *topology.NetworkTopology
+	operation := func() error {
+		if !eh.isLatest(hash) {
+			return backoff.Permanent(fmt.Errorf("refresh with hash %s superseded", hash))
+		}
+
+		var err error
+		networkTopology, err = eh.topologyProvider.NetworkTopology(hash)
+		return err
+	}
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.MaxInterval = maxTopologyRetryInterval
+	expBackoff.MaxElapsedTime = 0
+
+	notify := func(err error, duration time.Duration) {
+		eh.log.Warn().Err(err).Msgf("Failed fetching network topology with hash %s, retrying in %s", hash, duration)
+	}
+	err := backoff.RetryNotify(operation, expBackoff, notify)
+	if err != nil || !eh.isLatest(hash) {
+		eh.log.Warn().Err(err).Msgf("Stopped fetching network topology with hash %s", hash)
+		return
+	}
+
+	eh.refresh(networkTopology, startBlock, endBlock)
+}
+
+func (eh *RefreshEventHandler) isLatest(hash string) bool {
+	eh.hashLock.Lock()
+	defer eh.hashLock.Unlock()
+	return eh.latestHash == hash
+}
+
+// refresh stores and applies the new topology and starts ECDSA and FROST resharing.
+// Topology that only changes addresses of existing peers is applied without resharing.
+// Refresh with the current topology proactively refreshes both ECDSA and FROST keyshares.
+func (eh *RefreshEventHandler) refresh(networkTopology *topology.NetworkTopology, startBlock *big.Int, endBlock *big.Int) {
+	addressOnly := false
+	proactive := false
+	currentTopology, err := eh.topologyStore.Topology()
+	if err == nil {
+		diff := topology.Diff(currentTopology, networkTopology)
+		addressOnly = diff.IsAddressOnly() && !diff.IsEmpty()
+		proactive = diff.IsEmpty()
+	}
+
+	err = eh.topologyStore.StoreTopology(networkTopology)
 	if err != nil {
 		log.Error().Err(err).Msgf("Failed storing network topology")
-		return nil
+		return
 	}
 
-	eh.connectionGate.SetTopology(topology)
-	p2p.LoadPeers(eh.host, topology.Peers)
+	eh.connectionGate.SetTopology(networkTopology)
+	p2p.LoadPeers(eh.host, networkTopology.Peers)
+
+	if addressOnly {
+		eh.log.Info().Msgf(
+			"Updated peer addresses from refresh message in block range: %s-%s", startBlock.String(), endBlock.String(),
+		)
+		return
+	}
 
 	eh.log.Info().Msgf(
 		"Resolved refresh message in block range: %s-%s", startBlock.String(), endBlock.String(),
 	)
 
 	resharing := resharing.NewResharing(
-		eh.sessionID(startBlock), topology.Threshold, eh.host, eh.communication, eh.ecdsaStorer,
+		eh.sessionID(startBlock), networkTopology.Threshold, eh.host, eh.communication, eh.ecdsaStorer,
 	)
 	err = eh.coordinator.Execute(context.Background(), []tss.TssProcess{resharing}, make(chan interface{}, 1))
 	if err != nil {
 		log.Err(err).Msgf("Failed executing ecdsa key refresh")
-		return nil
 	}
-	return nil
+
+	if proactive {
+		err = eh.refresher.RefreshFrost(eh.frostSessionID(startBlock))
+		if err != nil {
+			log.Err(err).Msgf("Failed executing frost key refresh")
+		}
+		return
+	}
+
+	frostResharing := frostResharing.NewResharing(
+		eh.frostSessionID(startBlock), networkTopology.Threshold, eh.host, eh.communication, eh.frostStorer,
+	)
+	err = eh.coordinator.Execute(context.Background(), []tss.TssProcess{frostResharing}, make(chan interface{}, 1))
+	if err != nil {
+		log.Err(err).Msgf("Failed executing frost key refresh")
+	}
 }
 
 func (eh *RefreshEventHandler) sessionID(block *big.Int) string {
 	return fmt.Sprintf("resharing-%s", block.String())
 }
+
+func (eh *RefreshEventHandler) frostSessionID(block *big.Int) string {
+	return fmt.Sprintf("frost-resharing-%s", block.String())
+}
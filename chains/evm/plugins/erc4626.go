@@ -0,0 +1,100 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package plugins
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+)
+
+const (
+	ERC4626Transfer   transfer.TransferType = "erc4626"
+	SharesMetadataKey                       = "shares"
+)
+
+type Erc4626DepositHandler struct{}
+
+// HandleDeposit converts ERC4626 vault share deposit into transfer of the underlying asset.
+// Calldata contains 32 bytes share amount followed by the recipient and handlerResponse
+// contains 32 bytes amount of the underlying asset the shares were redeemed for.
+func (dh *Erc4626DepositHandler) HandleDeposit(
+	sourceID,
+	destID uint8,
+	nonce uint64,
+	resourceID [32]byte,
+	calldata,
+	handlerResponse []byte,
+	messageID string,
+	timestamp time.Time) (*message.Message, error) {
+	if len(calldata) < 84 {
+		return nil, errors.New("invalid calldata length: less than 84 bytes")
+	}
+	if len(handlerResponse) < 32 {
+		return nil, errors.New("invalid handler response: missing underlying asset amount")
+	}
+
+	shares := new(big.Int).SetBytes(calldata[:32])
+	assets := handlerResponse[:32]
+
+	recipientAddressLength := big.NewInt(0).SetBytes(calldata[32:64])
+	if !recipientAddressLength.IsInt64() || recipientAddressLength.Int64() > int64(len(calldata)-64) {
+		return nil, errors.New("invalid calldata: recipient length exceeds calldata")
+	}
+	recipientAddress := calldata[64 : 64+recipientAddressLength.Int64()]
+
+	return message.NewMessage(
+		sourceID,
+		destID,
+		transfer.TransferMessageData{
+			DepositNonce: nonce,
+			ResourceId:   resourceID,
+			Metadata: map[string]interface{}{
+				SharesMetadataKey: shares,
+			},
+			Payload: []interface{}{
+				assets,
+				recipientAddress,
+			},
+			Type: ERC4626Transfer,
+		},
+		messageID,
+		transfer.TransferMessageType,
+		timestamp,
+	), nil
+}
+
+// ERC4626MessageHandler encodes underlying asset amount and recipient the same way
+// as fungible transfers so the destination vault handler can deposit the assets for the recipient
+func ERC4626MessageHandler(msg *transfer.TransferMessage) (*proposal.Proposal, error) {
+	if len(msg.Data.Payload) != 2 {
+		return nil, errors.New("malformed payload. Len  of payload should be 2")
+	}
+	assets, ok := msg.Data.Payload[0].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload amount format")
+	}
+	recipient, ok := msg.Data.Payload[1].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload recipient format")
+	}
+
+	var data []byte
+	data = append(data, common.LeftPadBytes(assets, 32)...)
+	recipientLen := big.NewInt(int64(len(recipient))).Bytes()
+	data = append(data, common.LeftPadBytes(recipientLen, 32)...)
+	data = append(data, recipient...)
+	return proposal.NewProposal(msg.Source, msg.Destination, transfer.TransferProposalData{
+		DepositNonce: msg.Data.DepositNonce,
+		ResourceId:   msg.Data.ResourceId,
+		Metadata:     msg.Data.Metadata,
+		Data:         data,
+	}, msg.ID, transfer.TransferProposalType), nil
+}
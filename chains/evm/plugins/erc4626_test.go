@@ -0,0 +1,71 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package plugins_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/plugins"
+	evmE2E "github.com/ChainSafe/sygma-relayer/e2e/evm"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+)
+
+type Erc4626TestSuite struct {
+	suite.Suite
+	recipient common.Address
+}
+
+func TestRunErc4626TestSuite(t *testing.T) {
+	suite.Run(t, new(Erc4626TestSuite))
+}
+
+func (s *Erc4626TestSuite) SetupTest() {
+	s.recipient = common.HexToAddress("0xf1e58fb17704c2da8479a533f9fad4ad0993ca6b")
+}
+
+func (s *Erc4626TestSuite) Test_HandleDeposit_MissingUnderlyingAmount() {
+	dh := plugins.Erc4626DepositHandler{}
+
+	_, err := dh.HandleDeposit(1, 2, 1, [32]byte{1}, evmE2E.ConstructErc20DepositData(s.recipient.Bytes(), big.NewInt(10)), []byte{}, "messageID", time.Now())
+
+	s.NotNil(err)
+}
+
+func (s *Erc4626TestSuite) Test_HandleDeposit_InvalidRecipientLength() {
+	dh := plugins.Erc4626DepositHandler{}
+	calldata := evmE2E.ConstructErc20DepositData(s.recipient.Bytes(), big.NewInt(10))
+	calldata[63] = 100
+
+	_, err := dh.HandleDeposit(1, 2, 1, [32]byte{1}, calldata, common.LeftPadBytes([]byte{12}, 32), "messageID", time.Now())
+
+	s.NotNil(err)
+}
+
+func (s *Erc4626TestSuite) Test_HandleDeposit_SendsUnderlyingAmount() {
+	dh := plugins.Erc4626DepositHandler{}
+	assets := common.LeftPadBytes(big.NewInt(12).Bytes(), 32)
+
+	msg, err := dh.HandleDeposit(1, 2, 1, [32]byte{1}, evmE2E.ConstructErc20DepositData(s.recipient.Bytes(), big.NewInt(10)), assets, "messageID", time.Now())
+
+	s.Nil(err)
+	data := msg.Data.(transfer.TransferMessageData)
+	s.Equal(data.Type, plugins.ERC4626Transfer)
+	s.Equal(data.Payload, []interface{}{assets, s.recipient.Bytes()})
+	s.Equal(data.Metadata[plugins.SharesMetadataKey], big.NewInt(10))
+}
+
+func (s *Erc4626TestSuite) Test_MessageHandler_InvalidPayload() {
+	_, err := plugins.ERC4626MessageHandler(&transfer.TransferMessage{
+		Data: transfer.TransferMessageData{
+			Payload: []interface{}{[]byte{1}},
+		},
+	})
+
+	s.NotNil(err)
+}
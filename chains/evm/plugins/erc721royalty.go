@@ -0,0 +1,106 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/executor"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+)
+
+const (
+	RoyaltyNonFungibleTransfer transfer.TransferType = "royaltyNonFungible"
+	// ROYALTY_FEE_DENOMINATOR is the ERC2981 fee denominator, fee numerator is expressed in basis points
+	ROYALTY_FEE_DENOMINATOR = 10000
+)
+
+type Erc721RoyaltyDepositHandler struct {
+	Erc721DepositHandler depositHandlers.Erc721DepositHandler
+}
+
+// HandleDeposit converts ERC721 deposit into message carrying ERC2981 royalty info of the token.
+// Calldata has the same format as ERC721 deposits and handlerResponse contains
+// abi encoded royalty receiver address and fee numerator.
+func (dh *Erc721RoyaltyDepositHandler) HandleDeposit(
+	sourceID,
+	destID uint8,
+	nonce uint64,
+	resourceID [32]byte,
+	calldata,
+	handlerResponse []byte,
+	messageID string,
+	timestamp time.Time) (*message.Message, error) {
+	if len(handlerResponse) != 64 {
+		return nil, fmt.Errorf("invalid handler response length %d: royalty info should be 64 bytes", len(handlerResponse))
+	}
+
+	msg, err := dh.Erc721DepositHandler.HandleDeposit(sourceID, destID, nonce, resourceID, calldata, handlerResponse, messageID, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	royaltyReceiver := common.BytesToAddress(handlerResponse[:32])
+	feeNumerator := new(big.Int).SetBytes(handlerResponse[32:64])
+	if feeNumerator.Cmp(big.NewInt(ROYALTY_FEE_DENOMINATOR)) > 0 {
+		return nil, fmt.Errorf("royalty fee numerator %s exceeds denominator", feeNumerator)
+	}
+
+	data := msg.Data.(transfer.TransferMessageData)
+	data.Payload = append(data.Payload, royaltyReceiver.Bytes(), feeNumerator.Bytes())
+	data.Type = RoyaltyNonFungibleTransfer
+	msg.Data = data
+	return msg, nil
+}
+
+// ERC721RoyaltyMessageHandler encodes ERC721 proposal data followed by 32 bytes royalty receiver
+// and 32 bytes royalty fee numerator
+func ERC721RoyaltyMessageHandler(msg *transfer.TransferMessage) (*proposal.Proposal, error) {
+	if len(msg.Data.Payload) != 5 {
+		return nil, errors.New("malformed payload. Len  of payload should be 5")
+	}
+	royaltyReceiver, ok := msg.Data.Payload[3].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload royalty receiver format")
+	}
+	feeNumerator, ok := msg.Data.Payload[4].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload royalty fee format")
+	}
+
+	erc721Message := &transfer.TransferMessage{
+		Source:      msg.Source,
+		Destination: msg.Destination,
+		Data: transfer.TransferMessageData{
+			DepositNonce: msg.Data.DepositNonce,
+			ResourceId:   msg.Data.ResourceId,
+			Metadata:     msg.Data.Metadata,
+			Payload:      msg.Data.Payload[:3],
+			Type:         transfer.NonFungibleTransfer,
+		},
+		Type: msg.Type,
+		ID:   msg.ID,
+	}
+	prop, err := executor.ERC721MessageHandler(erc721Message)
+	if err != nil {
+		return nil, err
+	}
+
+	propData := prop.Data.(transfer.TransferProposalData)
+	data := make([]byte, 0, len(propData.Data)+64)
+	data = append(data, propData.Data...)
+	data = append(data, common.LeftPadBytes(royaltyReceiver, 32)...)
+	data = append(data, common.LeftPadBytes(feeNumerator, 32)...)
+	propData.Data = data
+	prop.Data = propData
+	return prop, nil
+}
@@ -0,0 +1,76 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package plugins_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/plugins"
+	evmE2E "github.com/ChainSafe/sygma-relayer/e2e/evm"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+)
+
+type Erc721RoyaltyTestSuite struct {
+	suite.Suite
+	recipient       common.Address
+	royaltyReceiver common.Address
+	calldata        []byte
+}
+
+func TestRunErc721RoyaltyTestSuite(t *testing.T) {
+	suite.Run(t, new(Erc721RoyaltyTestSuite))
+}
+
+func (s *Erc721RoyaltyTestSuite) SetupTest() {
+	s.recipient = common.HexToAddress("0xf1e58fb17704c2da8479a533f9fad4ad0993ca6b")
+	s.royaltyReceiver = common.HexToAddress("0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b")
+	s.calldata = evmE2E.ConstructErc721DepositData(s.recipient.Bytes(), big.NewInt(2), []byte("metadata"))
+}
+
+func (s *Erc721RoyaltyTestSuite) royaltyInfo(feeNumerator int64) []byte {
+	info := common.LeftPadBytes(s.royaltyReceiver.Bytes(), 32)
+	return append(info, common.LeftPadBytes(big.NewInt(feeNumerator).Bytes(), 32)...)
+}
+
+func (s *Erc721RoyaltyTestSuite) Test_HandleDeposit_MissingRoyaltyInfo() {
+	dh := plugins.Erc721RoyaltyDepositHandler{}
+
+	_, err := dh.HandleDeposit(1, 2, 1, [32]byte{1}, s.calldata, []byte{}, "messageID", time.Now())
+
+	s.NotNil(err)
+}
+
+func (s *Erc721RoyaltyTestSuite) Test_HandleDeposit_FeeAboveDenominator() {
+	dh := plugins.Erc721RoyaltyDepositHandler{}
+
+	_, err := dh.HandleDeposit(1, 2, 1, [32]byte{1}, s.calldata, s.royaltyInfo(10001), "messageID", time.Now())
+
+	s.NotNil(err)
+}
+
+func (s *Erc721RoyaltyTestSuite) Test_RoyaltyTransfer() {
+	dh := plugins.Erc721RoyaltyDepositHandler{}
+
+	msg, err := dh.HandleDeposit(1, 2, 1, [32]byte{1}, s.calldata, s.royaltyInfo(250), "messageID", time.Now())
+	s.Nil(err)
+	data := msg.Data.(transfer.TransferMessageData)
+	s.Equal(data.Type, plugins.RoyaltyNonFungibleTransfer)
+	s.Equal(data.Payload[3], s.royaltyReceiver.Bytes())
+
+	prop, err := plugins.ERC721RoyaltyMessageHandler(&transfer.TransferMessage{
+		Source:      msg.Source,
+		Destination: msg.Destination,
+		Data:        data,
+		Type:        msg.Type,
+		ID:          msg.ID,
+	})
+
+	s.Nil(err)
+	s.Equal(prop.Data.(transfer.TransferProposalData).Data, append(s.calldata, s.royaltyInfo(250)...))
+}
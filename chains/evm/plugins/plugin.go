@@ -0,0 +1,143 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package plugins
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/executor"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+)
+
+// HandlerPlugin supplies everything the relayer needs to bridge a single transfer type:
+// the decoder for source deposits and the encoder for destination proposals
+type HandlerPlugin interface {
+	// TransferType is the type of transfer messages the deposit handler produces
+	TransferType() transfer.TransferType
+	// DepositHandler decodes deposit event data into a transfer message
+	DepositHandler() eventHandlers.DepositHandler
+	// EncodeProposal encodes transfer message into proposal data expected by the destination handler
+	EncodeProposal(msg *transfer.TransferMessage) (*proposal.Proposal, error)
+}
+
+type DepositHandlerRegistrar interface {
+	RegisterDepositHandler(handlerAddress string, handler eventHandlers.DepositHandler)
+}
+
+type TransferHandlerRegistrar interface {
+	RegisterTransferHandler(transferType transfer.TransferType, handler executor.TransferHandlerFunc)
+}
+
+type handlerPlugin struct {
+	transferType   transfer.TransferType
+	depositHandler eventHandlers.DepositHandler
+	encoder        executor.TransferHandlerFunc
+}
+
+// NewHandlerPlugin bundles deposit handler and proposal encoder of a transfer type into a plugin
+func NewHandlerPlugin(
+	transferType transfer.TransferType,
+	depositHandler eventHandlers.DepositHandler,
+	encoder executor.TransferHandlerFunc,
+) HandlerPlugin {
+	return &handlerPlugin{
+		transferType:   transferType,
+		depositHandler: depositHandler,
+		encoder:        encoder,
+	}
+}
+
+func (p *handlerPlugin) TransferType() transfer.TransferType {
+	return p.transferType
+}
+
+func (p *handlerPlugin) DepositHandler() eventHandlers.DepositHandler {
+	return p.depositHandler
+}
+
+func (p *handlerPlugin) EncodeProposal(msg *transfer.TransferMessage) (*proposal.Proposal, error) {
+	return p.encoder(msg)
+}
+
+// Registry maps handler types from the domain configuration to deposit handlers of
+// types natively supported by the executor and to handler plugins of other types
+type Registry struct {
+	builtins map[string]eventHandlers.DepositHandler
+	plugins  map[string]HandlerPlugin
+}
+
+// NewRegistry creates plugin registry with all handler types supported by the relayer
+func NewRegistry() *Registry {
+	r := &Registry{
+		builtins: make(map[string]eventHandlers.DepositHandler),
+		plugins:  make(map[string]HandlerPlugin),
+	}
+
+	r.builtins["erc20"] = &depositHandlers.Erc20DepositHandler{}
+	r.builtins["native"] = &depositHandlers.Erc20DepositHandler{}
+	r.builtins["erc721"] = &depositHandlers.Erc721DepositHandler{}
+	r.builtins["erc1155"] = &depositHandlers.Erc1155DepositHandler{}
+	r.builtins["permissionlessGeneric"] = &depositHandlers.PermissionlessGenericDepositHandler{}
+	r.Register("erc4626", NewHandlerPlugin(ERC4626Transfer, &Erc4626DepositHandler{}, ERC4626MessageHandler))
+	r.Register("erc721Royalty", NewHandlerPlugin(RoyaltyNonFungibleTransfer, &Erc721RoyaltyDepositHandler{}, ERC721RoyaltyMessageHandler))
+	return r
+}
+
+// Register adds plugin for the handler type, overriding any previously registered plugin
+func (r *Registry) Register(handlerType string, plugin HandlerPlugin) {
+	r.plugins[handlerType] = plugin
+}
+
+// Plugin returns plugin registered for the handler type
+func (r *Registry) Plugin(handlerType string) (HandlerPlugin, error) {
+	plugin, ok := r.plugins[handlerType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported handler type %s", handlerType)
+	}
+	return plugin, nil
+}
+
+// DepositHandler returns deposit handler of the builtin or plugin handler type
+func (r *Registry) DepositHandler(handlerType string) (eventHandlers.DepositHandler, error) {
+	depositHandler, ok := r.builtins[handlerType]
+	if ok {
+		return depositHandler, nil
+	}
+
+	plugin, err := r.Plugin(handlerType)
+	if err != nil {
+		return nil, err
+	}
+	return plugin.DepositHandler(), nil
+}
+
+// RegisterDepositHandlers registers deposit handlers of all handlers configured for the
+// source domain. Handlers of unsupported types are skipped so deposits to them are
+// ignored instead of preventing the relayer from starting.
+func (r *Registry) RegisterDepositHandlers(handlers []evm.HandlerConfig, depositHandler DepositHandlerRegistrar) {
+	for _, handler := range handlers {
+		dh, err := r.DepositHandler(handler.Type)
+		if err != nil {
+			log.Warn().Err(err).Str("handler", handler.Address).Msgf("Skipping handler registration")
+			continue
+		}
+
+		depositHandler.RegisterDepositHandler(handler.Address, dh)
+	}
+}
+
+// RegisterTransferHandlers registers proposal encoders of all plugins on the transfer handler
+// of the destination domain executor, as plugin transfers can arrive from any source domain.
+// Builtin transfer types are encoded by the transfer handler itself.
+func (r *Registry) RegisterTransferHandlers(transferHandler TransferHandlerRegistrar) {
+	for _, plugin := range r.plugins {
+		transferHandler.RegisterTransferHandler(plugin.TransferType(), plugin.EncodeProposal)
+	}
+}
@@ -0,0 +1,121 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package plugins_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/executor"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/plugins"
+	evmE2E "github.com/ChainSafe/sygma-relayer/e2e/evm"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+)
+
+type mockHandlerMatcher struct {
+	handler common.Address
+}
+
+func (m *mockHandlerMatcher) GetHandlerAddressForResourceID(resourceID [32]byte) (common.Address, error) {
+	return m.handler, nil
+}
+
+type RegistryTestSuite struct {
+	suite.Suite
+	registry *plugins.Registry
+	handler  common.Address
+}
+
+func TestRunRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(RegistryTestSuite))
+}
+
+func (s *RegistryTestSuite) SetupTest() {
+	s.registry = plugins.NewRegistry()
+	s.handler = common.HexToAddress("0x02091EefF969b33A5CE8A729DaE325879bf76f90")
+}
+
+func (s *RegistryTestSuite) Test_Plugin_UnsupportedType() {
+	_, err := s.registry.Plugin("invalid")
+
+	s.NotNil(err)
+}
+
+func (s *RegistryTestSuite) Test_RegisterDepositHandlers_SkipsUnsupportedType() {
+	depositHandler := depositHandlers.NewETHDepositHandler(&mockHandlerMatcher{handler: s.handler})
+	s.registry.RegisterDepositHandlers(
+		[]evm.HandlerConfig{{Address: s.handler.Hex(), Type: "invalid"}},
+		depositHandler,
+	)
+
+	_, err := depositHandler.HandleDeposit(1, 2, 1, [32]byte{1}, []byte{}, []byte{}, "messageID", time.Now())
+
+	s.NotNil(err)
+}
+
+func (s *RegistryTestSuite) Test_RegisterDepositHandlers_BuiltinType() {
+	depositHandler := depositHandlers.NewETHDepositHandler(&mockHandlerMatcher{handler: s.handler})
+	s.registry.RegisterDepositHandlers(
+		[]evm.HandlerConfig{{Address: s.handler.Hex(), Type: "erc20"}},
+		depositHandler,
+	)
+	transferHandler := executor.NewTransferMessageHandler(nil, nil, nil)
+	s.registry.RegisterTransferHandlers(transferHandler)
+
+	recipient := common.HexToAddress("0xf1e58fb17704c2da8479a533f9fad4ad0993ca6b")
+	msg, err := depositHandler.HandleDeposit(
+		1, 2, 1, [32]byte{1}, evmE2E.ConstructErc20DepositData(recipient.Bytes(), big.NewInt(10)), []byte{}, "messageID", time.Now())
+	s.Nil(err)
+
+	s.Equal(msg.Data.(transfer.TransferMessageData).Type, transfer.FungibleTransfer)
+}
+
+func (s *RegistryTestSuite) Test_RegisterTransferHandlers_BridgesPluginTransfer() {
+	sourceDepositHandler := depositHandlers.NewETHDepositHandler(&mockHandlerMatcher{handler: s.handler})
+	s.registry.RegisterDepositHandlers(
+		[]evm.HandlerConfig{{Address: s.handler.Hex(), Type: "erc4626"}},
+		sourceDepositHandler,
+	)
+	// destination domain does not configure the plugin handler type
+	destinationTransferHandler := executor.NewTransferMessageHandler(nil, nil, nil)
+	s.registry.RegisterTransferHandlers(destinationTransferHandler)
+
+	recipient := common.HexToAddress("0xf1e58fb17704c2da8479a533f9fad4ad0993ca6b")
+	msg, err := sourceDepositHandler.HandleDeposit(
+		1,
+		2,
+		1,
+		[32]byte{1},
+		evmE2E.ConstructErc20DepositData(recipient.Bytes(), big.NewInt(10)),
+		common.LeftPadBytes(big.NewInt(12).Bytes(), 32),
+		"messageID",
+		time.Now(),
+	)
+	s.Nil(err)
+
+	prop, err := destinationTransferHandler.HandleMessage(msg)
+
+	s.Nil(err)
+	s.Equal(prop.Data.(transfer.TransferProposalData).Data, evmE2E.ConstructErc20DepositData(recipient.Bytes(), big.NewInt(12)))
+}
+
+func (s *RegistryTestSuite) Test_HandleMessage_PluginNotRegistered() {
+	transferHandler := executor.NewTransferMessageHandler(nil, nil, nil)
+
+	_, err := transferHandler.HandleMessage(&message.Message{
+		Data: transfer.TransferMessageData{
+			Type: plugins.ERC4626Transfer,
+		},
+		Type: transfer.TransferMessageType,
+	})
+
+	s.NotNil(err)
+}
@@ -0,0 +1,130 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package policy
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const FeeMetadataKey = "fee"
+
+type RawSelectorPolicy struct {
+	Selector string `mapstructure:"selector"`
+	MaxGas   uint64 `mapstructure:"maxGas"`
+}
+
+type RawTargetPolicy struct {
+	Address   string              `mapstructure:"address"`
+	MaxGas    uint64              `mapstructure:"maxGas"`
+	Selectors []RawSelectorPolicy `mapstructure:"selectors"`
+}
+
+type RawGenericPolicy struct {
+	MinFeePerGas string            `mapstructure:"minFeePerGas"`
+	Targets      []RawTargetPolicy `mapstructure:"targets"`
+}
+
+type TargetPolicy struct {
+	// MaxGas is the gas ceiling for calls to the target. Zero means no ceiling.
+	MaxGas uint64
+	// SelectorMaxGas overrides target gas ceiling per function selector
+	SelectorMaxGas map[string]uint64
+}
+
+// GenericPolicy restricts which permissionless generic proposals relayers are willing to execute
+type GenericPolicy struct {
+	MinFeePerGas *big.Int
+	Targets      map[common.Address]TargetPolicy
+}
+
+// NewGenericPolicy parses raw policy configuration. Returns nil policy if raw policy is nil.
+func NewGenericPolicy(raw *RawGenericPolicy) (*GenericPolicy, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	policy := &GenericPolicy{
+		Targets: make(map[common.Address]TargetPolicy),
+	}
+	if raw.MinFeePerGas != "" {
+		minFeePerGas, ok := new(big.Int).SetString(raw.MinFeePerGas, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid minFeePerGas %s", raw.MinFeePerGas)
+		}
+		policy.MinFeePerGas = minFeePerGas
+	}
+
+	for _, t := range raw.Targets {
+		if !common.IsHexAddress(t.Address) {
+			return nil, fmt.Errorf("invalid target address %s", t.Address)
+		}
+
+		target := TargetPolicy{
+			MaxGas:         t.MaxGas,
+			SelectorMaxGas: make(map[string]uint64),
+		}
+		for _, s := range t.Selectors {
+			selector, err := hexutil.Decode(s.Selector)
+			if err != nil || len(selector) != 4 {
+				return nil, fmt.Errorf("invalid function selector %s", s.Selector)
+			}
+			target.SelectorMaxGas[hexutil.Encode(selector)] = s.MaxGas
+		}
+		policy.Targets[common.HexToAddress(t.Address)] = target
+	}
+	return policy, nil
+}
+
+// Violation describes why a generic proposal was rejected by the policy
+type Violation struct {
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("generic policy violation: %s", v.Reason)
+}
+
+// Check validates permissionless generic call against the policy.
+// Returns nil if policy is not configured.
+func (p *GenericPolicy) Check(target []byte, selector []byte, gasLimit *big.Int, fee *big.Int) *Violation {
+	if p == nil {
+		return nil
+	}
+
+	maxGas := uint64(0)
+	if len(p.Targets) > 0 {
+		if len(target) != common.AddressLength {
+			return &Violation{Reason: fmt.Sprintf("invalid target address %s", hexutil.Encode(target))}
+		}
+		targetPolicy, ok := p.Targets[common.BytesToAddress(target)]
+		if !ok {
+			return &Violation{Reason: fmt.Sprintf("target %s not allowlisted", common.BytesToAddress(target))}
+		}
+
+		maxGas = targetPolicy.MaxGas
+		selectorMaxGas, ok := targetPolicy.SelectorMaxGas[strings.ToLower(hexutil.Encode(selector))]
+		if ok {
+			maxGas = selectorMaxGas
+		}
+	}
+	if maxGas != 0 && gasLimit.Cmp(new(big.Int).SetUint64(maxGas)) > 0 {
+		return &Violation{Reason: fmt.Sprintf("gas limit %s above ceiling %d", gasLimit, maxGas)}
+	}
+
+	if p.MinFeePerGas != nil && p.MinFeePerGas.Sign() > 0 {
+		if fee == nil {
+			return &Violation{Reason: "deposit fee unknown"}
+		}
+		minFee := new(big.Int).Mul(p.MinFeePerGas, gasLimit)
+		if fee.Cmp(minFee) < 0 {
+			return &Violation{Reason: fmt.Sprintf("fee %s below minimum %s for gas limit %s", fee, minFee, gasLimit)}
+		}
+	}
+	return nil
+}
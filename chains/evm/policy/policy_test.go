@@ -0,0 +1,110 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package policy_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/policy"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type GenericPolicyTestSuite struct {
+	suite.Suite
+	policy   *policy.GenericPolicy
+	target   common.Address
+	selector []byte
+}
+
+func TestRunGenericPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(GenericPolicyTestSuite))
+}
+
+func (s *GenericPolicyTestSuite) SetupTest() {
+	s.target = common.HexToAddress("0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b")
+	s.selector = []byte{0x65, 0x4c, 0xf8, 0x8c}
+	genericPolicy, err := policy.NewGenericPolicy(&policy.RawGenericPolicy{
+		MinFeePerGas: "10",
+		Targets: []policy.RawTargetPolicy{
+			{
+				Address: s.target.Hex(),
+				MaxGas:  500000,
+				Selectors: []policy.RawSelectorPolicy{
+					{
+						Selector: "0x654cf88c",
+						MaxGas:   100000,
+					},
+				},
+			},
+		},
+	})
+	s.Nil(err)
+	s.policy = genericPolicy
+}
+
+func (s *GenericPolicyTestSuite) Test_NewGenericPolicy_NilConfig() {
+	genericPolicy, err := policy.NewGenericPolicy(nil)
+
+	s.Nil(err)
+	s.Nil(genericPolicy)
+}
+
+func (s *GenericPolicyTestSuite) Test_NewGenericPolicy_InvalidSelector() {
+	_, err := policy.NewGenericPolicy(&policy.RawGenericPolicy{
+		Targets: []policy.RawTargetPolicy{
+			{
+				Address:   s.target.Hex(),
+				Selectors: []policy.RawSelectorPolicy{{Selector: "0x01"}},
+			},
+		},
+	})
+
+	s.NotNil(err)
+}
+
+func (s *GenericPolicyTestSuite) Test_Check_NilPolicy() {
+	var genericPolicy *policy.GenericPolicy
+
+	violation := genericPolicy.Check([]byte{1}, []byte{1}, big.NewInt(100000000), nil)
+
+	s.Nil(violation)
+}
+
+func (s *GenericPolicyTestSuite) Test_Check_TargetNotAllowlisted() {
+	violation := s.policy.Check(common.HexToAddress("0x1").Bytes(), s.selector, big.NewInt(100), big.NewInt(1000))
+
+	s.NotNil(violation)
+}
+
+func (s *GenericPolicyTestSuite) Test_Check_SelectorGasCeilingExceeded() {
+	violation := s.policy.Check(s.target.Bytes(), s.selector, big.NewInt(200000), big.NewInt(2000000))
+
+	s.NotNil(violation)
+}
+
+func (s *GenericPolicyTestSuite) Test_Check_TargetGasCeiling() {
+	violation := s.policy.Check(s.target.Bytes(), []byte{1, 2, 3, 4}, big.NewInt(200000), big.NewInt(2000000))
+
+	s.Nil(violation)
+}
+
+func (s *GenericPolicyTestSuite) Test_Check_FeeTooLow() {
+	violation := s.policy.Check(s.target.Bytes(), s.selector, big.NewInt(100000), big.NewInt(999999))
+
+	s.NotNil(violation)
+}
+
+func (s *GenericPolicyTestSuite) Test_Check_FeeUnknown() {
+	violation := s.policy.Check(s.target.Bytes(), s.selector, big.NewInt(100000), nil)
+
+	s.NotNil(violation)
+}
+
+func (s *GenericPolicyTestSuite) Test_Check_Valid() {
+	violation := s.policy.Check(s.target.Bytes(), s.selector, big.NewInt(100000), big.NewInt(1000000))
+
+	s.Nil(violation)
+}
@@ -0,0 +1,40 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
+)
+
+// RPCConnection adapts substrate connection to the connection used by the submitter
+type RPCConnection struct {
+	*connection.Connection
+}
+
+func NewRPCConnection(conn *connection.Connection) *RPCConnection {
+	return &RPCConnection{
+		Connection: conn,
+	}
+}
+
+func (c *RPCConnection) GetGenesisHash() types.Hash {
+	return c.GenesisHash
+}
+
+func (c *RPCConnection) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
+	return c.RPC.State.GetRuntimeVersionLatest()
+}
+
+// AccountNextIndex returns the next account nonce, taking into account
+// transactions of the account that are still in the transaction pool
+func (c *RPCConnection) AccountNextIndex(address string) (types.U32, error) {
+	var nonce types.U32
+	err := c.Client.Call(&nonce, "system_accountNextIndex", address)
+	return nonce, err
+}
+
+func (c *RPCConnection) SubmitAndWatchExtrinsic(ext types.Extrinsic) (StatusSubscription, error) {
+	return c.RPC.Author.SubmitAndWatchExtrinsic(ext)
+}
@@ -0,0 +1,63 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client
+
+import (
+	"math/bits"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	minEraPeriod = 4
+	maxEraPeriod = 1 << 16
+)
+
+// NewMortalEra creates era valid for period blocks starting from the current block and returns
+// the era birth block. Period is rounded up to the power of two and the era is encoded the same
+// way as substrate encodes Era::mortal. For periods above 4096 blocks the phase is quantized, so
+// the birth block can be lower than the current block and the extrinsic has to be signed with the
+// hash of the birth block.
+func NewMortalEra(period uint64, current uint64) (types.ExtrinsicEra, uint64) {
+	if period < minEraPeriod {
+		period = minEraPeriod
+	}
+	if period > maxEraPeriod {
+		period = maxEraPeriod
+	}
+	if period&(period-1) != 0 {
+		period = 1 << bits.Len64(period)
+	}
+
+	phase := current % period
+	quantizeFactor := period >> 12
+	if quantizeFactor < 1 {
+		quantizeFactor = 1
+	}
+	quantizedPhase := phase / quantizeFactor * quantizeFactor
+
+	periodBits := bits.TrailingZeros64(period) - 1
+	if periodBits < 1 {
+		periodBits = 1
+	}
+	if periodBits > 15 {
+		periodBits = 15
+	}
+	encoded := uint16(periodBits) | uint16(quantizedPhase/quantizeFactor)<<4
+	era := types.ExtrinsicEra{
+		IsMortalEra: true,
+		AsMortalEra: types.MortalEra{
+			First:  byte(encoded),
+			Second: byte(encoded >> 8),
+		},
+	}
+
+	// same as Era::birth in substrate
+	birth := current
+	if birth < quantizedPhase {
+		birth = quantizedPhase
+	}
+	birth = (birth-quantizedPhase)/period*period + quantizedPhase
+	return era, birth
+}
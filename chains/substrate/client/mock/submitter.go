@@ -0,0 +1,217 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/substrate/client/submitter.go
+
+// Package mock_client is a generated GoMock package.
+package mock_client
+
+import (
+	reflect "reflect"
+
+	client "github.com/ChainSafe/sygma-relayer/chains/substrate/client"
+	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStatusSubscription is a mock of StatusSubscription interface.
+type MockStatusSubscription struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusSubscriptionMockRecorder
+}
+
+// MockStatusSubscriptionMockRecorder is the mock recorder for MockStatusSubscription.
+type MockStatusSubscriptionMockRecorder struct {
+	mock *MockStatusSubscription
+}
+
+// NewMockStatusSubscription creates a new mock instance.
+func NewMockStatusSubscription(ctrl *gomock.Controller) *MockStatusSubscription {
+	mock := &MockStatusSubscription{ctrl: ctrl}
+	mock.recorder = &MockStatusSubscriptionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusSubscription) EXPECT() *MockStatusSubscriptionMockRecorder {
+	return m.recorder
+}
+
+// Chan mocks base method.
+func (m *MockStatusSubscription) Chan() <-chan types.ExtrinsicStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Chan")
+	ret0, _ := ret[0].(<-chan types.ExtrinsicStatus)
+	return ret0
+}
+
+// Chan indicates an expected call of Chan.
+func (mr *MockStatusSubscriptionMockRecorder) Chan() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chan", reflect.TypeOf((*MockStatusSubscription)(nil).Chan))
+}
+
+// Err mocks base method.
+func (m *MockStatusSubscription) Err() <-chan error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Err")
+	ret0, _ := ret[0].(<-chan error)
+	return ret0
+}
+
+// Err indicates an expected call of Err.
+func (mr *MockStatusSubscriptionMockRecorder) Err() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Err", reflect.TypeOf((*MockStatusSubscription)(nil).Err))
+}
+
+// Unsubscribe mocks base method.
+func (m *MockStatusSubscription) Unsubscribe() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe")
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockStatusSubscriptionMockRecorder) Unsubscribe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockStatusSubscription)(nil).Unsubscribe))
+}
+
+// MockConnection is a mock of Connection interface.
+type MockConnection struct {
+	ctrl     *gomock.Controller
+	recorder *MockConnectionMockRecorder
+}
+
+// MockConnectionMockRecorder is the mock recorder for MockConnection.
+type MockConnectionMockRecorder struct {
+	mock *MockConnection
+}
+
+// NewMockConnection creates a new mock instance.
+func NewMockConnection(ctrl *gomock.Controller) *MockConnection {
+	mock := &MockConnection{ctrl: ctrl}
+	mock.recorder = &MockConnectionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConnection) EXPECT() *MockConnectionMockRecorder {
+	return m.recorder
+}
+
+// AccountNextIndex mocks base method.
+func (m *MockConnection) AccountNextIndex(address string) (types.U32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountNextIndex", address)
+	ret0, _ := ret[0].(types.U32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountNextIndex indicates an expected call of AccountNextIndex.
+func (mr *MockConnectionMockRecorder) AccountNextIndex(address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountNextIndex", reflect.TypeOf((*MockConnection)(nil).AccountNextIndex), address)
+}
+
+// GetBlockHash mocks base method.
+func (m *MockConnection) GetBlockHash(blockNumber uint64) (types.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockHash", blockNumber)
+	ret0, _ := ret[0].(types.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHash indicates an expected call of GetBlockHash.
+func (mr *MockConnectionMockRecorder) GetBlockHash(blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHash", reflect.TypeOf((*MockConnection)(nil).GetBlockHash), blockNumber)
+}
+
+// GetFinalizedHead mocks base method.
+func (m *MockConnection) GetFinalizedHead() (types.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFinalizedHead")
+	ret0, _ := ret[0].(types.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFinalizedHead indicates an expected call of GetFinalizedHead.
+func (mr *MockConnectionMockRecorder) GetFinalizedHead() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFinalizedHead", reflect.TypeOf((*MockConnection)(nil).GetFinalizedHead))
+}
+
+// GetGenesisHash mocks base method.
+func (m *MockConnection) GetGenesisHash() types.Hash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenesisHash")
+	ret0, _ := ret[0].(types.Hash)
+	return ret0
+}
+
+// GetGenesisHash indicates an expected call of GetGenesisHash.
+func (mr *MockConnectionMockRecorder) GetGenesisHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenesisHash", reflect.TypeOf((*MockConnection)(nil).GetGenesisHash))
+}
+
+// GetHeader mocks base method.
+func (m *MockConnection) GetHeader(blockHash types.Hash) (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeader", blockHash)
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeader indicates an expected call of GetHeader.
+func (mr *MockConnectionMockRecorder) GetHeader(blockHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockConnection)(nil).GetHeader), blockHash)
+}
+
+// GetMetadata mocks base method.
+func (m *MockConnection) GetMetadata() types.Metadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadata")
+	ret0, _ := ret[0].(types.Metadata)
+	return ret0
+}
+
+// GetMetadata indicates an expected call of GetMetadata.
+func (mr *MockConnectionMockRecorder) GetMetadata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockConnection)(nil).GetMetadata))
+}
+
+// GetRuntimeVersionLatest mocks base method.
+func (m *MockConnection) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuntimeVersionLatest")
+	ret0, _ := ret[0].(*types.RuntimeVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuntimeVersionLatest indicates an expected call of GetRuntimeVersionLatest.
+func (mr *MockConnectionMockRecorder) GetRuntimeVersionLatest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntimeVersionLatest", reflect.TypeOf((*MockConnection)(nil).GetRuntimeVersionLatest))
+}
+
+// SubmitAndWatchExtrinsic mocks base method.
+func (m *MockConnection) SubmitAndWatchExtrinsic(ext types.Extrinsic) (client.StatusSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAndWatchExtrinsic", ext)
+	ret0, _ := ret[0].(client.StatusSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitAndWatchExtrinsic indicates an expected call of SubmitAndWatchExtrinsic.
+func (mr *MockConnectionMockRecorder) SubmitAndWatchExtrinsic(ext interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAndWatchExtrinsic", reflect.TypeOf((*MockConnection)(nil).SubmitAndWatchExtrinsic), ext)
+}
@@ -0,0 +1,386 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/rs/zerolog/log"
+)
+
+var ErrAlreadyIncluded = errors.New("extrinsic call already included")
+
+var (
+	// watchTimeout is the time extrinsic has to be finalized in before it is resubmitted
+	watchTimeout = time.Minute * 10
+	maxResubmits = 5
+	// nonceErrors are submission errors caused by a stale local nonce
+	nonceErrors = []string{"Priority is too low", "Transaction is outdated"}
+)
+
+type StatusSubscription interface {
+	Chan() <-chan types.ExtrinsicStatus
+	Err() <-chan error
+	Unsubscribe()
+}
+
+type Connection interface {
+	GetMetadata() types.Metadata
+	GetGenesisHash() types.Hash
+	GetFinalizedHead() (types.Hash, error)
+	GetBlockHash(blockNumber uint64) (types.Hash, error)
+	GetHeader(blockHash types.Hash) (*types.Header, error)
+	GetRuntimeVersionLatest() (*types.RuntimeVersion, error)
+	AccountNextIndex(address string) (types.U32, error)
+	SubmitAndWatchExtrinsic(ext types.Extrinsic) (StatusSubscription, error)
+}
+
+type pendingExtrinsic struct {
+	call      types.Call
+	nonce     types.U32
+	tip       uint64
+	resubmits int
+}
+
+// Submitter submits extrinsics of a single account to a substrate domain.
+// Nonces are allocated locally so concurrent submissions do not collide and
+// are resynchronised with the chain on submission errors.
+type Submitter struct {
+	conn        Connection
+	key         *signature.KeyringPair
+	tip         uint64
+	maxTip      uint64
+	tipIncrease uint64
+	eraPeriod   uint64
+
+	nonceLock sync.Mutex
+	nonce     types.U32
+	synced    bool
+
+	pendingLock sync.Mutex
+	pending     map[types.Hash]*pendingExtrinsic
+}
+
+// NewSubmitter creates an instance of a submitter that signs extrinsics with mortal eras
+// valid for eraPeriod blocks and resubmits extrinsics that were not finalized.
+//
+// Tip of resubmitted extrinsics is increased by tipIncrease percentage capped
+// at maxTip, or uncapped if maxTip is zero.
+func NewSubmitter(
+	conn Connection,
+	key *signature.KeyringPair,
+	tip uint64,
+	maxTip uint64,
+	tipIncrease uint64,
+	eraPeriod uint64,
+) *Submitter {
+	return &Submitter{
+		conn:        conn,
+		key:         key,
+		tip:         tip,
+		maxTip:      maxTip,
+		tipIncrease: tipIncrease,
+		eraPeriod:   eraPeriod,
+		pending:     make(map[types.Hash]*pendingExtrinsic),
+	}
+}
+
+// Transact constructs and submits an extrinsic to call the method with the given arguments.
+// All args are passed directly into GSRPC. GSRPC types are recommended to avoid serialization inconsistencies.
+func (s *Submitter) Transact(method string, args ...interface{}) (types.Hash, StatusSubscription, error) {
+	log.Debug().Msgf("Submitting substrate call... method %s, sender %s", method, s.key.Address)
+
+	meta := s.conn.GetMetadata()
+	call, err := types.NewCall(&meta, method, args...)
+	if err != nil {
+		return types.Hash{}, nil, fmt.Errorf("failed to construct call: %w", err)
+	}
+
+	s.nonceLock.Lock()
+	defer s.nonceLock.Unlock()
+
+	nonce, err := s.nextNonce()
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+	hash, sub, err := s.submit(call, nonce, s.tip)
+	if err != nil && isNonceError(err) {
+		log.Warn().Err(err).Msgf("Stale nonce %d, resynchronising nonce and resubmitting", nonce)
+		s.synced = false
+		nonce, err = s.nextNonce()
+		if err != nil {
+			return types.Hash{}, nil, err
+		}
+		hash, sub, err = s.submit(call, nonce, s.tip)
+	}
+	if err != nil {
+		s.synced = false
+		return types.Hash{}, nil, fmt.Errorf("submission of extrinsic failed: %w", err)
+	}
+
+	log.Info().Str("extrinsic", hash.Hex()).Msgf("Extrinsic call submitted... method %s, sender %s, nonce %d", method, s.key.Address, nonce)
+	s.nonce = nonce + 1
+	s.pendingLock.Lock()
+	s.pending[hash] = &pendingExtrinsic{
+		call:  call,
+		nonce: nonce,
+		tip:   s.tip,
+	}
+	s.pendingLock.Unlock()
+	return hash, sub, nil
+}
+
+// Watch waits for the extrinsic to be finalized until the context is done. Extrinsics that are
+// dropped from the pool or not finalized in time are resubmitted with the same nonce and increased tip,
+// while invalid or usurped extrinsics are resubmitted with a resynchronised nonce unless included
+// reports that the call was already included on chain, in which case ErrAlreadyIncluded is returned.
+//
+// Returns hash of the finalized extrinsic, which changes on resubmission, and
+// hash of the block it was finalized in.
+func (s *Submitter) Watch(
+	ctx context.Context,
+	extHash types.Hash,
+	sub StatusSubscription,
+	included func() (bool, error),
+) (types.Hash, types.Hash, error) {
+	s.pendingLock.Lock()
+	pending, ok := s.pending[extHash]
+	s.pendingLock.Unlock()
+	if !ok {
+		return types.Hash{}, types.Hash{}, fmt.Errorf("extrinsic %s not submitted by the submitter", extHash.Hex())
+	}
+	defer func() {
+		s.removePending(extHash)
+	}()
+
+	for {
+		blockHash, resync, err := s.watch(ctx, extHash, sub)
+		if err != nil {
+			return types.Hash{}, types.Hash{}, err
+		}
+		if blockHash != nil {
+			return extHash, *blockHash, nil
+		}
+
+		if pending.resubmits >= maxResubmits {
+			return types.Hash{}, types.Hash{}, fmt.Errorf("extrinsic not finalized after %d resubmissions", maxResubmits)
+		}
+
+		// extrinsic with a resynchronised nonce could execute the call twice
+		if resync {
+			isIncluded, err := included()
+			if err != nil {
+				return types.Hash{}, types.Hash{}, fmt.Errorf("failed checking extrinsic inclusion before resubmission: %w", err)
+			}
+			if isIncluded {
+				return types.Hash{}, types.Hash{}, ErrAlreadyIncluded
+			}
+		}
+
+		newHash, newSub, err := s.resubmit(pending, resync)
+		if err != nil {
+			return types.Hash{}, types.Hash{}, err
+		}
+
+		log.Warn().Str("extrinsic", newHash.Hex()).Msgf("Resubmitted extrinsic %s with nonce %d and tip %d", extHash.Hex(), pending.nonce, pending.tip)
+		s.pendingLock.Lock()
+		delete(s.pending, extHash)
+		s.pending[newHash] = pending
+		s.pendingLock.Unlock()
+		extHash = newHash
+		sub = newSub
+	}
+}
+
+// watch waits for the extrinsic status and returns finalized block hash or
+// if the extrinsic should be resubmitted with a resynchronised nonce
+func (s *Submitter) watch(ctx context.Context, extHash types.Hash, sub StatusSubscription) (*types.Hash, bool, error) {
+	timeout := time.NewTimer(watchTimeout)
+	defer timeout.Stop()
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case status := <-sub.Chan():
+			{
+				switch {
+				case status.IsInBlock:
+					log.Debug().Str("extrinsic", extHash.Hex()).Msgf("Extrinsic in block with hash: %#x", status.AsInBlock)
+				case status.IsFinalized:
+					log.Info().Str("extrinsic", extHash.Hex()).Msgf("Extrinsic is finalized in block with hash: %#x", status.AsFinalized)
+					return &status.AsFinalized, false, nil
+				case status.IsDropped, status.IsFinalityTimeout:
+					log.Warn().Str("extrinsic", extHash.Hex()).Msgf("Extrinsic dropped from the transaction pool")
+					return nil, false, nil
+				case status.IsInvalid, status.IsUsurped:
+					log.Warn().Str("extrinsic", extHash.Hex()).Msgf("Extrinsic invalid or usurped")
+					return nil, true, nil
+				}
+			}
+		case err := <-sub.Err():
+			log.Warn().Str("extrinsic", extHash.Hex()).Err(err).Msgf("Extrinsic subscription failed")
+			return nil, false, nil
+		case <-timeout.C:
+			log.Warn().Str("extrinsic", extHash.Hex()).Msgf("Extrinsic not finalized in %s", watchTimeout)
+			return nil, false, nil
+		case <-ctx.Done():
+			return nil, false, fmt.Errorf("stopped watching extrinsic %s: %w", extHash.Hex(), ctx.Err())
+		}
+	}
+}
+
+func (s *Submitter) resubmit(pending *pendingExtrinsic, resync bool) (types.Hash, StatusSubscription, error) {
+	s.nonceLock.Lock()
+	defer s.nonceLock.Unlock()
+
+	nonce := pending.nonce
+	if resync {
+		s.synced = false
+		var err error
+		nonce, err = s.nextNonce()
+		if err != nil {
+			return types.Hash{}, nil, err
+		}
+	}
+
+	tip := s.IncreaseTip(pending.tip)
+	hash, sub, err := s.submit(pending.call, nonce, tip)
+	if err != nil {
+		s.synced = false
+		return types.Hash{}, nil, fmt.Errorf("resubmission of extrinsic failed: %w", err)
+	}
+
+	if resync {
+		s.nonce = nonce + 1
+	}
+	pending.nonce = nonce
+	pending.tip = tip
+	pending.resubmits++
+	return hash, sub, nil
+}
+
+func (s *Submitter) submit(call types.Call, nonce types.U32, tip uint64) (types.Hash, StatusSubscription, error) {
+	rv, err := s.conn.GetRuntimeVersionLatest()
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+	finalizedHash, err := s.conn.GetFinalizedHead()
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+	finalized, err := s.conn.GetHeader(finalizedHash)
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+	era, birth := NewMortalEra(s.eraPeriod, uint64(finalized.Number))
+	birthHash := finalizedHash
+	if birth != uint64(finalized.Number) {
+		birthHash, err = s.conn.GetBlockHash(birth)
+		if err != nil {
+			return types.Hash{}, nil, err
+		}
+	}
+
+	ext := types.NewExtrinsic(call)
+	o := types.SignatureOptions{
+		BlockHash:          birthHash,
+		Era:                era,
+		GenesisHash:        s.conn.GetGenesisHash(),
+		Nonce:              types.NewUCompactFromUInt(uint64(nonce)),
+		SpecVersion:        rv.SpecVersion,
+		Tip:                types.NewUCompactFromUInt(tip),
+		TransactionVersion: rv.TransactionVersion,
+	}
+	err = ext.Sign(*s.key, o)
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+
+	sub, err := s.conn.SubmitAndWatchExtrinsic(ext)
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+
+	hash, err := ExtrinsicHash(ext)
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+	return hash, sub, nil
+}
+
+// nextNonce returns the locally tracked nonce, fetching it from the chain
+// if it is not synchronised
+func (s *Submitter) nextNonce() (types.U32, error) {
+	if s.synced {
+		return s.nonce, nil
+	}
+
+	nonce, err := s.conn.AccountNextIndex(s.key.Address)
+	if err != nil {
+		return 0, err
+	}
+	s.nonce = nonce
+	s.synced = true
+	return nonce, nil
+}
+
+func (s *Submitter) removePending(extHash types.Hash) {
+	s.pendingLock.Lock()
+	delete(s.pending, extHash)
+	s.pendingLock.Unlock()
+}
+
+// IncreaseTip bumps tip by preset percentage.
+//
+// If tip was 10 and the increase is 15 the new tip
+// would be 11 (it floors the value). In case the tip didn't
+// change it increases it by 1. The increased tip never exceeds
+// the max tip, if one is configured.
+func (s *Submitter) IncreaseTip(tip uint64) uint64 {
+	increasedTip := tip + tip*s.tipIncrease/100
+	if increasedTip == tip {
+		increasedTip = tip + 1
+	}
+
+	if s.maxTip != 0 && increasedTip > s.maxTip {
+		return s.maxTip
+	}
+	return increasedTip
+}
+
+func isNonceError(err error) bool {
+	for _, nonceErr := range nonceErrors {
+		if strings.Contains(err.Error(), nonceErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtrinsicHash returns blake2b-256 hash of the encoded extrinsic
+func ExtrinsicHash(ext types.Extrinsic) (types.Hash, error) {
+	encoded, err := codec.Encode(ext)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	h, err := hash.NewBlake2b256(nil)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	_, err = h.Write(encoded)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return types.NewHash(h.Sum(nil)), nil
+}
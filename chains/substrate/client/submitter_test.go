@@ -0,0 +1,300 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/client"
+	mock_client "github.com/ChainSafe/sygma-relayer/chains/substrate/client/mock"
+)
+
+type SubmitterTestSuite struct {
+	suite.Suite
+
+	mockConn  *mock_client.MockConnection
+	submitter *client.Submitter
+	submitted []types.Extrinsic
+}
+
+func TestRunSubmitterTestSuite(t *testing.T) {
+	suite.Run(t, new(SubmitterTestSuite))
+}
+
+func (s *SubmitterTestSuite) SetupTest() {
+	var meta types.Metadata
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	s.Nil(err)
+
+	ctrl := gomock.NewController(s.T())
+	s.mockConn = mock_client.NewMockConnection(ctrl)
+	s.mockConn.EXPECT().GetMetadata().Return(meta).AnyTimes()
+	s.mockConn.EXPECT().GetGenesisHash().Return(types.Hash{1}).AnyTimes()
+	s.mockConn.EXPECT().GetFinalizedHead().Return(types.Hash{2}, nil).AnyTimes()
+	s.mockConn.EXPECT().GetHeader(types.Hash{2}).Return(&types.Header{Number: 100}, nil).AnyTimes()
+	s.mockConn.EXPECT().GetRuntimeVersionLatest().Return(&types.RuntimeVersion{SpecVersion: 1, TransactionVersion: 1}, nil).AnyTimes()
+	s.submitted = make([]types.Extrinsic, 0)
+	s.submitter = client.NewSubmitter(s.mockConn, &signature.TestKeyringPairAlice, 10, 12, 15, 64)
+}
+
+func (s *SubmitterTestSuite) notIncluded() (bool, error) {
+	return false, nil
+}
+
+func (s *SubmitterTestSuite) subscription(statuses ...types.ExtrinsicStatus) *mock_client.MockStatusSubscription {
+	statusChn := make(chan types.ExtrinsicStatus, len(statuses))
+	for _, status := range statuses {
+		statusChn <- status
+	}
+
+	sub := mock_client.NewMockStatusSubscription(gomock.NewController(s.T()))
+	sub.EXPECT().Chan().Return(statusChn).AnyTimes()
+	sub.EXPECT().Err().Return(nil).AnyTimes()
+	sub.EXPECT().Unsubscribe().AnyTimes()
+	return sub
+}
+
+func (s *SubmitterTestSuite) expectSubmission(sub client.StatusSubscription, err error) {
+	s.mockConn.EXPECT().SubmitAndWatchExtrinsic(gomock.Any()).DoAndReturn(func(ext types.Extrinsic) (client.StatusSubscription, error) {
+		s.submitted = append(s.submitted, ext)
+		return sub, err
+	})
+}
+
+func (s *SubmitterTestSuite) Test_Transact_AllocatesNoncesLocally() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(5), nil)
+	s.expectSubmission(s.subscription(), nil)
+	s.expectSubmission(s.subscription(), nil)
+
+	_, _, err := s.submitter.Transact("System.remark", []byte{1})
+	s.Nil(err)
+	_, _, err = s.submitter.Transact("System.remark", []byte{2})
+	s.Nil(err)
+
+	s.Equal(s.submitted[0].Signature.Nonce, types.NewUCompactFromUInt(5))
+	s.Equal(s.submitted[1].Signature.Nonce, types.NewUCompactFromUInt(6))
+	s.True(s.submitted[0].Signature.Era.IsMortalEra)
+}
+
+func (s *SubmitterTestSuite) Test_Transact_ResyncsNonceOnError() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(5), nil)
+	s.expectSubmission(nil, errors.New("connection closed"))
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(9), nil)
+	s.expectSubmission(s.subscription(), nil)
+
+	_, _, err := s.submitter.Transact("System.remark", []byte{1})
+	s.NotNil(err)
+	_, _, err = s.submitter.Transact("System.remark", []byte{1})
+	s.Nil(err)
+
+	s.Equal(s.submitted[1].Signature.Nonce, types.NewUCompactFromUInt(9))
+}
+
+func (s *SubmitterTestSuite) Test_Transact_StaleNonceResubmitted() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(5), nil)
+	s.expectSubmission(nil, errors.New("1014: Priority is too low"))
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(6), nil)
+	s.expectSubmission(s.subscription(), nil)
+
+	_, _, err := s.submitter.Transact("System.remark", []byte{1})
+
+	s.Nil(err)
+	s.Equal(s.submitted[1].Signature.Nonce, types.NewUCompactFromUInt(6))
+}
+
+func (s *SubmitterTestSuite) Test_Watch_UnknownExtrinsic() {
+	_, _, err := s.submitter.Watch(context.Background(), types.Hash{1}, s.subscription(), s.notIncluded)
+
+	s.NotNil(err)
+}
+
+func (s *SubmitterTestSuite) Test_Watch_Finalized() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(5), nil)
+	s.expectSubmission(s.subscription(
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: types.Hash{3}},
+		types.ExtrinsicStatus{IsFinalized: true, AsFinalized: types.Hash{3}},
+	), nil)
+	hash, sub, err := s.submitter.Transact("System.remark", []byte{1})
+	s.Nil(err)
+
+	extHash, blockHash, err := s.submitter.Watch(context.Background(), hash, sub, s.notIncluded)
+
+	s.Nil(err)
+	s.Equal(extHash, hash)
+	s.Equal(blockHash, types.Hash{3})
+}
+
+func (s *SubmitterTestSuite) Test_Watch_DroppedResubmittedWithHigherTip() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(5), nil)
+	s.expectSubmission(s.subscription(types.ExtrinsicStatus{IsDropped: true}), nil)
+	s.expectSubmission(s.subscription(types.ExtrinsicStatus{IsFinalized: true, AsFinalized: types.Hash{3}}), nil)
+	hash, sub, err := s.submitter.Transact("System.remark", []byte{1})
+	s.Nil(err)
+
+	extHash, blockHash, err := s.submitter.Watch(context.Background(), hash, sub, s.notIncluded)
+
+	s.Nil(err)
+	s.NotEqual(extHash, hash)
+	s.Equal(blockHash, types.Hash{3})
+	s.Equal(s.submitted[1].Signature.Nonce, types.NewUCompactFromUInt(5))
+	s.Equal(s.submitted[1].Signature.Tip, types.NewUCompactFromUInt(11))
+}
+
+func (s *SubmitterTestSuite) Test_Watch_InvalidResubmittedWithResyncedNonce() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(5), nil)
+	s.expectSubmission(s.subscription(types.ExtrinsicStatus{IsInvalid: true}), nil)
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(7), nil)
+	s.expectSubmission(s.subscription(types.ExtrinsicStatus{IsFinalized: true, AsFinalized: types.Hash{3}}), nil)
+	hash, sub, err := s.submitter.Transact("System.remark", []byte{1})
+	s.Nil(err)
+
+	_, _, err = s.submitter.Watch(context.Background(), hash, sub, s.notIncluded)
+
+	s.Nil(err)
+	s.Equal(s.submitted[1].Signature.Nonce, types.NewUCompactFromUInt(7))
+}
+
+func (s *SubmitterTestSuite) Test_Watch_InvalidAlreadyIncluded() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(5), nil)
+	s.expectSubmission(s.subscription(types.ExtrinsicStatus{IsUsurped: true}), nil)
+	hash, sub, err := s.submitter.Transact("System.remark", []byte{1})
+	s.Nil(err)
+
+	_, _, err = s.submitter.Watch(context.Background(), hash, sub, func() (bool, error) {
+		return true, nil
+	})
+
+	s.ErrorIs(err, client.ErrAlreadyIncluded)
+	s.Equal(len(s.submitted), 1)
+}
+
+func (s *SubmitterTestSuite) Test_Watch_InclusionCheckFails() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(5), nil)
+	s.expectSubmission(s.subscription(types.ExtrinsicStatus{IsInvalid: true}), nil)
+	hash, sub, err := s.submitter.Transact("System.remark", []byte{1})
+	s.Nil(err)
+
+	_, _, err = s.submitter.Watch(context.Background(), hash, sub, func() (bool, error) {
+		return false, errors.New("error")
+	})
+
+	s.NotNil(err)
+	s.Equal(len(s.submitted), 1)
+}
+
+func (s *SubmitterTestSuite) Test_Watch_ContextDone() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(5), nil)
+	s.expectSubmission(s.subscription(), nil)
+	hash, sub, err := s.submitter.Transact("System.remark", []byte{1})
+	s.Nil(err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	_, _, err = s.submitter.Watch(ctx, hash, sub, s.notIncluded)
+
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func (s *SubmitterTestSuite) Test_Transact_SignsWithQuantizedBirthBlock() {
+	ctrl := gomock.NewController(s.T())
+	conn := mock_client.NewMockConnection(ctrl)
+	var meta types.Metadata
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	s.Nil(err)
+	conn.EXPECT().GetMetadata().Return(meta)
+	conn.EXPECT().GetGenesisHash().Return(types.Hash{1})
+	conn.EXPECT().GetFinalizedHead().Return(types.Hash{2}, nil)
+	conn.EXPECT().GetHeader(types.Hash{2}).Return(&types.Header{Number: 10001}, nil)
+	conn.EXPECT().GetBlockHash(uint64(10000)).Return(types.Hash{3}, nil)
+	conn.EXPECT().GetRuntimeVersionLatest().Return(&types.RuntimeVersion{SpecVersion: 1, TransactionVersion: 1}, nil)
+	conn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(5), nil)
+	conn.EXPECT().SubmitAndWatchExtrinsic(gomock.Any()).Return(s.subscription(), nil)
+	submitter := client.NewSubmitter(conn, &signature.TestKeyringPairAlice, 10, 12, 15, 8192)
+
+	_, _, err = submitter.Transact("System.remark", []byte{1})
+
+	s.Nil(err)
+}
+
+func (s *SubmitterTestSuite) Test_Watch_MaxResubmissions() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(types.U32(5), nil)
+	for i := 0; i < 6; i++ {
+		s.expectSubmission(s.subscription(types.ExtrinsicStatus{IsDropped: true}), nil)
+	}
+	hash, sub, err := s.submitter.Transact("System.remark", []byte{1})
+	s.Nil(err)
+
+	_, _, err = s.submitter.Watch(context.Background(), hash, sub, s.notIncluded)
+
+	s.NotNil(err)
+	s.Equal(len(s.submitted), 6)
+}
+
+func (s *SubmitterTestSuite) Test_IncreaseTip() {
+	s.Equal(s.submitter.IncreaseTip(10), uint64(11))
+	s.Equal(s.submitter.IncreaseTip(11), uint64(12))
+	s.Equal(s.submitter.IncreaseTip(0), uint64(1))
+}
+
+func (s *SubmitterTestSuite) Test_IncreaseTip_StaysAtMaxTip() {
+	tip := s.submitter.IncreaseTip(12)
+	s.Equal(tip, uint64(12))
+
+	tip = s.submitter.IncreaseTip(tip)
+	s.Equal(tip, uint64(12))
+}
+
+func (s *SubmitterTestSuite) Test_IncreaseTip_NoMaxTip() {
+	submitter := client.NewSubmitter(s.mockConn, &signature.TestKeyringPairAlice, 10, 0, 15, 64)
+
+	s.Equal(submitter.IncreaseTip(100), uint64(115))
+}
+
+type MortalEraTestSuite struct {
+	suite.Suite
+}
+
+func TestRunMortalEraTestSuite(t *testing.T) {
+	suite.Run(t, new(MortalEraTestSuite))
+}
+
+func (s *MortalEraTestSuite) Test_NewMortalEra() {
+	era, birth := client.NewMortalEra(64, 42)
+
+	s.True(era.IsMortalEra)
+	s.Equal(era.AsMortalEra, types.MortalEra{First: 165, Second: 2})
+	s.Equal(birth, uint64(42))
+}
+
+func (s *MortalEraTestSuite) Test_NewMortalEra_PeriodRoundedUp() {
+	era, _ := client.NewMortalEra(50, 42)
+	expectedEra, _ := client.NewMortalEra(64, 42)
+
+	s.Equal(era, expectedEra)
+}
+
+func (s *MortalEraTestSuite) Test_NewMortalEra_PeriodClamped() {
+	era, _ := client.NewMortalEra(1, 2)
+	expectedEra, _ := client.NewMortalEra(4, 2)
+	s.Equal(era, expectedEra)
+
+	era, _ = client.NewMortalEra(1<<20, 2)
+	expectedEra, _ = client.NewMortalEra(1<<16, 2)
+	s.Equal(era, expectedEra)
+}
+
+func (s *MortalEraTestSuite) Test_NewMortalEra_QuantizedBirth() {
+	_, birth := client.NewMortalEra(8192, 10001)
+
+	s.Equal(birth, uint64(10000))
+}
@@ -17,29 +17,50 @@ import (
 
 type RawSubstrateConfig struct {
 	chain.GeneralChainConfig `mapstructure:",squash"`
-	ChainID                  int64  `mapstructure:"chainID"`
-	StartBlock               int64  `mapstructure:"startBlock"`
-	BlockInterval            int64  `mapstructure:"blockInterval" default:"5"`
-	BlockRetryInterval       uint64 `mapstructure:"blockRetryInterval" default:"5"`
-	SubstrateNetwork         int64  `mapstructure:"substrateNetwork"`
-	Tip                      uint64 `mapstructure:"tip"`
+	ChainID                  int64                     `mapstructure:"chainID"`
+	StartBlock               int64                     `mapstructure:"startBlock"`
+	BlockInterval            int64                     `mapstructure:"blockInterval" default:"5"`
+	BlockRetryInterval       uint64                    `mapstructure:"blockRetryInterval" default:"5"`
+	SubstrateNetwork         int64                     `mapstructure:"substrateNetwork"`
+	Tip                      uint64                    `mapstructure:"tip"`
+	MaxTip                   uint64                    `mapstructure:"maxTip"`
+	TipIncreasePercentage    uint64                    `mapstructure:"tipIncreasePercentage" default:"15"`
+	EraPeriod                uint64                    `mapstructure:"eraPeriod" default:"64"`
+	VerificationEndpoints    []string                  `mapstructure:"verificationEndpoints"`
+	VerificationQuorum       int                       `mapstructure:"verificationQuorum"`
+	EventBatchSize           uint64                    `mapstructure:"eventBatchSize" default:"100"`
+	EventFetchParallelism    int                       `mapstructure:"eventFetchParallelism" default:"4"`
+	ParachainID              *uint32                   `mapstructure:"parachainID"`
+	Parachains               []uint32                  `mapstructure:"parachains"`
+	Resources                []chain.RawResourceConfig `mapstructure:"resources"`
 }
 
 type SubstrateConfig struct {
-	GeneralChainConfig chain.GeneralChainConfig
-	ChainID            *big.Int
-	StartBlock         *big.Int
-	BlockInterval      *big.Int
-	BlockRetryInterval time.Duration
-	SubstrateNetwork   uint16
-	Tip                uint64
+	GeneralChainConfig    chain.GeneralChainConfig
+	ChainID               *big.Int
+	StartBlock            *big.Int
+	BlockInterval         *big.Int
+	BlockRetryInterval    time.Duration
+	SubstrateNetwork      uint16
+	Tip                   uint64
+	MaxTip                uint64
+	TipIncrease           uint64
+	EraPeriod             uint64
+	VerificationEndpoints []string
+	VerificationQuorum    int
+	EventBatchSize        uint64
+	EventFetchParallelism int
+	ParachainTopology     *ParachainTopology
+	Resources             []chain.ResourceConfig
 }
 
 func (c *SubstrateConfig) String() string {
 	kp, _ := signature.KeyringPairFromSecret(c.GeneralChainConfig.Key, c.SubstrateNetwork)
 	return fmt.Sprintf(`Name: '%s', Id: '%d', Type: '%s', BlockstorePath: '%s', FreshStart: '%t', 
 							  LatestBlock: '%t', Key address: '%s', StartBlock: '%s', BlockInterval: '%s', 
-                              BlockRetryInterval: '%s', ChainID: '%d', Tip: '%d', SubstrateNetworkPrefix: "%d"`,
+                              BlockRetryInterval: '%s', ChainID: '%d', Tip: '%d', MaxTip: '%d', TipIncrease: '%d', 
+                              EraPeriod: '%d', VerificationEndpoints: '%d', VerificationQuorum: '%d', EventBatchSize: '%d', 
+                              EventFetchParallelism: '%d', SubstrateNetworkPrefix: "%d"`,
 		c.GeneralChainConfig.Name,
 		*c.GeneralChainConfig.Id,
 		c.GeneralChainConfig.Type,
@@ -52,6 +73,13 @@ func (c *SubstrateConfig) String() string {
 		c.BlockRetryInterval,
 		c.ChainID,
 		c.Tip,
+		c.MaxTip,
+		c.TipIncrease,
+		c.EraPeriod,
+		len(c.VerificationEndpoints),
+		c.VerificationQuorum,
+		c.EventBatchSize,
+		c.EventFetchParallelism,
 		c.SubstrateNetwork,
 	)
 }
@@ -60,6 +88,21 @@ func (c *RawSubstrateConfig) Validate() error {
 	if err := c.GeneralChainConfig.Validate(); err != nil {
 		return err
 	}
+	if c.MaxTip != 0 && c.MaxTip < c.Tip {
+		return fmt.Errorf("maxTip %d lower than tip %d", c.MaxTip, c.Tip)
+	}
+	if c.EraPeriod < 4 || c.EraPeriod > 1<<16 {
+		return fmt.Errorf("eraPeriod %d has to be between 4 and 65536", c.EraPeriod)
+	}
+	if c.VerificationQuorum > len(c.VerificationEndpoints)+1 {
+		return fmt.Errorf("verificationQuorum can not be larger than the number of endpoints")
+	}
+	if c.EventBatchSize < 1 {
+		return fmt.Errorf("eventBatchSize has to be positive")
+	}
+	if c.EventFetchParallelism < 1 {
+		return fmt.Errorf("eventFetchParallelism has to be positive")
+	}
 
 	return nil
 }
@@ -83,15 +126,29 @@ func NewSubstrateConfig(chainConfig map[string]interface{}) (*SubstrateConfig, e
 		return nil, err
 	}
 
+	resources, err := chain.NewResourceConfigs(c.Resources)
+	if err != nil {
+		return nil, err
+	}
+
 	c.GeneralChainConfig.ParseFlags()
 	config := &SubstrateConfig{
-		GeneralChainConfig: c.GeneralChainConfig,
-		ChainID:            big.NewInt(c.ChainID),
-		BlockRetryInterval: time.Duration(c.BlockRetryInterval) * time.Second,
-		StartBlock:         big.NewInt(c.StartBlock),
-		BlockInterval:      big.NewInt(c.BlockInterval),
-		SubstrateNetwork:   uint16(c.SubstrateNetwork),
-		Tip:                uint64(c.Tip),
+		GeneralChainConfig:    c.GeneralChainConfig,
+		ChainID:               big.NewInt(c.ChainID),
+		BlockRetryInterval:    time.Duration(c.BlockRetryInterval) * time.Second,
+		StartBlock:            big.NewInt(c.StartBlock),
+		BlockInterval:         big.NewInt(c.BlockInterval),
+		SubstrateNetwork:      uint16(c.SubstrateNetwork),
+		Tip:                   uint64(c.Tip),
+		MaxTip:                c.MaxTip,
+		TipIncrease:           c.TipIncreasePercentage,
+		EraPeriod:             c.EraPeriod,
+		VerificationEndpoints: c.VerificationEndpoints,
+		VerificationQuorum:    c.VerificationQuorum,
+		EventBatchSize:        c.EventBatchSize,
+		EventFetchParallelism: c.EventFetchParallelism,
+		ParachainTopology:     NewParachainTopology(c.ParachainID, c.Parachains),
+		Resources:             resources,
 	}
 
 	return config, nil
@@ -57,30 +57,44 @@ func (s *NewSubstrateConfigTestSuite) Test_ValidConfig() {
 			Endpoint: "ws://domain.com",
 			Id:       id,
 		},
-		StartBlock:         big.NewInt(0),
-		ChainID:            big.NewInt(5),
-		SubstrateNetwork:   uint16(0),
-		BlockInterval:      big.NewInt(5),
-		BlockRetryInterval: time.Duration(5) * time.Second,
+		StartBlock:            big.NewInt(0),
+		ChainID:               big.NewInt(5),
+		SubstrateNetwork:      uint16(0),
+		BlockInterval:         big.NewInt(5),
+		BlockRetryInterval:    time.Duration(5) * time.Second,
+		TipIncrease:           15,
+		EraPeriod:             64,
+		EventBatchSize:        100,
+		EventFetchParallelism: 4,
 	})
 }
 
 func (s *NewSubstrateConfigTestSuite) Test_ValidConfigWithCustomParams() {
 	rawConfig := map[string]interface{}{
-		"id":                 1,
-		"endpoint":           "ws://domain.com",
-		"name":               "substrate1",
-		"chainID":            5,
-		"substrateNetwork":   0,
-		"startBlock":         1000,
-		"blockRetryInterval": 10,
-		"blockInterval":      2,
+		"id":                    1,
+		"endpoint":              "ws://domain.com",
+		"name":                  "substrate1",
+		"chainID":               5,
+		"substrateNetwork":      0,
+		"startBlock":            1000,
+		"blockRetryInterval":    10,
+		"blockInterval":         2,
+		"tip":                   10,
+		"maxTip":                100,
+		"eraPeriod":             128,
+		"verificationEndpoints": []string{"ws://witness1.com", "ws://witness2.com"},
+		"verificationQuorum":    2,
+		"eventBatchSize":        500,
+		"eventFetchParallelism": 8,
+		"parachainID":           uint32(2004),
+		"parachains":            []uint32{2000, 2030},
 	}
 
 	actualConfig, err := NewSubstrateConfig(rawConfig)
 
 	id := new(uint8)
 	*id = 1
+	parachainID := uint32(2004)
 	s.Nil(err)
 	s.Equal(*actualConfig, SubstrateConfig{
 		GeneralChainConfig: chain.GeneralChainConfig{
@@ -88,10 +102,65 @@ func (s *NewSubstrateConfigTestSuite) Test_ValidConfigWithCustomParams() {
 			Endpoint: "ws://domain.com",
 			Id:       id,
 		},
-		ChainID:            big.NewInt(5),
-		SubstrateNetwork:   uint16(0),
-		StartBlock:         big.NewInt(1000),
-		BlockInterval:      big.NewInt(2),
-		BlockRetryInterval: time.Duration(10) * time.Second,
+		ChainID:               big.NewInt(5),
+		SubstrateNetwork:      uint16(0),
+		StartBlock:            big.NewInt(1000),
+		BlockInterval:         big.NewInt(2),
+		BlockRetryInterval:    time.Duration(10) * time.Second,
+		Tip:                   10,
+		MaxTip:                100,
+		TipIncrease:           15,
+		EraPeriod:             128,
+		VerificationEndpoints: []string{"ws://witness1.com", "ws://witness2.com"},
+		VerificationQuorum:    2,
+		EventBatchSize:        500,
+		EventFetchParallelism: 8,
+		ParachainTopology:     NewParachainTopology(&parachainID, []uint32{2000, 2030}),
 	})
 }
+
+func (s *NewSubstrateConfigTestSuite) Test_InvalidMaxTip() {
+	_, err := NewSubstrateConfig(map[string]interface{}{
+		"id":       1,
+		"endpoint": "ws://domain.com",
+		"name":     "substrate1",
+		"tip":      10,
+		"maxTip":   5,
+	})
+
+	s.NotNil(err)
+}
+
+func (s *NewSubstrateConfigTestSuite) Test_InvalidEraPeriod() {
+	_, err := NewSubstrateConfig(map[string]interface{}{
+		"id":        1,
+		"endpoint":  "ws://domain.com",
+		"name":      "substrate1",
+		"eraPeriod": 2,
+	})
+
+	s.NotNil(err)
+}
+
+func (s *NewSubstrateConfigTestSuite) Test_InvalidVerificationQuorum() {
+	_, err := NewSubstrateConfig(map[string]interface{}{
+		"id":                    1,
+		"endpoint":              "ws://domain.com",
+		"name":                  "substrate1",
+		"verificationEndpoints": []string{"ws://witness1.com"},
+		"verificationQuorum":    3,
+	})
+
+	s.NotNil(err)
+}
+
+func (s *NewSubstrateConfigTestSuite) Test_InvalidEventFetchParallelism() {
+	_, err := NewSubstrateConfig(map[string]interface{}{
+		"id":                    1,
+		"endpoint":              "ws://domain.com",
+		"name":                  "substrate1",
+		"eventFetchParallelism": -1,
+	})
+
+	s.NotNil(err)
+}
@@ -0,0 +1,338 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package connection
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
+)
+
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error
+}
+
+type MetadataProvider interface {
+	GetMetadata(blockHash types.Hash) (*types.Metadata, error)
+}
+
+type ThroughputMeter interface {
+	TrackBlockFetch(domainID uint8, blocks int64, duration time.Duration)
+}
+
+// EventFetcher fetches events of block ranges in JSON-RPC batches. Block hashes of a batch are fetched
+// in a single batch request, followed by a single batch of state_queryStorageAt requests that
+// read events, timestamp and runtime version of each block.
+//
+// Batches are fetched with bounded parallelism and event registries are cached per runtime spec version.
+type EventFetcher struct {
+	client      BatchCaller
+	state       MetadataProvider
+	metrics     ThroughputMeter
+	domainID    uint8
+	batchSize   uint64
+	parallelism int
+	log         zerolog.Logger
+
+	eventsKey      types.StorageKey
+	timestampKey   types.StorageKey
+	upgradeKey     types.StorageKey
+	parseEvents    func(eventRegistry registry.EventRegistry, sd *types.StorageDataRaw) ([]*parser.Event, error)
+	createRegistry func(meta *types.Metadata) (registry.EventRegistry, error)
+
+	registryLock sync.Mutex
+	registries   map[uint32]registry.EventRegistry
+}
+
+func NewEventFetcher(
+	domainID uint8,
+	client BatchCaller,
+	state MetadataProvider,
+	meta *types.Metadata,
+	batchSize uint64,
+	parallelism int,
+	metrics ThroughputMeter,
+) (*EventFetcher, error) {
+	if batchSize < 1 || parallelism < 1 {
+		return nil, fmt.Errorf("batch size %d and parallelism %d have to be positive", batchSize, parallelism)
+	}
+
+	eventsKey, err := types.CreateStorageKey(meta, "System", "Events", nil)
+	if err != nil {
+		return nil, err
+	}
+	timestampKey, err := types.CreateStorageKey(meta, "Timestamp", "Now", nil)
+	if err != nil {
+		return nil, err
+	}
+	upgradeKey, err := types.CreateStorageKey(meta, "System", "LastRuntimeUpgrade", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventFetcher{
+		client:         client,
+		state:          state,
+		metrics:        metrics,
+		domainID:       domainID,
+		batchSize:      batchSize,
+		parallelism:    parallelism,
+		log:            log.With().Uint8("domainID", domainID).Logger(),
+		eventsKey:      eventsKey,
+		timestampKey:   timestampKey,
+		upgradeKey:     upgradeKey,
+		parseEvents:    parser.NewEventParser().ParseEvents,
+		createRegistry: registry.NewFactory().CreateEventRegistry,
+		registries:     make(map[uint32]registry.EventRegistry),
+	}, nil
+}
+
+// FetchEvents fetches events of blocks in the provided range ordered by block
+func (f *EventFetcher) FetchEvents(startBlock, endBlock *big.Int) ([]*parser.Event, error) {
+	if startBlock.Cmp(endBlock) == 1 {
+		return []*parser.Event{}, nil
+	}
+	start := time.Now()
+
+	from := startBlock.Uint64()
+	to := endBlock.Uint64()
+	batches := (to-from)/f.batchSize + 1
+	results := make([][]*parser.Event, batches)
+	p := pool.New().WithErrors().WithMaxGoroutines(f.parallelism)
+	for i := uint64(0); i < batches; i++ {
+		i := i
+		batchStart := from + i*f.batchSize
+		batchEnd := batchStart + f.batchSize - 1
+		if batchEnd > to {
+			batchEnd = to
+		}
+		p.Go(func() error {
+			evts, err := f.fetchBatch(batchStart, batchEnd)
+			if err != nil {
+				return err
+			}
+			results[i] = evts
+			return nil
+		})
+	}
+	err := p.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	evts := make([]*parser.Event, 0)
+	for _, result := range results {
+		evts = append(evts, result...)
+	}
+
+	blocks := to - from + 1
+	f.metrics.TrackBlockFetch(f.domainID, int64(blocks), time.Since(start))
+	f.log.Debug().Msgf("Fetched events of %d blocks in %s", blocks, time.Since(start))
+	return evts, nil
+}
+
+func (f *EventFetcher) fetchBatch(startBlock, endBlock uint64) ([]*parser.Event, error) {
+	hashes, err := f.blockHashes(startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{f.eventsKey.Hex(), f.timestampKey.Hex(), f.upgradeKey.Hex()}
+	changeSets := make([][]types.StorageChangeSet, len(hashes))
+	elems := make([]gethrpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		elems[i] = gethrpc.BatchElem{
+			Method: "state_queryStorageAt",
+			Args:   []interface{}{keys, hash.Hex()},
+			Result: &changeSets[i],
+		}
+	}
+	err = f.batchCall(elems)
+	if err != nil {
+		return nil, err
+	}
+
+	evts := make([]*parser.Event, 0)
+	for i, hash := range hashes {
+		blockEvts, err := f.blockEvents(hash, changeSets[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding events of block %d: %w", startBlock+uint64(i), err)
+		}
+		evts = append(evts, blockEvts...)
+	}
+	return evts, nil
+}
+
+func (f *EventFetcher) blockHashes(startBlock, endBlock uint64) ([]types.Hash, error) {
+	hexHashes := make([]string, endBlock-startBlock+1)
+	elems := make([]gethrpc.BatchElem, len(hexHashes))
+	for i := range elems {
+		elems[i] = gethrpc.BatchElem{
+			Method: "chain_getBlockHash",
+			Args:   []interface{}{startBlock + uint64(i)},
+			Result: &hexHashes[i],
+		}
+	}
+	err := f.batchCall(elems)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]types.Hash, len(hexHashes))
+	for i, hexHash := range hexHashes {
+		hashes[i], err = types.NewHashFromHexString(hexHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hash of block %d: %w", startBlock+uint64(i), err)
+		}
+	}
+	return hashes, nil
+}
+
+func (f *EventFetcher) batchCall(elems []gethrpc.BatchElem) error {
+	err := f.client.BatchCallContext(context.Background(), elems)
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		if elem.Error != nil {
+			return fmt.Errorf("%s failed: %w", elem.Method, elem.Error)
+		}
+	}
+	return nil
+}
+
+func (f *EventFetcher) blockEvents(blockHash types.Hash, changeSets []types.StorageChangeSet) ([]*parser.Event, error) {
+	storage := make(map[string]types.StorageDataRaw)
+	for _, changeSet := range changeSets {
+		for _, change := range changeSet.Changes {
+			if change.HasStorageData {
+				storage[change.StorageKey.Hex()] = change.StorageData
+			}
+		}
+	}
+
+	rawEvents, ok := storage[f.eventsKey.Hex()]
+	if !ok {
+		return []*parser.Event{}, nil
+	}
+	rawTimestamp, ok := storage[f.timestampKey.Hex()]
+	if !ok || len(rawTimestamp) != 8 {
+		return nil, fmt.Errorf("missing block timestamp")
+	}
+	msec := int64(binary.LittleEndian.Uint64(rawTimestamp))
+	timestamp := time.Unix(msec/1e3, (msec%1e3)*1e6)
+
+	specVersion, err := decodeSpecVersion(storage[f.upgradeKey.Hex()])
+	if err != nil {
+		return nil, err
+	}
+	eventRegistry, err := f.eventRegistry(specVersion, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	evts, err := f.parseEvents(eventRegistry, &rawEvents)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range evts {
+		e.Fields = append(e.Fields, &registry.DecodedField{
+			Value: timestamp,
+			Name:  "block_timestamp",
+		})
+	}
+	return evts, nil
+}
+
+// eventRegistry returns cached event registry of the runtime version, creating
+// it from the block metadata if the runtime version was not seen before
+func (f *EventFetcher) eventRegistry(specVersion uint32, blockHash types.Hash) (registry.EventRegistry, error) {
+	f.registryLock.Lock()
+	defer f.registryLock.Unlock()
+
+	eventRegistry, ok := f.registries[specVersion]
+	if ok {
+		return eventRegistry, nil
+	}
+
+	meta, err := f.state.GetMetadata(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	eventRegistry, err = f.createRegistry(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	f.log.Info().Msgf("Created event registry for runtime version %d", specVersion)
+	f.registries[specVersion] = eventRegistry
+	return eventRegistry, nil
+}
+
+// decodeSpecVersion decodes spec version from the System.LastRuntimeUpgrade storage.
+// Chains that were never upgraded have no last runtime upgrade and the genesis runtime
+// is returned as spec version 0.
+func decodeSpecVersion(lastRuntimeUpgrade types.StorageDataRaw) (uint32, error) {
+	if len(lastRuntimeUpgrade) == 0 {
+		return 0, nil
+	}
+
+	specVersion, err := scale.NewDecoder(bytes.NewReader(lastRuntimeUpgrade)).DecodeUintCompact()
+	if err != nil {
+		return 0, fmt.Errorf("failed decoding runtime version: %w", err)
+	}
+	if !specVersion.IsUint64() || specVersion.Uint64() > uint64(^uint32(0)) {
+		return 0, fmt.Errorf("invalid runtime version %s", specVersion)
+	}
+	return uint32(specVersion.Uint64()), nil
+}
+
+// BatchedConnection is a substrate connection that fetches events of block ranges in batches
+type BatchedConnection struct {
+	*connection.Connection
+
+	fetcher *EventFetcher
+}
+
+func NewBatchedConnection(
+	domainID uint8,
+	conn *connection.Connection,
+	batchSize uint64,
+	parallelism int,
+	metrics ThroughputMeter,
+) (*BatchedConnection, error) {
+	client, ok := conn.Client.(BatchCaller)
+	if !ok {
+		return nil, fmt.Errorf("substrate client does not support batch calls")
+	}
+
+	meta := conn.GetMetadata()
+	fetcher, err := NewEventFetcher(domainID, client, conn.RPC.State, &meta, batchSize, parallelism, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BatchedConnection{
+		Connection: conn,
+		fetcher:    fetcher,
+	}, nil
+}
+
+func (c *BatchedConnection) FetchEvents(startBlock, endBlock *big.Int) ([]*parser.Event, error) {
+	return c.fetcher.FetchEvents(startBlock, endBlock)
+}
@@ -0,0 +1,171 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package connection
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/suite"
+
+	mock_connection "github.com/ChainSafe/sygma-relayer/chains/substrate/connection/mock"
+)
+
+var (
+	eventsKey    = types.StorageKey{1}
+	timestampKey = types.StorageKey{2}
+	upgradeKey   = types.StorageKey{3}
+)
+
+type EventFetcherTestSuite struct {
+	suite.Suite
+
+	client     *mock_connection.MockBatchCaller
+	state      *mock_connection.MockMetadataProvider
+	metrics    *mock_connection.MockThroughputMeter
+	fetcher    *EventFetcher
+	registries int
+}
+
+func TestRunEventFetcherTestSuite(t *testing.T) {
+	suite.Run(t, new(EventFetcherTestSuite))
+}
+
+func (s *EventFetcherTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.client = mock_connection.NewMockBatchCaller(ctrl)
+	s.state = mock_connection.NewMockMetadataProvider(ctrl)
+	s.metrics = mock_connection.NewMockThroughputMeter(ctrl)
+	s.registries = 0
+	s.fetcher = &EventFetcher{
+		client:       s.client,
+		state:        s.state,
+		metrics:      s.metrics,
+		domainID:     1,
+		batchSize:    3,
+		parallelism:  2,
+		log:          log.Logger,
+		eventsKey:    eventsKey,
+		timestampKey: timestampKey,
+		upgradeKey:   upgradeKey,
+		parseEvents: func(eventRegistry registry.EventRegistry, sd *types.StorageDataRaw) ([]*parser.Event, error) {
+			return []*parser.Event{{Name: fmt.Sprintf("block-%d", (*sd)[0])}}, nil
+		},
+		createRegistry: func(meta *types.Metadata) (registry.EventRegistry, error) {
+			s.registries++
+			return registry.EventRegistry{}, nil
+		},
+		registries: make(map[uint32]registry.EventRegistry),
+	}
+}
+
+// mockChain serves block hashes and storage of blocks where the runtime
+// is upgraded from spec version 1 to 2 at block 5
+func mockChain(ctx context.Context, elems []gethrpc.BatchElem) error {
+	for _, elem := range elems {
+		switch elem.Method {
+		case "chain_getBlockHash":
+			block := elem.Args[0].(uint64)
+			*elem.Result.(*string) = types.Hash{byte(block)}.Hex()
+		case "state_queryStorageAt":
+			hash, _ := types.NewHashFromHexString(elem.Args[1].(string))
+			timestamp := make([]byte, 8)
+			binary.LittleEndian.PutUint64(timestamp, uint64(hash[0])*1000)
+			specVersion := types.StorageDataRaw{1 << 2}
+			if hash[0] >= 5 {
+				specVersion = types.StorageDataRaw{2 << 2}
+			}
+			*elem.Result.(*[]types.StorageChangeSet) = []types.StorageChangeSet{{
+				Block: hash,
+				Changes: []types.KeyValueOption{
+					{StorageKey: eventsKey, HasStorageData: true, StorageData: types.StorageDataRaw{hash[0]}},
+					{StorageKey: timestampKey, HasStorageData: true, StorageData: timestamp},
+					{StorageKey: upgradeKey, HasStorageData: true, StorageData: specVersion},
+				},
+			}}
+		}
+	}
+	return nil
+}
+
+func (s *EventFetcherTestSuite) Test_FetchEvents_OrderedByBlock() {
+	s.client.EXPECT().BatchCallContext(gomock.Any(), gomock.Any()).DoAndReturn(mockChain).AnyTimes()
+	s.state.EXPECT().GetMetadata(gomock.Any()).Return(&types.Metadata{}, nil).Times(2)
+	s.metrics.EXPECT().TrackBlockFetch(uint8(1), int64(10), gomock.Any())
+
+	evts, err := s.fetcher.FetchEvents(big.NewInt(1), big.NewInt(10))
+
+	s.Nil(err)
+	s.Equal(len(evts), 10)
+	for i, evt := range evts {
+		s.Equal(evt.Name, fmt.Sprintf("block-%d", i+1))
+		s.Equal(evt.Fields[len(evt.Fields)-1].Name, "block_timestamp")
+		s.Equal(evt.Fields[len(evt.Fields)-1].Value, time.Unix(int64(i+1), 0))
+	}
+	s.Equal(s.registries, 2)
+}
+
+func (s *EventFetcherTestSuite) Test_FetchEvents_EmptyRange() {
+	evts, err := s.fetcher.FetchEvents(big.NewInt(10), big.NewInt(9))
+
+	s.Nil(err)
+	s.Equal(len(evts), 0)
+}
+
+func (s *EventFetcherTestSuite) Test_FetchEvents_BatchError() {
+	s.client.EXPECT().BatchCallContext(gomock.Any(), gomock.Any()).Return(errors.New("error")).AnyTimes()
+
+	_, err := s.fetcher.FetchEvents(big.NewInt(1), big.NewInt(10))
+
+	s.NotNil(err)
+}
+
+func (s *EventFetcherTestSuite) Test_FetchEvents_ElementError() {
+	s.client.EXPECT().BatchCallContext(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, elems []gethrpc.BatchElem) error {
+		err := mockChain(ctx, elems)
+		elems[len(elems)-1].Error = errors.New("unknown block")
+		return err
+	}).AnyTimes()
+
+	_, err := s.fetcher.FetchEvents(big.NewInt(1), big.NewInt(10))
+
+	s.NotNil(err)
+}
+
+func (s *EventFetcherTestSuite) Test_FetchEvents_MissingTimestamp() {
+	s.client.EXPECT().BatchCallContext(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, elems []gethrpc.BatchElem) error {
+		err := mockChain(ctx, elems)
+		if elems[0].Method == "state_queryStorageAt" {
+			changeSets := elems[0].Result.(*[]types.StorageChangeSet)
+			(*changeSets)[0].Changes[1].HasStorageData = false
+		}
+		return err
+	}).AnyTimes()
+	s.state.EXPECT().GetMetadata(gomock.Any()).Return(&types.Metadata{}, nil).AnyTimes()
+
+	_, err := s.fetcher.FetchEvents(big.NewInt(1), big.NewInt(2))
+
+	s.NotNil(err)
+}
+
+func (s *EventFetcherTestSuite) Test_DecodeSpecVersion() {
+	specVersion, err := decodeSpecVersion(types.StorageDataRaw{})
+	s.Nil(err)
+	s.Equal(specVersion, uint32(0))
+
+	specVersion, err = decodeSpecVersion(types.StorageDataRaw{0x91, 0x01, 0x08, 's', 'y'})
+	s.Nil(err)
+	s.Equal(specVersion, uint32(100))
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/substrate/connection/batch.go
+
+// Package mock_connection is a generated GoMock package.
+package mock_connection
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	rpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBatchCaller is a mock of BatchCaller interface.
+type MockBatchCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchCallerMockRecorder
+}
+
+// MockBatchCallerMockRecorder is the mock recorder for MockBatchCaller.
+type MockBatchCallerMockRecorder struct {
+	mock *MockBatchCaller
+}
+
+// NewMockBatchCaller creates a new mock instance.
+func NewMockBatchCaller(ctrl *gomock.Controller) *MockBatchCaller {
+	mock := &MockBatchCaller{ctrl: ctrl}
+	mock.recorder = &MockBatchCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchCaller) EXPECT() *MockBatchCallerMockRecorder {
+	return m.recorder
+}
+
+// BatchCallContext mocks base method.
+func (m *MockBatchCaller) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCallContext", ctx, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCallContext indicates an expected call of BatchCallContext.
+func (mr *MockBatchCallerMockRecorder) BatchCallContext(ctx, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCallContext", reflect.TypeOf((*MockBatchCaller)(nil).BatchCallContext), ctx, b)
+}
+
+// MockMetadataProvider is a mock of MetadataProvider interface.
+type MockMetadataProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetadataProviderMockRecorder
+}
+
+// MockMetadataProviderMockRecorder is the mock recorder for MockMetadataProvider.
+type MockMetadataProviderMockRecorder struct {
+	mock *MockMetadataProvider
+}
+
+// NewMockMetadataProvider creates a new mock instance.
+func NewMockMetadataProvider(ctrl *gomock.Controller) *MockMetadataProvider {
+	mock := &MockMetadataProvider{ctrl: ctrl}
+	mock.recorder = &MockMetadataProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetadataProvider) EXPECT() *MockMetadataProviderMockRecorder {
+	return m.recorder
+}
+
+// GetMetadata mocks base method.
+func (m *MockMetadataProvider) GetMetadata(blockHash types.Hash) (*types.Metadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadata", blockHash)
+	ret0, _ := ret[0].(*types.Metadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetadata indicates an expected call of GetMetadata.
+func (mr *MockMetadataProviderMockRecorder) GetMetadata(blockHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockMetadataProvider)(nil).GetMetadata), blockHash)
+}
+
+// MockThroughputMeter is a mock of ThroughputMeter interface.
+type MockThroughputMeter struct {
+	ctrl     *gomock.Controller
+	recorder *MockThroughputMeterMockRecorder
+}
+
+// MockThroughputMeterMockRecorder is the mock recorder for MockThroughputMeter.
+type MockThroughputMeterMockRecorder struct {
+	mock *MockThroughputMeter
+}
+
+// NewMockThroughputMeter creates a new mock instance.
+func NewMockThroughputMeter(ctrl *gomock.Controller) *MockThroughputMeter {
+	mock := &MockThroughputMeter{ctrl: ctrl}
+	mock.recorder = &MockThroughputMeterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThroughputMeter) EXPECT() *MockThroughputMeterMockRecorder {
+	return m.recorder
+}
+
+// TrackBlockFetch mocks base method.
+func (m *MockThroughputMeter) TrackBlockFetch(domainID uint8, blocks int64, duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackBlockFetch", domainID, blocks, duration)
+}
+
+// TrackBlockFetch indicates an expected call of TrackBlockFetch.
+func (mr *MockThroughputMeterMockRecorder) TrackBlockFetch(domainID, blocks, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackBlockFetch", reflect.TypeOf((*MockThroughputMeter)(nil).TrackBlockFetch), domainID, blocks, duration)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/substrate/connection/verified.go
+
+// Package mock_connection is a generated GoMock package.
+package mock_connection
+
+import (
+	reflect "reflect"
+
+	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEndpoint is a mock of Endpoint interface.
+type MockEndpoint struct {
+	ctrl     *gomock.Controller
+	recorder *MockEndpointMockRecorder
+}
+
+// MockEndpointMockRecorder is the mock recorder for MockEndpoint.
+type MockEndpointMockRecorder struct {
+	mock *MockEndpoint
+}
+
+// NewMockEndpoint creates a new mock instance.
+func NewMockEndpoint(ctrl *gomock.Controller) *MockEndpoint {
+	mock := &MockEndpoint{ctrl: ctrl}
+	mock.recorder = &MockEndpointMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEndpoint) EXPECT() *MockEndpointMockRecorder {
+	return m.recorder
+}
+
+// GetBlockHash mocks base method.
+func (m *MockEndpoint) GetBlockHash(blockNumber uint64) (types.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockHash", blockNumber)
+	ret0, _ := ret[0].(types.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHash indicates an expected call of GetBlockHash.
+func (mr *MockEndpointMockRecorder) GetBlockHash(blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHash", reflect.TypeOf((*MockEndpoint)(nil).GetBlockHash), blockNumber)
+}
+
+// GetFinalizedHead mocks base method.
+func (m *MockEndpoint) GetFinalizedHead() (types.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFinalizedHead")
+	ret0, _ := ret[0].(types.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFinalizedHead indicates an expected call of GetFinalizedHead.
+func (mr *MockEndpointMockRecorder) GetFinalizedHead() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFinalizedHead", reflect.TypeOf((*MockEndpoint)(nil).GetFinalizedHead))
+}
+
+// GetHeader mocks base method.
+func (m *MockEndpoint) GetHeader(blockHash types.Hash) (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeader", blockHash)
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeader indicates an expected call of GetHeader.
+func (mr *MockEndpointMockRecorder) GetHeader(blockHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockEndpoint)(nil).GetHeader), blockHash)
+}
+
+// GetStorageRaw mocks base method.
+func (m *MockEndpoint) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageRaw", key, blockHash)
+	ret0, _ := ret[0].(*types.StorageDataRaw)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageRaw indicates an expected call of GetStorageRaw.
+func (mr *MockEndpointMockRecorder) GetStorageRaw(key, blockHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageRaw", reflect.TypeOf((*MockEndpoint)(nil).GetStorageRaw), key, blockHash)
+}
@@ -0,0 +1,364 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package connection
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
+)
+
+var ErrQuorumNotReached = errors.New("endpoint quorum not reached")
+
+// EndpointCallTimeout is the maximum time verification waits for endpoint responses
+var EndpointCallTimeout = 30 * time.Second
+
+type Endpoint interface {
+	GetFinalizedHead() (types.Hash, error)
+	GetBlockHash(blockNumber uint64) (types.Hash, error)
+	GetHeader(blockHash types.Hash) (*types.Header, error)
+	GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error)
+}
+
+// RPCEndpoint adapts substrate connection to an endpoint used for verification
+type RPCEndpoint struct {
+	*connection.Connection
+}
+
+func NewRPCEndpoint(conn *connection.Connection) *RPCEndpoint {
+	return &RPCEndpoint{
+		Connection: conn,
+	}
+}
+
+func (e *RPCEndpoint) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	return e.RPC.State.GetStorageRaw(key, blockHash)
+}
+
+// WitnessEndpoint is an endpoint that is dialed lazily so that witnesses
+// unreachable at startup are retried on subsequent calls instead of preventing the relayer from starting
+type WitnessEndpoint struct {
+	url      string
+	dial     func(url string) (Endpoint, error)
+	lock     sync.Mutex
+	endpoint Endpoint
+}
+
+func NewWitnessEndpoint(url string, dial func(url string) (Endpoint, error)) *WitnessEndpoint {
+	e := &WitnessEndpoint{
+		url:  url,
+		dial: dial,
+	}
+	if _, err := e.connect(); err != nil {
+		log.Warn().Err(err).Str("url", url).Msgf("Witness endpoint unreachable, retrying on next call")
+	}
+	return e
+}
+
+func (e *WitnessEndpoint) connect() (Endpoint, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.endpoint != nil {
+		return e.endpoint, nil
+	}
+	endpoint, err := e.dial(e.url)
+	if err != nil {
+		return nil, err
+	}
+	e.endpoint = endpoint
+	return endpoint, nil
+}
+
+func (e *WitnessEndpoint) GetFinalizedHead() (types.Hash, error) {
+	endpoint, err := e.connect()
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return endpoint.GetFinalizedHead()
+}
+
+func (e *WitnessEndpoint) GetBlockHash(blockNumber uint64) (types.Hash, error) {
+	endpoint, err := e.connect()
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return endpoint.GetBlockHash(blockNumber)
+}
+
+func (e *WitnessEndpoint) GetHeader(blockHash types.Hash) (*types.Header, error) {
+	endpoint, err := e.connect()
+	if err != nil {
+		return nil, err
+	}
+	return endpoint.GetHeader(blockHash)
+}
+
+func (e *WitnessEndpoint) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	endpoint, err := e.connect()
+	if err != nil {
+		return nil, err
+	}
+	return endpoint.GetStorageRaw(key, blockHash)
+}
+
+// VerifiedConnection is a substrate connection that does not trust a single RPC node with data
+// the listener acts upon. Finalized heads, block hashes and raw block events are only returned
+// when a quorum of independent endpoints agrees on them, and blocks are checked against
+// the verified block hash.
+//
+// Metadata, used to decode verified events, and block timestamps are read from the primary endpoint.
+type VerifiedConnection struct {
+	*connection.Connection
+
+	endpoints []Endpoint
+	quorum    int
+	log       zerolog.Logger
+}
+
+// NewVerifiedConnection creates connection that verifies data of the primary connection
+// against independent endpoints. Quorum is the number of endpoints that have to return
+// matching data and endpoints should include the primary endpoint.
+func NewVerifiedConnection(
+	domainID uint8,
+	primary *connection.Connection,
+	endpoints []Endpoint,
+	quorum int,
+) (*VerifiedConnection, error) {
+	if quorum < 1 || quorum > len(endpoints) {
+		return nil, fmt.Errorf("quorum %d has to be between 1 and number of endpoints %d", quorum, len(endpoints))
+	}
+
+	return &VerifiedConnection{
+		Connection: primary,
+		endpoints:  endpoints,
+		quorum:     quorum,
+		log:        log.With().Uint8("domainID", domainID).Logger(),
+	}, nil
+}
+
+// GetFinalizedHead returns the highest block that a quorum of endpoints considers finalized
+func (c *VerifiedConnection) GetFinalizedHead() (types.Hash, error) {
+	numbers := make([]uint64, 0, len(c.endpoints))
+	results := c.callAll(func(e Endpoint) (interface{}, error) {
+		hash, err := e.GetFinalizedHead()
+		if err != nil {
+			return nil, err
+		}
+		header, err := e.GetHeader(hash)
+		if err != nil {
+			return nil, err
+		}
+		return uint64(header.Number), nil
+	})
+	c.collect(results, func(r endpointResult) bool {
+		if r.err != nil {
+			c.log.Warn().Err(r.err).Msgf("Endpoint %d failed fetching finalized head", r.index)
+			return false
+		}
+		numbers = append(numbers, r.value.(uint64))
+		return false
+	})
+	if len(numbers) < c.quorum {
+		return types.Hash{}, fmt.Errorf("%w: %d endpoints returned finalized head", ErrQuorumNotReached, len(numbers))
+	}
+
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] > numbers[j] })
+	return c.GetBlockHash(numbers[c.quorum-1])
+}
+
+// GetBlockHash returns block hash that a quorum of endpoints agrees on
+func (c *VerifiedConnection) GetBlockHash(blockNumber uint64) (types.Hash, error) {
+	value, err := c.quorumCall(func(e Endpoint) (interface{}, error) {
+		return e.GetBlockHash(blockNumber)
+	})
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return value.(types.Hash), nil
+}
+
+// GetBlock fetches the block from the primary endpoint and verifies
+// that the block header hashes to the requested block hash
+func (c *VerifiedConnection) GetBlock(blockHash types.Hash) (*types.SignedBlock, error) {
+	block, err := c.Connection.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	headerHash, err := blake2bHash(block.Block.Header)
+	if err != nil {
+		return nil, err
+	}
+	if headerHash != blockHash {
+		return nil, fmt.Errorf("block header hash %s does not match block hash %s", headerHash.Hex(), blockHash.Hex())
+	}
+	return block, nil
+}
+
+// GetBlockEvents decodes block events from raw event storage that a quorum of endpoints agrees on
+func (c *VerifiedConnection) GetBlockEvents(blockHash types.Hash) ([]*parser.Event, error) {
+	eventRetriever, err := retriever.NewDefaultEventRetriever(c, c.State)
+	if err != nil {
+		return nil, err
+	}
+
+	evts, err := eventRetriever.GetEvents(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp, err := c.GetBlockTimestamp(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range evts {
+		e.Fields = append(e.Fields, &registry.DecodedField{
+			Value: timestamp,
+			Name:  "block_timestamp",
+		})
+	}
+	return evts, nil
+}
+
+// GetStorageEvents returns raw System.Events storage of the block that a quorum of endpoints agrees on
+func (c *VerifiedConnection) GetStorageEvents(meta *types.Metadata, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	key, err := types.CreateStorageKey(meta, "System", "Events", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := c.quorumCall(func(e Endpoint) (interface{}, error) {
+		data, err := e.GetStorageRaw(key, blockHash)
+		if err != nil {
+			return nil, err
+		}
+		return *data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := value.(types.StorageDataRaw)
+	return &data, nil
+}
+
+// FetchEvents fetches verified events of blocks in the provided range
+func (c *VerifiedConnection) FetchEvents(startBlock, endBlock *big.Int) ([]*parser.Event, error) {
+	evts := make([]*parser.Event, 0)
+	for i := new(big.Int).Set(startBlock); i.Cmp(endBlock) <= 0; i.Add(i, big.NewInt(1)) {
+		hash, err := c.GetBlockHash(i.Uint64())
+		if err != nil {
+			return nil, err
+		}
+
+		evt, err := c.GetBlockEvents(hash)
+		if err != nil {
+			return nil, err
+		}
+		evts = append(evts, evt...)
+	}
+	return evts, nil
+}
+
+type endpointResult struct {
+	index int
+	value interface{}
+	err   error
+}
+
+// callAll executes f against all endpoints in parallel and returns results
+// as the endpoints respond
+func (c *VerifiedConnection) callAll(f func(e Endpoint) (interface{}, error)) <-chan endpointResult {
+	results := make(chan endpointResult, len(c.endpoints))
+	for i, e := range c.endpoints {
+		go func(i int, e Endpoint) {
+			value, err := f(e)
+			results <- endpointResult{index: i, value: value, err: err}
+		}(i, e)
+	}
+	return results
+}
+
+// collect reads endpoint results until all endpoints responded, handle returns true
+// or EndpointCallTimeout expires. Endpoints that have not responded by then are skipped.
+func (c *VerifiedConnection) collect(results <-chan endpointResult, handle func(r endpointResult) bool) {
+	timeout := time.NewTimer(EndpointCallTimeout)
+	defer timeout.Stop()
+
+	for received := 0; received < len(c.endpoints); received++ {
+		select {
+		case r := <-results:
+			if handle(r) {
+				return
+			}
+		case <-timeout.C:
+			c.log.Warn().Msgf("%d endpoints did not respond within %s", len(c.endpoints)-received, EndpointCallTimeout)
+			return
+		}
+	}
+}
+
+// quorumCall executes f against all endpoints in parallel and returns the result
+// that at least quorum endpoints agreed upon as soon as the quorum is reached
+func (c *VerifiedConnection) quorumCall(f func(e Endpoint) (interface{}, error)) (interface{}, error) {
+	votes := make(map[types.Hash]int)
+	var value interface{}
+	var err error
+	c.collect(c.callAll(f), func(r endpointResult) bool {
+		if r.err != nil {
+			c.log.Warn().Err(r.err).Msgf("Endpoint %d quorum call failed", r.index)
+			return false
+		}
+
+		hash, hashErr := blake2bHash(r.value)
+		if hashErr != nil {
+			err = hashErr
+			return true
+		}
+		votes[hash]++
+		if votes[hash] >= c.quorum {
+			value = r.value
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("%w: required %d matching responses from %d endpoints", ErrQuorumNotReached, c.quorum, len(c.endpoints))
+	}
+	return value, nil
+}
+
+func blake2bHash(value interface{}) (types.Hash, error) {
+	encoded, err := codec.Encode(value)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	h, err := hash.NewBlake2b256(nil)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	_, err = h.Write(encoded)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return types.NewHash(h.Sum(nil)), nil
+}
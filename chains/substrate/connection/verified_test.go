@@ -0,0 +1,184 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package connection_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/connection"
+	mock_connection "github.com/ChainSafe/sygma-relayer/chains/substrate/connection/mock"
+)
+
+type VerifiedConnectionTestSuite struct {
+	suite.Suite
+
+	endpoints []*mock_connection.MockEndpoint
+	conn      *connection.VerifiedConnection
+}
+
+func TestRunVerifiedConnectionTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifiedConnectionTestSuite))
+}
+
+func (s *VerifiedConnectionTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.endpoints = []*mock_connection.MockEndpoint{
+		mock_connection.NewMockEndpoint(ctrl),
+		mock_connection.NewMockEndpoint(ctrl),
+		mock_connection.NewMockEndpoint(ctrl),
+	}
+
+	endpoints := make([]connection.Endpoint, len(s.endpoints))
+	for i, e := range s.endpoints {
+		endpoints[i] = e
+	}
+	conn, err := connection.NewVerifiedConnection(1, nil, endpoints, 2)
+	s.Nil(err)
+	s.conn = conn
+}
+
+func (s *VerifiedConnectionTestSuite) Test_NewVerifiedConnection_InvalidQuorum() {
+	_, err := connection.NewVerifiedConnection(1, nil, []connection.Endpoint{s.endpoints[0]}, 2)
+
+	s.NotNil(err)
+}
+
+func (s *VerifiedConnectionTestSuite) Test_GetBlockHash_QuorumReached() {
+	s.endpoints[0].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{1}, nil)
+	s.endpoints[1].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{2}, nil).MaxTimes(1)
+	s.endpoints[2].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{1}, nil)
+
+	hash, err := s.conn.GetBlockHash(10)
+
+	s.Nil(err)
+	s.Equal(hash, types.Hash{1})
+}
+
+func (s *VerifiedConnectionTestSuite) Test_GetBlockHash_QuorumNotReached() {
+	s.endpoints[0].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{1}, nil)
+	s.endpoints[1].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{2}, nil)
+	s.endpoints[2].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{}, errors.New("error"))
+
+	_, err := s.conn.GetBlockHash(10)
+
+	s.ErrorIs(err, connection.ErrQuorumNotReached)
+}
+
+func (s *VerifiedConnectionTestSuite) Test_GetBlockHash_HangingEndpointTimesOut() {
+	timeout := connection.EndpointCallTimeout
+	connection.EndpointCallTimeout = time.Millisecond * 100
+	defer func() { connection.EndpointCallTimeout = timeout }()
+	s.endpoints[0].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{1}, nil)
+	s.endpoints[1].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{2}, nil)
+	s.endpoints[2].EXPECT().GetBlockHash(uint64(10)).DoAndReturn(func(blockNumber uint64) (types.Hash, error) {
+		time.Sleep(time.Second)
+		return types.Hash{1}, nil
+	})
+
+	start := time.Now()
+	_, err := s.conn.GetBlockHash(10)
+
+	s.ErrorIs(err, connection.ErrQuorumNotReached)
+	s.Less(time.Since(start), time.Second)
+}
+
+func (s *VerifiedConnectionTestSuite) Test_GetBlockHash_ReturnsOnQuorum() {
+	s.endpoints[0].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{1}, nil)
+	s.endpoints[1].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{1}, nil)
+	s.endpoints[2].EXPECT().GetBlockHash(uint64(10)).DoAndReturn(func(blockNumber uint64) (types.Hash, error) {
+		time.Sleep(time.Second)
+		return types.Hash{2}, nil
+	}).MaxTimes(1)
+
+	start := time.Now()
+	hash, err := s.conn.GetBlockHash(10)
+
+	s.Nil(err)
+	s.Equal(hash, types.Hash{1})
+	s.Less(time.Since(start), time.Second)
+}
+
+func (s *VerifiedConnectionTestSuite) Test_WitnessEndpoint_UnreachableAtStartup() {
+	dials := 0
+	witness := connection.NewWitnessEndpoint("ws://witness", func(url string) (connection.Endpoint, error) {
+		dials++
+		if dials == 1 {
+			return nil, errors.New("unreachable")
+		}
+		return s.endpoints[2], nil
+	})
+	conn, err := connection.NewVerifiedConnection(1, nil, []connection.Endpoint{s.endpoints[0], s.endpoints[1], witness}, 2)
+	s.Nil(err)
+	s.endpoints[0].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{1}, nil)
+	s.endpoints[1].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{2}, nil).MaxTimes(1)
+	s.endpoints[2].EXPECT().GetBlockHash(uint64(10)).Return(types.Hash{1}, nil)
+
+	hash, err := conn.GetBlockHash(10)
+
+	s.Nil(err)
+	s.Equal(hash, types.Hash{1})
+	s.Equal(dials, 2)
+}
+
+func (s *VerifiedConnectionTestSuite) Test_GetFinalizedHead_ReturnsHeadFinalizedByQuorum() {
+	s.endpoints[0].EXPECT().GetFinalizedHead().Return(types.Hash{1}, nil)
+	s.endpoints[0].EXPECT().GetHeader(types.Hash{1}).Return(&types.Header{Number: 12}, nil)
+	s.endpoints[1].EXPECT().GetFinalizedHead().Return(types.Hash{2}, nil)
+	s.endpoints[1].EXPECT().GetHeader(types.Hash{2}).Return(&types.Header{Number: 10}, nil)
+	s.endpoints[2].EXPECT().GetFinalizedHead().Return(types.Hash{3}, nil)
+	s.endpoints[2].EXPECT().GetHeader(types.Hash{3}).Return(&types.Header{Number: 11}, nil)
+	for _, e := range s.endpoints {
+		e.EXPECT().GetBlockHash(uint64(11)).Return(types.Hash{3}, nil).MaxTimes(1)
+	}
+
+	hash, err := s.conn.GetFinalizedHead()
+
+	s.Nil(err)
+	s.Equal(hash, types.Hash{3})
+}
+
+func (s *VerifiedConnectionTestSuite) Test_GetFinalizedHead_QuorumNotReached() {
+	s.endpoints[0].EXPECT().GetFinalizedHead().Return(types.Hash{1}, nil)
+	s.endpoints[0].EXPECT().GetHeader(types.Hash{1}).Return(&types.Header{Number: 12}, nil)
+	s.endpoints[1].EXPECT().GetFinalizedHead().Return(types.Hash{}, errors.New("error"))
+	s.endpoints[2].EXPECT().GetFinalizedHead().Return(types.Hash{}, errors.New("error"))
+
+	_, err := s.conn.GetFinalizedHead()
+
+	s.ErrorIs(err, connection.ErrQuorumNotReached)
+}
+
+func (s *VerifiedConnectionTestSuite) Test_GetStorageEvents_MismatchingEvents() {
+	var meta types.Metadata
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	s.Nil(err)
+	s.endpoints[0].EXPECT().GetStorageRaw(gomock.Any(), types.Hash{1}).Return(&types.StorageDataRaw{1}, nil)
+	s.endpoints[1].EXPECT().GetStorageRaw(gomock.Any(), types.Hash{1}).Return(&types.StorageDataRaw{2}, nil)
+	s.endpoints[2].EXPECT().GetStorageRaw(gomock.Any(), types.Hash{1}).Return(&types.StorageDataRaw{3}, nil)
+
+	_, err = s.conn.GetStorageEvents(&meta, types.Hash{1})
+
+	s.ErrorIs(err, connection.ErrQuorumNotReached)
+}
+
+func (s *VerifiedConnectionTestSuite) Test_GetStorageEvents_QuorumReached() {
+	var meta types.Metadata
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	s.Nil(err)
+	s.endpoints[0].EXPECT().GetStorageRaw(gomock.Any(), types.Hash{1}).Return(&types.StorageDataRaw{1}, nil).MaxTimes(1)
+	s.endpoints[1].EXPECT().GetStorageRaw(gomock.Any(), types.Hash{1}).Return(&types.StorageDataRaw{2}, nil)
+	s.endpoints[2].EXPECT().GetStorageRaw(gomock.Any(), types.Hash{1}).Return(&types.StorageDataRaw{2}, nil)
+
+	events, err := s.conn.GetStorageEvents(&meta, types.Hash{1})
+
+	s.Nil(err)
+	s.Equal(*events, types.StorageDataRaw{2})
+}
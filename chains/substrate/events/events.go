@@ -22,10 +22,13 @@ type Deposit struct {
 type Retry struct {
 	DepositOnBlockHeight types.U128 `mapstructure:"deposit_on_block_height"`
 	DestDomainID         types.U8   `mapstructure:"dest_domain_id"`
+	// ResourceID is nil for pallets that retry deposits of all resources
+	ResourceID *types.Bytes32 `mapstructure:"resource_id"`
 }
 
 const (
 	ParachainUpdatedEvent       = "ParachainSystem.ValidationFunctionApplied"
+	CodeUpdatedEvent            = "System.CodeUpdated"
 	ExtrinsicFailedEvent        = "System.ExtrinsicFailed"
 	ExtrinsicSuccessEvent       = "System.ExtrinsicSuccess"
 	RetryEvent                  = "SygmaBridge.Retry"
@@ -0,0 +1,189 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/mitchellh/mapstructure"
+)
+
+type FailedHandlerExecution struct {
+	Error          []byte    `mapstructure:"error"`
+	OriginDomainID types.U8  `mapstructure:"origin_domain_id"`
+	DepositNonce   types.U64 `mapstructure:"deposit_nonce"`
+}
+
+// ProposalKey identifies a proposal by its origin domain and deposit nonce
+type ProposalKey struct {
+	OriginDomainID uint8
+	DepositNonce   uint64
+}
+
+// ExtrinsicOutcome is the result of extrinsic execution decoded from the events of the block
+// the extrinsic was finalized in
+type ExtrinsicOutcome struct {
+	// DispatchError is the readable reason the extrinsic failed, empty if the extrinsic was successful
+	DispatchError string
+	// FailedHandlers maps proposals that failed in the handler to the handler error
+	FailedHandlers map[ProposalKey]string
+}
+
+// Success returns true if the extrinsic was dispatched successfully, proposals in it
+// can still fail in the handler
+func (o *ExtrinsicOutcome) Success() bool {
+	return o.DispatchError == ""
+}
+
+// DecodeExtrinsicOutcome decodes outcome of extrinsic at the extrinsic index from block events.
+// Module errors are resolved to pallet error names through the metadata.
+func DecodeExtrinsicOutcome(meta *types.Metadata, evts []*parser.Event, extrinsicIndex uint32) (*ExtrinsicOutcome, error) {
+	outcome := &ExtrinsicOutcome{
+		FailedHandlers: make(map[ProposalKey]string),
+	}
+
+	found := false
+	for _, evt := range evts {
+		if evt.Phase == nil || !evt.Phase.IsApplyExtrinsic || evt.Phase.AsApplyExtrinsic != extrinsicIndex {
+			continue
+		}
+
+		switch evt.Name {
+		case ExtrinsicSuccessEvent:
+			found = true
+		case ExtrinsicFailedEvent:
+			found = true
+			outcome.DispatchError = "unknown dispatch error"
+			for _, field := range evt.Fields {
+				if strings.HasSuffix(field.Name, "dispatch_error") {
+					outcome.DispatchError = describeField(meta, field)
+				}
+			}
+		case FailedHandlerExecutionEvent:
+			var failure FailedHandlerExecution
+			err := decodeFields(evt.Fields, &failure)
+			if err != nil {
+				return nil, err
+			}
+
+			key := ProposalKey{
+				OriginDomainID: uint8(failure.OriginDomainID),
+				DepositNonce:   uint64(failure.DepositNonce),
+			}
+			outcome.FailedHandlers[key] = string(failure.Error)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no result event found for extrinsic %d", extrinsicIndex)
+	}
+
+	return outcome, nil
+}
+
+func decodeFields(fields registry.DecodedFields, v interface{}) error {
+	values := make(map[string]interface{})
+	for _, field := range fields {
+		values[field.Name] = field.Value
+	}
+	return mapstructure.Decode(values, v)
+}
+
+// describeField converts decoded dispatch error into readable reason. Registry decoding drops
+// the outer variant index so errors are resolved from the shape of the decoded value: unit
+// variants through the lookup type and module errors through the pallet errors type.
+func describeField(meta *types.Metadata, field *registry.DecodedField) string {
+	switch value := field.Value.(type) {
+	case registry.DecodedFields:
+		if reason, ok := describeModuleError(meta, value); ok {
+			return reason
+		}
+		if len(value) == 1 {
+			return describeField(meta, value[0])
+		}
+	case types.U8:
+		return variantName(meta, field.LookupIndex, uint8(value))
+	case uint8:
+		return variantName(meta, field.LookupIndex, value)
+	}
+	return fmt.Sprintf("%s: %v", field.Name, field.Value)
+}
+
+func describeModuleError(meta *types.Metadata, fields registry.DecodedFields) (string, bool) {
+	var moduleIndex, errorIndex *uint8
+	for _, field := range fields {
+		switch field.Name {
+		case "index":
+			if index, ok := byteValue(field.Value); ok {
+				moduleIndex = &index
+			}
+		case "error":
+			if index, ok := byteValue(field.Value); ok {
+				errorIndex = &index
+			}
+		}
+	}
+	if moduleIndex == nil || errorIndex == nil {
+		return "", false
+	}
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if uint8(pallet.Index) != *moduleIndex {
+			continue
+		}
+		if !pallet.HasErrors {
+			break
+		}
+
+		name := variantName(meta, pallet.Errors.Type.Int64(), *errorIndex)
+		return fmt.Sprintf("%s.%s", pallet.Name, name), true
+	}
+	return fmt.Sprintf("module %d error %d", *moduleIndex, *errorIndex), true
+}
+
+// byteValue returns the first byte of the value, module error is a single byte on older runtimes
+// and a four byte array with the error index in the first byte on newer runtimes
+func byteValue(value interface{}) (uint8, bool) {
+	switch v := value.(type) {
+	case types.U8:
+		return uint8(v), true
+	case uint8:
+		return v, true
+	case []interface{}:
+		if len(v) > 0 {
+			return byteValue(v[0])
+		}
+	case []types.U8:
+		if len(v) > 0 {
+			return uint8(v[0]), true
+		}
+	case []byte:
+		if len(v) > 0 {
+			return v[0], true
+		}
+	}
+	return 0, false
+}
+
+func variantName(meta *types.Metadata, typeID int64, index uint8) string {
+	typ, ok := meta.AsMetadataV14.EfficientLookup[typeID]
+	if !ok || !typ.Def.IsVariant {
+		return fmt.Sprintf("variant %d", index)
+	}
+
+	for _, variant := range typ.Def.Variant.Variants {
+		if uint8(variant.Index) != index {
+			continue
+		}
+
+		if len(variant.Docs) == 0 {
+			return string(variant.Name)
+		}
+		return fmt.Sprintf("%s (%s)", variant.Name, strings.TrimSpace(string(variant.Docs[0])))
+	}
+	return fmt.Sprintf("variant %d", index)
+}
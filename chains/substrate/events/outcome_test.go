@@ -0,0 +1,205 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package events_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/events"
+)
+
+const (
+	dispatchErrorType = 1
+	bridgeErrorType   = 2
+)
+
+func outcomeMetadata() *types.Metadata {
+	return &types.Metadata{
+		Version: 14,
+		AsMetadataV14: types.MetadataV14{
+			Pallets: []types.PalletMetadataV14{
+				{
+					Name:      "SygmaBridge",
+					Index:     5,
+					HasErrors: true,
+					Errors:    types.ErrorMetadataV14{Type: types.NewSi1LookupTypeIDFromUInt(bridgeErrorType)},
+				},
+			},
+			EfficientLookup: map[int64]*types.Si1Type{
+				dispatchErrorType: {
+					Def: types.Si1TypeDef{
+						IsVariant: true,
+						Variant: types.Si1TypeDefVariant{Variants: []types.Si1Variant{
+							{Name: "Other", Index: 0},
+							{Name: "BadOrigin", Index: 2},
+							{Name: "Module", Index: 3},
+						}},
+					},
+				},
+				bridgeErrorType: {
+					Def: types.Si1TypeDef{
+						IsVariant: true,
+						Variant: types.Si1TypeDefVariant{Variants: []types.Si1Variant{
+							{Name: "BridgePaused", Index: 0},
+							{Name: "BadMpcSignature", Index: 1, Docs: []types.Text{" Proposal signature is not valid"}},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func applyExtrinsic(index uint32) *types.Phase {
+	return &types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: index}
+}
+
+type ExtrinsicOutcomeTestSuite struct {
+	suite.Suite
+}
+
+func TestRunExtrinsicOutcomeTestSuite(t *testing.T) {
+	suite.Run(t, new(ExtrinsicOutcomeTestSuite))
+}
+
+func (s *ExtrinsicOutcomeTestSuite) Test_DecodeExtrinsicOutcome_MissingResultEvent() {
+	evts := []*parser.Event{
+		{Name: events.ExtrinsicSuccessEvent, Phase: applyExtrinsic(0)},
+	}
+
+	_, err := events.DecodeExtrinsicOutcome(outcomeMetadata(), evts, 1)
+
+	s.NotNil(err)
+}
+
+func (s *ExtrinsicOutcomeTestSuite) Test_DecodeExtrinsicOutcome_Success() {
+	evts := []*parser.Event{
+		{Name: events.ExtrinsicFailedEvent, Phase: applyExtrinsic(0)},
+		{Name: events.ExtrinsicSuccessEvent, Phase: applyExtrinsic(1)},
+	}
+
+	outcome, err := events.DecodeExtrinsicOutcome(outcomeMetadata(), evts, 1)
+
+	s.Nil(err)
+	s.True(outcome.Success())
+	s.Equal(len(outcome.FailedHandlers), 0)
+}
+
+func (s *ExtrinsicOutcomeTestSuite) Test_DecodeExtrinsicOutcome_FailedHandlers() {
+	evts := []*parser.Event{
+		{
+			Name:  events.FailedHandlerExecutionEvent,
+			Phase: applyExtrinsic(1),
+			Fields: registry.DecodedFields{
+				{Name: "error", Value: []interface{}{types.U8('f'), types.U8('a'), types.U8('i'), types.U8('l')}},
+				{Name: "origin_domain_id", Value: types.U8(2)},
+				{Name: "deposit_nonce", Value: types.U64(7)},
+			},
+		},
+		{
+			Name:  events.FailedHandlerExecutionEvent,
+			Phase: applyExtrinsic(0),
+			Fields: registry.DecodedFields{
+				{Name: "error", Value: []interface{}{types.U8('x')}},
+				{Name: "origin_domain_id", Value: types.U8(2)},
+				{Name: "deposit_nonce", Value: types.U64(8)},
+			},
+		},
+		{Name: events.ExtrinsicSuccessEvent, Phase: applyExtrinsic(1)},
+	}
+
+	outcome, err := events.DecodeExtrinsicOutcome(outcomeMetadata(), evts, 1)
+
+	s.Nil(err)
+	s.True(outcome.Success())
+	s.Equal(outcome.FailedHandlers, map[events.ProposalKey]string{
+		{OriginDomainID: 2, DepositNonce: 7}: "fail",
+	})
+}
+
+func (s *ExtrinsicOutcomeTestSuite) Test_DecodeExtrinsicOutcome_ModuleError() {
+	evts := []*parser.Event{
+		{
+			Name:  events.ExtrinsicFailedEvent,
+			Phase: applyExtrinsic(1),
+			Fields: registry.DecodedFields{
+				{
+					Name:        "sp_runtime.DispatchError.dispatch_error",
+					LookupIndex: dispatchErrorType,
+					Value: registry.DecodedFields{
+						{
+							Name: "sp_runtime.ModuleError.ModuleError",
+							Value: registry.DecodedFields{
+								{Name: "index", Value: types.U8(5)},
+								{Name: "error", Value: []interface{}{types.U8(1), types.U8(0), types.U8(0), types.U8(0)}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	outcome, err := events.DecodeExtrinsicOutcome(outcomeMetadata(), evts, 1)
+
+	s.Nil(err)
+	s.False(outcome.Success())
+	s.Equal(outcome.DispatchError, "SygmaBridge.BadMpcSignature (Proposal signature is not valid)")
+}
+
+func (s *ExtrinsicOutcomeTestSuite) Test_DecodeExtrinsicOutcome_UnknownModule() {
+	evts := []*parser.Event{
+		{
+			Name:  events.ExtrinsicFailedEvent,
+			Phase: applyExtrinsic(1),
+			Fields: registry.DecodedFields{
+				{
+					Name:        "sp_runtime.DispatchError.dispatch_error",
+					LookupIndex: dispatchErrorType,
+					Value: registry.DecodedFields{
+						{
+							Name: "sp_runtime.ModuleError.ModuleError",
+							Value: registry.DecodedFields{
+								{Name: "index", Value: types.U8(9)},
+								{Name: "error", Value: types.U8(3)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	outcome, err := events.DecodeExtrinsicOutcome(outcomeMetadata(), evts, 1)
+
+	s.Nil(err)
+	s.Equal(outcome.DispatchError, "module 9 error 3")
+}
+
+func (s *ExtrinsicOutcomeTestSuite) Test_DecodeExtrinsicOutcome_UnitVariantError() {
+	evts := []*parser.Event{
+		{
+			Name:  events.ExtrinsicFailedEvent,
+			Phase: applyExtrinsic(1),
+			Fields: registry.DecodedFields{
+				{
+					Name:        "sp_runtime.DispatchError.dispatch_error",
+					LookupIndex: dispatchErrorType,
+					Value:       uint8(2),
+				},
+			},
+		},
+	}
+
+	outcome, err := events.DecodeExtrinsicOutcome(outcomeMetadata(), evts, 1)
+
+	s.Nil(err)
+	s.False(outcome.Success())
+	s.Equal(outcome.DispatchError, "BadOrigin")
+}
@@ -5,18 +5,21 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
 	"time"
 
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/client"
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/events"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store"
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/sourcegraph/conc/pool"
 	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 
-	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	ethCommon "github.com/ethereum/go-ethereum/common"
 
@@ -35,19 +38,25 @@ var (
 
 type BridgePallet interface {
 	IsProposalExecuted(p *transfer.TransferProposal) (bool, error)
-	ExecuteProposals(proposals []*transfer.TransferProposal, signature []byte) (types.Hash, *author.ExtrinsicStatusSubscription, error)
+	ExecuteProposals(proposals []*transfer.TransferProposal, signature []byte) (types.Hash, client.StatusSubscription, error)
 	ProposalsHash(proposals []*transfer.TransferProposal) ([]byte, error)
-	TrackExtrinsic(extHash types.Hash, sub *author.ExtrinsicStatusSubscription) error
+	TrackExtrinsic(ctx context.Context, extHash types.Hash, sub client.StatusSubscription, proposals []*transfer.TransferProposal) (*events.ExtrinsicOutcome, error)
+}
+
+type RuntimeChecker interface {
+	Check() error
 }
 
 type Executor struct {
-	coordinator *tss.Coordinator
-	host        host.Host
-	comm        comm.Communication
-	fetcher     signing.SaveDataFetcher
-	bridge      BridgePallet
-	conn        *connection.Connection
-	exitLock    *sync.RWMutex
+	coordinator    *tss.Coordinator
+	host           host.Host
+	comm           comm.Communication
+	fetcher        signing.SaveDataFetcher
+	bridge         BridgePallet
+	conn           *connection.Connection
+	exitLock       *sync.RWMutex
+	runtimeChecker RuntimeChecker
+	propStorer     PropStorer
 }
 
 func NewExecutor(
@@ -58,15 +67,19 @@ func NewExecutor(
 	fetcher signing.SaveDataFetcher,
 	conn *connection.Connection,
 	exitLock *sync.RWMutex,
+	runtimeChecker RuntimeChecker,
+	propStorer PropStorer,
 ) *Executor {
 	return &Executor{
-		host:        host,
-		comm:        comm,
-		coordinator: coordinator,
-		bridge:      bridgePallet,
-		fetcher:     fetcher,
-		conn:        conn,
-		exitLock:    exitLock,
+		host:           host,
+		comm:           comm,
+		coordinator:    coordinator,
+		bridge:         bridgePallet,
+		fetcher:        fetcher,
+		conn:           conn,
+		exitLock:       exitLock,
+		runtimeChecker: runtimeChecker,
+		propStorer:     propStorer,
 	}
 }
 
@@ -75,6 +88,13 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 	e.exitLock.RLock()
 	defer e.exitLock.RUnlock()
 
+	// blocks while metadata is refreshed after runtime upgrade
+	err := e.runtimeChecker.Check()
+	if err != nil {
+		log.Error().Err(err).Msgf("Refusing to execute proposals")
+		return err
+	}
+
 	transferProposals := make([]*transfer.TransferProposal, 0)
 	for _, prop := range proposals {
 		transferProposal := &transfer.TransferProposal{
@@ -84,19 +104,20 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 			Type:        prop.Type,
 			MessageID:   prop.MessageID,
 		}
-		transferProposals = append(transferProposals, transferProposal)
 
 		isExecuted, err := e.bridge.IsProposalExecuted(transferProposal)
 		if err != nil {
 			return err
 		}
 		if isExecuted {
+			log.Info().Str("messageID", transferProposal.MessageID).Msgf("Proposal %p already executed", transferProposal)
+			e.updateProposals([]*transfer.TransferProposal{transferProposal}, store.ProposalUpdate{Status: store.ExecutedProp})
 			continue
 		}
 
-		proposals = append(proposals, prop)
+		transferProposals = append(transferProposals, transferProposal)
 	}
-	if len(proposals) == 0 {
+	if len(transferProposals) == 0 {
 		return nil
 	}
 
@@ -106,6 +127,7 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 	}
 
 	messageID := transferProposals[0].MessageID
+	e.updateProposals(transferProposals, store.ProposalUpdate{Status: store.PendingProp, SessionID: messageID})
 	msg := big.NewInt(0)
 	msg.SetBytes(propHash)
 	signing, err := signing.NewSigning(
@@ -127,6 +149,7 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 	pool.Go(func() error {
 		err := e.coordinator.Execute(executionContext, []tss.TssProcess{signing}, sigChn)
 		if err != nil {
+			e.updateProposals(transferProposals, store.ProposalUpdate{Reason: err.Error()})
 			cancelWatch()
 		}
 
@@ -140,6 +163,7 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 
 func (e *Executor) watchExecution(ctx context.Context, cancelExecution context.CancelFunc, proposals []*transfer.TransferProposal, sigChn chan interface{}, sessionID string) error {
 	ticker := time.NewTicker(executionCheckPeriod)
+	deadline := time.Now().Add(signingTimeout)
 	timeout := time.NewTicker(signingTimeout)
 	defer ticker.Stop()
 	defer timeout.Stop()
@@ -158,10 +182,25 @@ func (e *Executor) watchExecution(ctx context.Context, cancelExecution context.C
 				hash, sub, err := e.executeProposal(proposals, signatureData)
 				if err != nil {
 					_ = e.comm.Broadcast(e.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, sessionID)
+					e.updateProposals(proposals, store.ProposalUpdate{Reason: err.Error()})
+					return err
+				}
+
+				// extrinsic is watched until the execution deadline even if signing was cancelled
+				trackContext, cancelTrack := context.WithDeadline(context.Background(), deadline)
+				outcome, err := e.bridge.TrackExtrinsic(trackContext, hash, sub, proposals)
+				cancelTrack()
+				if errors.Is(err, client.ErrAlreadyIncluded) {
+					log.Info().Str("messageID", sessionID).Msgf("Proposals executed before extrinsic resubmission")
+					e.updateProposals(proposals, store.ProposalUpdate{Status: store.ExecutedProp})
+					return nil
+				}
+				if err != nil {
+					e.updateProposals(proposals, store.ProposalUpdate{Status: store.FailedProp, TxHash: hash.Hex(), Reason: err.Error()})
 					return err
 				}
 
-				return e.bridge.TrackExtrinsic(hash, sub)
+				return e.handleOutcome(proposals, outcome, hash, sessionID)
 			}
 		case <-ticker.C:
 			{
@@ -170,11 +209,14 @@ func (e *Executor) watchExecution(ctx context.Context, cancelExecution context.C
 				}
 
 				log.Info().Str("messageID", sessionID).Msgf("Successfully executed proposals")
+				e.updateProposals(proposals, store.ProposalUpdate{Status: store.ExecutedProp})
 				return nil
 			}
 		case <-timeout.C:
 			{
-				return fmt.Errorf("execution timed out in %s", signingTimeout)
+				err := fmt.Errorf("execution timed out in %s", signingTimeout)
+				e.updateProposals(proposals, store.ProposalUpdate{Reason: err.Error()})
+				return err
 			}
 		case <-ctx.Done():
 			{
@@ -184,13 +226,19 @@ func (e *Executor) watchExecution(ctx context.Context, cancelExecution context.C
 	}
 }
 
-func (e *Executor) executeProposal(proposals []*transfer.TransferProposal, signatureData *common.SignatureData) (types.Hash, *author.ExtrinsicStatusSubscription, error) {
+func (e *Executor) executeProposal(proposals []*transfer.TransferProposal, signatureData *common.SignatureData) (types.Hash, client.StatusSubscription, error) {
 	sig := []byte{}
 	sig = append(sig[:], ethCommon.LeftPadBytes(signatureData.R, 32)...)
 	sig = append(sig[:], ethCommon.LeftPadBytes(signatureData.S, 32)...)
 	sig = append(sig[:], signatureData.SignatureRecovery...)
 	sig[len(sig)-1] += 27 // Transform V from 0/1 to 27/28
 
+	err := e.runtimeChecker.Check()
+	if err != nil {
+		log.Error().Err(err).Msgf("Refusing to submit proposals")
+		return types.Hash{}, nil, err
+	}
+
 	hash, sub, err := e.bridge.ExecuteProposals(proposals, sig)
 	if err != nil {
 		return types.Hash{}, nil, err
@@ -209,3 +257,48 @@ func (e *Executor) areProposalsExecuted(proposals []*transfer.TransferProposal)
 
 	return true
 }
+
+// handleOutcome stores proposal statuses based on the extrinsic outcome. Proposals that failed
+// in the handler are marked as permanently failed as retrying them would fail the same way.
+func (e *Executor) handleOutcome(proposals []*transfer.TransferProposal, outcome *events.ExtrinsicOutcome, hash types.Hash, sessionID string) error {
+	if !outcome.Success() {
+		err := fmt.Errorf("extrinsic failed: %s", outcome.DispatchError)
+		e.updateProposals(proposals, store.ProposalUpdate{Status: store.FailedProp, TxHash: hash.Hex(), Reason: err.Error()})
+		return err
+	}
+
+	for _, prop := range proposals {
+		reason, failed := outcome.FailedHandlers[events.ProposalKey{
+			OriginDomainID: prop.Source,
+			DepositNonce:   prop.Data.DepositNonce,
+		}]
+		if !failed {
+			e.updateProposal(prop, store.ProposalUpdate{Status: store.ExecutedProp, TxHash: hash.Hex()})
+			continue
+		}
+
+		log.Error().Str("messageID", prop.MessageID).Msgf(
+			"Proposal %d-%d-%d failed in handler: %s", prop.Source, prop.Destination, prop.Data.DepositNonce, reason)
+		e.updateProposal(prop, store.ProposalUpdate{Status: store.PermanentlyFailedProp, TxHash: hash.Hex(), Reason: reason})
+	}
+
+	log.Info().Str("messageID", sessionID).Msgf("Successfully executed proposals")
+	return nil
+}
+
+func (e *Executor) updateProposals(proposals []*transfer.TransferProposal, update store.ProposalUpdate) {
+	for _, prop := range proposals {
+		e.updateProposal(prop, update)
+	}
+}
+
+func (e *Executor) updateProposal(prop *transfer.TransferProposal, update store.ProposalUpdate) {
+	update.Source = prop.Source
+	update.Destination = prop.Destination
+	update.DepositNonce = prop.Data.DepositNonce
+	update.MessageID = prop.MessageID
+	err := e.propStorer.UpdateProposal(update)
+	if err != nil {
+		log.Err(err).Str("messageID", prop.MessageID).Msgf("Failed storing proposal %+v update %+v", prop, update)
+	}
+}
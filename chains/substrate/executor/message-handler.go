@@ -7,17 +7,68 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
+	"github.com/ChainSafe/sygma-relayer/chains"
+	"github.com/ChainSafe/sygma-relayer/chains/substrate"
+	"github.com/ChainSafe/sygma-relayer/config/chain"
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/store"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rs/zerolog/log"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 )
 
-type SubstrateMessageHandler struct{}
+type QuarantineStorer interface {
+	Quarantine(record store.QuarantineRecord) error
+}
+
+type ProposalRecorder interface {
+	UpdateProposal(update store.ProposalUpdate) error
+}
+
+type SubstrateMessageHandler struct {
+	decimals    *chains.ResourceDecimals
+	collections map[[32]byte]types.U32
+	topology    *substrate.ParachainTopology
+	quarantine  QuarantineStorer
+	recorder    ProposalRecorder
+}
+
+// NewSubstrateMessageHandler creates message handler that converts fungible
+// amounts between source and destination resource decimals and maps non-fungible
+// resources to NFT pallet collections.
+//
+// Transfers to recipients that are not routable in the parachain topology are rejected
+// before signing and recorded in the quarantine store with the rejection reason.
+// Decoded recipients of accepted transfers are recorded in the proposal record.
+func NewSubstrateMessageHandler(
+	decimals *chains.ResourceDecimals,
+	resources []chain.ResourceConfig,
+	topology *substrate.ParachainTopology,
+	quarantine QuarantineStorer,
+	recorder ProposalRecorder,
+) *SubstrateMessageHandler {
+	collections := make(map[[32]byte]types.U32)
+	for _, resource := range resources {
+		if resource.Collection != nil {
+			collections[resource.ResourceID] = types.NewU32(*resource.Collection)
+		}
+	}
+
+	return &SubstrateMessageHandler{
+		decimals:    decimals,
+		collections: collections,
+		topology:    topology,
+		quarantine:  quarantine,
+		recorder:    recorder,
+	}
+}
 
 func (mh *SubstrateMessageHandler) HandleMessage(m *message.Message) (*proposal.Proposal, error) {
 	transferMessage := &transfer.TransferMessage{
@@ -27,13 +78,88 @@ func (mh *SubstrateMessageHandler) HandleMessage(m *message.Message) (*proposal.
 		Type:        m.Type,
 		ID:          m.ID,
 	}
+	err := mh.validateRecipient(transferMessage)
+	if err != nil {
+		return nil, err
+	}
+
 	switch transferMessage.Data.Type {
 	case transfer.FungibleTransfer:
+		err := mh.decimals.NormalizeFungibleAmount(transferMessage)
+		if err != nil {
+			return nil, err
+		}
 		return fungibleTransferMessageHandler(transferMessage)
+	case transfer.NonFungibleTransfer:
+		collection, ok := mh.collections[transferMessage.Data.ResourceId]
+		if !ok {
+			return nil, fmt.Errorf("no collection configured for resource %s", hexutil.Encode(transferMessage.Data.ResourceId[:]))
+		}
+		return nonFungibleTransferMessageHandler(transferMessage, collection)
+	case transfer.PermissionlessGenericTransfer:
+		return genericTransferMessageHandler(transferMessage)
 	}
 	return nil, errors.New("wrong message type passed while handling message")
 }
 
+// validateRecipient decodes recipient multilocation of the transfer and rejects
+// transfers with malformed or unroutable recipients
+func (mh *SubstrateMessageHandler) validateRecipient(m *transfer.TransferMessage) error {
+	if mh.topology == nil || len(m.Data.Payload) < 2 {
+		return nil
+	}
+	recipient, ok := m.Data.Payload[1].([]byte)
+	if !ok {
+		return nil
+	}
+
+	var validate func(location *substrate.MultiLocation) error
+	switch m.Data.Type {
+	case transfer.FungibleTransfer, transfer.NonFungibleTransfer:
+		validate = mh.topology.ValidateRecipient
+	case transfer.PermissionlessGenericTransfer:
+		validate = mh.topology.ValidateDestination
+	default:
+		return nil
+	}
+
+	location, err := substrate.DecodeMultiLocation(recipient)
+	if err != nil {
+		return mh.reject(m, fmt.Errorf("malformed recipient %s: %w", hexutil.Encode(recipient), err))
+	}
+	err = validate(location)
+	if err != nil {
+		return mh.reject(m, fmt.Errorf("unroutable recipient %s: %w", location, err))
+	}
+
+	log.Info().Str("messageID", m.ID).Msgf("Resolved recipient %s", location)
+	return mh.recorder.UpdateProposal(store.ProposalUpdate{
+		Source:       m.Source,
+		Destination:  m.Destination,
+		DepositNonce: m.Data.DepositNonce,
+		MessageID:    m.ID,
+		Recipient:    location.String(),
+	})
+}
+
+func (mh *SubstrateMessageHandler) reject(m *transfer.TransferMessage, reason error) error {
+	log.Error().Str("messageID", m.ID).Err(reason).Msgf("Rejected transfer %d-%d-%d", m.Source, m.Destination, m.Data.DepositNonce)
+	err := mh.quarantine.Quarantine(store.QuarantineRecord{
+		Source:       m.Source,
+		Destination:  m.Destination,
+		DepositNonce: m.Data.DepositNonce,
+		ResourceID:   hexutil.Encode(m.Data.ResourceId[:]),
+		MessageID:    m.ID,
+		Reason:       reason.Error(),
+		Status:       store.RejectedProp,
+		Timestamp:    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return reason
+}
+
 func fungibleTransferMessageHandler(m *transfer.TransferMessage) (*proposal.Proposal, error) {
 	if len(m.Data.Payload) != 2 {
 		return nil, errors.New("malformed payload. Len  of payload should be 2")
@@ -60,9 +186,124 @@ func fungibleTransferMessageHandler(m *transfer.TransferMessage) (*proposal.Prop
 	}, m.ID, transfer.TransferProposalType), nil
 }
 
+// nonFungibleTransferMessageHandler encodes proposal data as SCALE encoded
+// (collection u32, item u128, recipient MultiLocation, metadata Vec<u8>) expected by the NFT pallet
+func nonFungibleTransferMessageHandler(m *transfer.TransferMessage, collection types.U32) (*proposal.Proposal, error) {
+	if len(m.Data.Payload) != 3 {
+		return nil, errors.New("malformed payload. Len  of payload should be 3")
+	}
+	tokenID, ok := m.Data.Payload[0].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload tokenID format")
+	}
+	recipient, ok := m.Data.Payload[1].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload recipient format")
+	}
+	metadata, ok := m.Data.Payload[2].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload metadata format")
+	}
+
+	item := new(big.Int).SetBytes(tokenID)
+	if item.BitLen() > 128 {
+		return nil, fmt.Errorf("token ID %s exceeds u128 item ID", item)
+	}
+
+	var data []byte
+	encodedCollection, err := codec.Encode(collection)
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, encodedCollection...)
+	encodedItem, err := codec.Encode(types.NewU128(*item))
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, encodedItem...)
+	// recipient is already SCALE encoded MultiLocation
+	data = append(data, recipient...)
+	encodedMetadata, err := codec.Encode(types.NewBytes(metadata))
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, encodedMetadata...)
+	return proposal.NewProposal(m.Source, m.Destination, transfer.TransferProposalData{
+		DepositNonce: m.Data.DepositNonce,
+		ResourceId:   m.Data.ResourceId,
+		Metadata:     m.Data.Metadata,
+		Data:         data,
+	}, m.ID, transfer.TransferProposalType), nil
+}
+
+// genericTransferMessageHandler encodes proposal data as SCALE encoded
+// (dest MultiLocation, weight u64, depositor Vec<u8>, call Vec<u8>) expected by the XCM call handler.
+// Call is the encoded call index taken from the function signature followed by call arguments
+// taken from execution data.
+func genericTransferMessageHandler(m *transfer.TransferMessage) (*proposal.Proposal, error) {
+	if len(m.Data.Payload) != 5 {
+		return nil, errors.New("malformed payload. Len  of payload should be 5")
+	}
+	callIndex, ok := m.Data.Payload[0].([]byte)
+	if !ok {
+		return nil, errors.New("wrong function signature format")
+	}
+	dest, ok := m.Data.Payload[1].([]byte)
+	if !ok {
+		return nil, errors.New("wrong contract address format")
+	}
+	maxFee, ok := m.Data.Payload[2].([]byte)
+	if !ok {
+		return nil, errors.New("wrong max fee format")
+	}
+	depositor, ok := m.Data.Payload[3].([]byte)
+	if !ok {
+		return nil, errors.New("wrong depositor data format")
+	}
+	executionData, ok := m.Data.Payload[4].([]byte)
+	if !ok {
+		return nil, errors.New("wrong execution data format")
+	}
+
+	weight := new(big.Int).SetBytes(maxFee)
+	if !weight.IsUint64() {
+		return nil, fmt.Errorf("max fee %s exceeds u64 weight", weight)
+	}
+
+	call := make([]byte, 0, len(callIndex)+len(executionData))
+	call = append(call, callIndex...)
+	call = append(call, executionData...)
+
+	var data []byte
+	// dest is already SCALE encoded MultiLocation
+	data = append(data, dest...)
+	encodedWeight, err := codec.Encode(types.NewU64(weight.Uint64()))
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, encodedWeight...)
+	encodedDepositor, err := codec.Encode(types.NewBytes(depositor))
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, encodedDepositor...)
+	encodedCall, err := codec.Encode(types.NewBytes(call))
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, encodedCall...)
+	return proposal.NewProposal(m.Source, m.Destination, transfer.TransferProposalData{
+		DepositNonce: m.Data.DepositNonce,
+		ResourceId:   m.Data.ResourceId,
+		Metadata:     m.Data.Metadata,
+		Data:         data,
+	}, m.ID, transfer.TransferProposalType), nil
+}
+
 type PropStorer interface {
 	StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error
 	PropStatus(source, destination uint8, depositNonce uint64) (store.PropStatus, error)
+	UpdateProposal(update store.ProposalUpdate) error
 }
 
 type BlockFetcher interface {
@@ -117,7 +358,7 @@ func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Pro
 	if err != nil {
 		return nil, err
 	}
-	filteredDeposits, err := retry.FilterDeposits(h.propStorer, domainDeposits, retryData.ResourceID, retryData.DestinationDomainID)
+	filteredDeposits, err := retry.FilterDeposits(h.propStorer, domainDeposits, retryData.ResourceID, retryData.AllResources, retryData.DestinationDomainID)
 	if err != nil {
 		return nil, err
 	}
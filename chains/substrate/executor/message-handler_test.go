@@ -4,14 +4,17 @@
 package executor_test
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"math/big"
 	"testing"
 	"unsafe"
 
+	substrateChains "github.com/ChainSafe/sygma-relayer/chains/substrate"
 	"github.com/ChainSafe/sygma-relayer/chains/substrate/executor"
 	mock_executor "github.com/ChainSafe/sygma-relayer/chains/substrate/executor/mock"
+	"github.com/ChainSafe/sygma-relayer/config/chain"
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/store"
@@ -20,6 +23,8 @@ import (
 
 	"github.com/ChainSafe/sygma-relayer/e2e/evm"
 	"github.com/ChainSafe/sygma-relayer/e2e/substrate"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
@@ -361,3 +366,456 @@ func (s *RetryMessageHandlerTestSuite) Test_HandleMessage_ValidDeposits() {
 	s.Equal(msgs[0].Data.(transfer.TransferMessageData).DepositNonce, failedNonce)
 	s.Equal(msgs[0].Destination, validDomain)
 }
+
+type NonFungibleTransferHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestRunNonFungibleTransferHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(NonFungibleTransferHandlerTestSuite))
+}
+
+func (s *NonFungibleTransferHandlerTestSuite) TestHandleMessage() {
+	collection := uint32(7)
+	mh := executor.NewSubstrateMessageHandler(nil, []chain.ResourceConfig{
+		{ResourceID: [32]byte{1}, Collection: &collection},
+	}, nil, nil, nil)
+	msg := &message.Message{
+		Source:      1,
+		Destination: 2,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{1},
+			Payload: []interface{}{
+				[]byte{5},
+				[]byte{0, 1, 1, 0},
+				[]byte{1, 2},
+			},
+			Type: transfer.NonFungibleTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+
+	prop, err := mh.HandleMessage(msg)
+
+	s.Nil(err)
+	data, _ := hex.DecodeString("07000000" + "05000000000000000000000000000000" + "00010100" + "080102")
+	s.Equal(prop.Data.(transfer.TransferProposalData).Data, data)
+}
+
+func (s *NonFungibleTransferHandlerTestSuite) TestHandleMessageMissingCollection() {
+	mh := executor.NewSubstrateMessageHandler(nil, []chain.ResourceConfig{{ResourceID: [32]byte{1}}}, nil, nil, nil)
+	msg := &message.Message{
+		Data: transfer.TransferMessageData{
+			ResourceId: [32]byte{1},
+			Payload:    []interface{}{[]byte{5}, []byte{0}, []byte{}},
+			Type:       transfer.NonFungibleTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+
+	prop, err := mh.HandleMessage(msg)
+
+	s.Nil(prop)
+	s.NotNil(err)
+}
+
+func (s *NonFungibleTransferHandlerTestSuite) TestHandleMessageTokenIDOverflow() {
+	collection := uint32(7)
+	mh := executor.NewSubstrateMessageHandler(nil, []chain.ResourceConfig{
+		{ResourceID: [32]byte{1}, Collection: &collection},
+	}, nil, nil, nil)
+	tokenID := new(big.Int).Lsh(big.NewInt(1), 128).Bytes()
+	msg := &message.Message{
+		Data: transfer.TransferMessageData{
+			ResourceId: [32]byte{1},
+			Payload:    []interface{}{tokenID, []byte{0}, []byte{}},
+			Type:       transfer.NonFungibleTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+
+	prop, err := mh.HandleMessage(msg)
+
+	s.Nil(prop)
+	s.NotNil(err)
+}
+
+type GenericTransferHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestRunGenericTransferHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(GenericTransferHandlerTestSuite))
+}
+
+func (s *GenericTransferHandlerTestSuite) TestHandleMessage() {
+	mh := executor.SubstrateMessageHandler{}
+	msg := &message.Message{
+		Source:      1,
+		Destination: 2,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{1},
+			Payload: []interface{}{
+				[]byte{10, 3},
+				[]byte{0, 1, 1, 0},
+				big.NewInt(1000).Bytes(),
+				[]byte{1, 2},
+				[]byte{4, 5, 6},
+			},
+			Type: transfer.PermissionlessGenericTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+
+	prop, err := mh.HandleMessage(msg)
+
+	s.Nil(err)
+	data, _ := hex.DecodeString("00010100" + "e803000000000000" + "080102" + "140a03040506")
+	s.Equal(prop.Data.(transfer.TransferProposalData).Data, data)
+}
+
+func (s *GenericTransferHandlerTestSuite) TestHandleMessageIncorrectPayloadLen() {
+	mh := executor.SubstrateMessageHandler{}
+	msg := &message.Message{
+		Data: transfer.TransferMessageData{
+			Payload: []interface{}{[]byte{10, 3}},
+			Type:    transfer.PermissionlessGenericTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+
+	prop, err := mh.HandleMessage(msg)
+
+	s.Nil(prop)
+	s.EqualError(err, "malformed payload. Len  of payload should be 5")
+}
+
+const (
+	u8Type = iota + 1
+	u32Type
+	u64Type
+	u128Type
+	bytesType
+	accountIDType
+	compactU32Type
+	networkIDType
+	optionalNetworkIDType
+	junctionType
+	junctionsType
+	multiLocationType
+	callType
+)
+
+func field(name string, typeID uint64) types.Si1Field {
+	return types.Si1Field{HasName: true, Name: types.Text(name), Type: types.NewSi1LookupTypeIDFromUInt(typeID)}
+}
+
+func variant(name string, index uint8, fields ...types.Si1Field) types.Si1Variant {
+	return types.Si1Variant{Name: types.Text(name), Index: types.NewU8(index), Fields: fields}
+}
+
+func variants(variants ...types.Si1Variant) *types.Si1Type {
+	return &types.Si1Type{Def: types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: variants}}}
+}
+
+func primitive(p types.Si0TypeDefPrimitive) *types.Si1Type {
+	return &types.Si1Type{Def: types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: p}}}
+}
+
+// handlerMetadata returns metadata of destination pallet calls whose arguments are laid out as
+// non-fungible (nft_transfer) and generic (xcm_call) proposal data, with XCM v3 MultiLocation types
+func handlerMetadata() *types.Metadata {
+	return &types.Metadata{
+		Version: 14,
+		AsMetadataV14: types.MetadataV14{
+			Pallets: []types.PalletMetadataV14{
+				{
+					Name:     "SygmaHandlers",
+					HasCalls: true,
+					Calls:    types.FunctionMetadataV14{Type: types.NewSi1LookupTypeIDFromUInt(callType)},
+				},
+			},
+			EfficientLookup: map[int64]*types.Si1Type{
+				u8Type:         primitive(types.IsU8),
+				u32Type:        primitive(types.IsU32),
+				u64Type:        primitive(types.IsU64),
+				u128Type:       primitive(types.IsU128),
+				bytesType:      {Def: types.Si1TypeDef{IsSequence: true, Sequence: types.Si1TypeDefSequence{Type: types.NewSi1LookupTypeIDFromUInt(u8Type)}}},
+				accountIDType:  {Def: types.Si1TypeDef{IsArray: true, Array: types.Si1TypeDefArray{Type: types.NewSi1LookupTypeIDFromUInt(u8Type), Len: 32}}},
+				compactU32Type: {Def: types.Si1TypeDef{IsCompact: true, Compact: types.Si1TypeDefCompact{Type: types.NewSi1LookupTypeIDFromUInt(u32Type)}}},
+				networkIDType:  variants(variant("Polkadot", 2), variant("Kusama", 3)),
+				optionalNetworkIDType: variants(
+					variant("None", 0),
+					variant("Some", 1, field("network", networkIDType)),
+				),
+				junctionType: variants(
+					variant("Parachain", 0, field("id", compactU32Type)),
+					variant("AccountId32", 1, field("network", optionalNetworkIDType), field("id", accountIDType)),
+				),
+				junctionsType: variants(
+					variant("Here", 0),
+					variant("X1", 1, field("0", junctionType)),
+					variant("X2", 2, field("0", junctionType), field("1", junctionType)),
+				),
+				multiLocationType: {
+					Def: types.Si1TypeDef{
+						IsComposite: true,
+						Composite: types.Si1TypeDefComposite{Fields: []types.Si1Field{
+							field("parents", u8Type),
+							field("interior", junctionsType),
+						}},
+					},
+				},
+				callType: variants(
+					variant("nft_transfer", 0,
+						field("collection", u32Type),
+						field("item", u128Type),
+						field("recipient", multiLocationType),
+						field("metadata", bytesType),
+					),
+					variant("xcm_call", 1,
+						field("dest", multiLocationType),
+						field("weight", u64Type),
+						field("depositor", bytesType),
+						field("call", bytesType),
+					),
+				),
+			},
+		},
+	}
+}
+
+type ProposalLayoutTestSuite struct {
+	suite.Suite
+
+	calls     registry.CallRegistry
+	recipient []byte
+}
+
+func TestRunProposalLayoutTestSuite(t *testing.T) {
+	suite.Run(t, new(ProposalLayoutTestSuite))
+}
+
+func (s *ProposalLayoutTestSuite) SetupTest() {
+	calls, err := registry.NewFactory().CreateCallRegistry(handlerMetadata())
+	s.Require().Nil(err)
+	s.calls = calls
+	s.recipient, _ = hex.DecodeString("0102" + "00411f" + "010102" + "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+}
+
+// decodeCall decodes proposal data as arguments of the pallet call and fails on trailing bytes
+func (s *ProposalLayoutTestSuite) decodeCall(method uint8, data []byte) registry.DecodedFields {
+	reader := bytes.NewReader(data)
+	fields, err := s.calls[types.CallIndex{SectionIndex: 0, MethodIndex: method}].Decode(scale.NewDecoder(reader))
+	s.Require().Nil(err)
+	s.Equal(reader.Len(), 0)
+	return fields
+}
+
+func byName(name string) registry.DecodedFieldPredicateFn {
+	return func(_ int, field *registry.DecodedField) bool {
+		return field.Name == name
+	}
+}
+
+func (s *ProposalLayoutTestSuite) Test_NonFungibleProposalMatchesPalletCall() {
+	collection := uint32(7)
+	mh := executor.NewSubstrateMessageHandler(nil, []chain.ResourceConfig{
+		{ResourceID: [32]byte{1}, Collection: &collection},
+	}, nil, nil, nil)
+	prop, err := mh.HandleMessage(&message.Message{
+		Data: transfer.TransferMessageData{
+			ResourceId: [32]byte{1},
+			Payload:    []interface{}{big.NewInt(5).Bytes(), s.recipient, []byte{1, 2}},
+			Type:       transfer.NonFungibleTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	})
+	s.Nil(err)
+
+	fields := s.decodeCall(0, prop.Data.(transfer.TransferProposalData).Data)
+
+	decodedCollection, err := registry.GetDecodedFieldAsType[types.U32](fields, byName("collection"))
+	s.Nil(err)
+	s.Equal(decodedCollection, types.NewU32(7))
+	item, err := registry.GetDecodedFieldAsType[types.U128](fields, byName("item"))
+	s.Nil(err)
+	s.Equal(item, types.NewU128(*big.NewInt(5)))
+	metadata, err := registry.GetDecodedFieldAsSliceOfType[types.U8](fields, byName("metadata"))
+	s.Nil(err)
+	s.Equal(metadata, []types.U8{1, 2})
+}
+
+func (s *ProposalLayoutTestSuite) Test_GenericProposalMatchesPalletCall() {
+	mh := executor.SubstrateMessageHandler{}
+	prop, err := mh.HandleMessage(&message.Message{
+		Data: transfer.TransferMessageData{
+			Payload: []interface{}{
+				[]byte{10, 3},
+				s.recipient,
+				big.NewInt(1000).Bytes(),
+				[]byte{1, 2},
+				[]byte{4, 5, 6},
+			},
+			Type: transfer.PermissionlessGenericTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	})
+	s.Nil(err)
+
+	fields := s.decodeCall(1, prop.Data.(transfer.TransferProposalData).Data)
+
+	weight, err := registry.GetDecodedFieldAsType[types.U64](fields, byName("weight"))
+	s.Nil(err)
+	s.Equal(weight, types.NewU64(1000))
+	depositor, err := registry.GetDecodedFieldAsSliceOfType[types.U8](fields, byName("depositor"))
+	s.Nil(err)
+	s.Equal(depositor, []types.U8{1, 2})
+	call, err := registry.GetDecodedFieldAsSliceOfType[types.U8](fields, byName("call"))
+	s.Nil(err)
+	s.Equal(call, []types.U8{10, 3, 4, 5, 6})
+}
+
+type RecipientValidationTestSuite struct {
+	suite.Suite
+
+	mockQuarantine *mock_executor.MockQuarantineStorer
+	mockRecorder   *mock_executor.MockProposalRecorder
+	messageHandler *executor.SubstrateMessageHandler
+}
+
+func TestRunRecipientValidationTestSuite(t *testing.T) {
+	suite.Run(t, new(RecipientValidationTestSuite))
+}
+
+func (s *RecipientValidationTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockQuarantine = mock_executor.NewMockQuarantineStorer(ctrl)
+	s.mockRecorder = mock_executor.NewMockProposalRecorder(ctrl)
+	parachainID := uint32(2004)
+	s.messageHandler = executor.NewSubstrateMessageHandler(
+		nil,
+		[]chain.ResourceConfig{},
+		substrateChains.NewParachainTopology(&parachainID, []uint32{2000}),
+		s.mockQuarantine,
+		s.mockRecorder,
+	)
+}
+
+func (s *RecipientValidationTestSuite) fungibleTransfer(recipient []byte) *message.Message {
+	return &message.Message{
+		Source:      1,
+		Destination: 2,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{1},
+			Payload: []interface{}{
+				[]byte{2},
+				recipient,
+			},
+			Type: transfer.FungibleTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+}
+
+func (s *RecipientValidationTestSuite) Test_LocalRecipient() {
+	recipientAddr := *(*[]types.U8)(unsafe.Pointer(&substrate.SubstratePK.PublicKey))
+	s.mockRecorder.EXPECT().UpdateProposal(gomock.Any()).Return(nil)
+
+	prop, err := s.messageHandler.HandleMessage(s.fungibleTransfer(substrate.ConstructRecipientData(recipientAddr)))
+
+	s.Nil(err)
+	s.NotNil(prop)
+}
+
+func (s *RecipientValidationTestSuite) Test_SiblingParachainRecipient() {
+	recipient, _ := hex.DecodeString("0102" + "00411f" + "0100" + "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	s.mockRecorder.EXPECT().UpdateProposal(store.ProposalUpdate{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: 1,
+		Recipient:    "{parents: 1, interior: X2(Parachain(2000), AccountId32(0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d))}",
+	}).Return(nil)
+
+	prop, err := s.messageHandler.HandleMessage(s.fungibleTransfer(recipient))
+
+	s.Nil(err)
+	s.NotNil(prop)
+}
+
+func (s *RecipientValidationTestSuite) Test_V1RecipientWithNetwork() {
+	recipient, _ := hex.DecodeString("0102" + "00411f" + "0102" + "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	s.mockRecorder.EXPECT().UpdateProposal(gomock.Any()).DoAndReturn(func(update store.ProposalUpdate) error {
+		s.Contains(update.Recipient, "AccountId32(Polkadot")
+		return nil
+	})
+
+	prop, err := s.messageHandler.HandleMessage(s.fungibleTransfer(recipient))
+
+	s.Nil(err)
+	s.NotNil(prop)
+}
+
+func (s *RecipientValidationTestSuite) Test_UnreachableParachainRejected() {
+	recipient, _ := hex.DecodeString("0102" + "00451f" + "0100" + "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	s.mockQuarantine.EXPECT().Quarantine(gomock.Any()).DoAndReturn(func(record store.QuarantineRecord) error {
+		s.Equal(record.Status, store.RejectedProp)
+		s.Equal(record.DepositNonce, uint64(1))
+		s.Contains(record.Reason, "sibling parachain 2001 not reachable")
+		return nil
+	})
+
+	prop, err := s.messageHandler.HandleMessage(s.fungibleTransfer(recipient))
+
+	s.Nil(prop)
+	s.NotNil(err)
+}
+
+func (s *RecipientValidationTestSuite) Test_MalformedRecipientRejected() {
+	s.mockQuarantine.EXPECT().Quarantine(gomock.Any()).DoAndReturn(func(record store.QuarantineRecord) error {
+		s.Equal(record.Status, store.RejectedProp)
+		s.Contains(record.Reason, "malformed recipient")
+		return nil
+	})
+
+	prop, err := s.messageHandler.HandleMessage(s.fungibleTransfer([]byte{0, 1, 1, 0}))
+
+	s.Nil(prop)
+	s.NotNil(err)
+}
+
+func (s *RecipientValidationTestSuite) Test_RecipientWithoutAccountRejected() {
+	recipient, _ := hex.DecodeString("0101" + "00411f")
+	s.mockQuarantine.EXPECT().Quarantine(gomock.Any()).Return(nil)
+
+	prop, err := s.messageHandler.HandleMessage(s.fungibleTransfer(recipient))
+
+	s.Nil(prop)
+	s.NotNil(err)
+}
+
+func (s *RecipientValidationTestSuite) Test_GenericTransferToParachain() {
+	dest, _ := hex.DecodeString("0101" + "00411f")
+	s.mockRecorder.EXPECT().UpdateProposal(gomock.Any()).Return(nil)
+	msg := &message.Message{
+		Data: transfer.TransferMessageData{
+			Payload: []interface{}{
+				[]byte{10, 3},
+				dest,
+				big.NewInt(1000).Bytes(),
+				[]byte{1, 2},
+				[]byte{4, 5, 6},
+			},
+			Type: transfer.PermissionlessGenericTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+
+	prop, err := s.messageHandler.HandleMessage(msg)
+
+	s.Nil(err)
+	s.NotNil(prop)
+}
@@ -14,6 +14,80 @@ import (
 	message "github.com/sygmaprotocol/sygma-core/relayer/message"
 )
 
+// MockQuarantineStorer is a mock of QuarantineStorer interface.
+type MockQuarantineStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuarantineStorerMockRecorder
+}
+
+// MockQuarantineStorerMockRecorder is the mock recorder for MockQuarantineStorer.
+type MockQuarantineStorerMockRecorder struct {
+	mock *MockQuarantineStorer
+}
+
+// NewMockQuarantineStorer creates a new mock instance.
+func NewMockQuarantineStorer(ctrl *gomock.Controller) *MockQuarantineStorer {
+	mock := &MockQuarantineStorer{ctrl: ctrl}
+	mock.recorder = &MockQuarantineStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuarantineStorer) EXPECT() *MockQuarantineStorerMockRecorder {
+	return m.recorder
+}
+
+// Quarantine mocks base method.
+func (m *MockQuarantineStorer) Quarantine(record store.QuarantineRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quarantine", record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Quarantine indicates an expected call of Quarantine.
+func (mr *MockQuarantineStorerMockRecorder) Quarantine(record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quarantine", reflect.TypeOf((*MockQuarantineStorer)(nil).Quarantine), record)
+}
+
+// MockProposalRecorder is a mock of ProposalRecorder interface.
+type MockProposalRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockProposalRecorderMockRecorder
+}
+
+// MockProposalRecorderMockRecorder is the mock recorder for MockProposalRecorder.
+type MockProposalRecorderMockRecorder struct {
+	mock *MockProposalRecorder
+}
+
+// NewMockProposalRecorder creates a new mock instance.
+func NewMockProposalRecorder(ctrl *gomock.Controller) *MockProposalRecorder {
+	mock := &MockProposalRecorder{ctrl: ctrl}
+	mock.recorder = &MockProposalRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProposalRecorder) EXPECT() *MockProposalRecorderMockRecorder {
+	return m.recorder
+}
+
+// UpdateProposal mocks base method.
+func (m *MockProposalRecorder) UpdateProposal(update store.ProposalUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProposal", update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProposal indicates an expected call of UpdateProposal.
+func (mr *MockProposalRecorderMockRecorder) UpdateProposal(update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProposal", reflect.TypeOf((*MockProposalRecorder)(nil).UpdateProposal), update)
+}
+
 // MockPropStorer is a mock of PropStorer interface.
 type MockPropStorer struct {
 	ctrl     *gomock.Controller
@@ -66,6 +140,20 @@ func (mr *MockPropStorerMockRecorder) StorePropStatus(source, destination, depos
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePropStatus", reflect.TypeOf((*MockPropStorer)(nil).StorePropStatus), source, destination, depositNonce, status)
 }
 
+// UpdateProposal mocks base method.
+func (m *MockPropStorer) UpdateProposal(update store.ProposalUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProposal", update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProposal indicates an expected call of UpdateProposal.
+func (mr *MockPropStorerMockRecorder) UpdateProposal(update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProposal", reflect.TypeOf((*MockPropStorer)(nil).UpdateProposal), update)
+}
+
 // MockBlockFetcher is a mock of BlockFetcher interface.
 type MockBlockFetcher struct {
 	ctrl     *gomock.Controller
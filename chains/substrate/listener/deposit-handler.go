@@ -5,6 +5,7 @@ package listener
 
 import (
 	"errors"
+	"math/big"
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
@@ -22,6 +23,8 @@ type SubstrateDepositHandler struct {
 
 const (
 	FungibleTransfer = iota
+	NonFungibleTransfer
+	GenericTransfer
 )
 
 // NewSubstrateDepositHandler creates an instance of SubstrateDepositHandler that contains
@@ -42,9 +45,14 @@ func (e *SubstrateDepositHandler) HandleDeposit(
 	messageID string,
 	timestamp time.Time) (*message.Message, error) {
 	var depositType transfer.TransferType
-	if transferType == FungibleTransfer {
+	switch transferType {
+	case FungibleTransfer:
 		depositType = transfer.FungibleTransfer
-	} else {
+	case NonFungibleTransfer:
+		depositType = transfer.NonFungibleTransfer
+	case GenericTransfer:
+		depositType = transfer.PermissionlessGenericTransfer
+	default:
 		return nil, errors.New("no corresponding deposit handler for this transfer type exists")
 	}
 
@@ -118,3 +126,128 @@ func FungibleTransferHandler(
 		transfer.TransferMessageType,
 		timestamp), nil
 }
+
+// NonFungibleTransferHandler converts data pulled from event logs into non-fungible transfer message.
+// Calldata contains 32 bytes token ID, length prefixed recipient and length prefixed token metadata.
+func NonFungibleTransferHandler(
+	sourceID uint8,
+	destID types.U8,
+	nonce types.U64,
+	resourceID types.Bytes32,
+	calldata []byte,
+	messageID string,
+	timestamp time.Time) (*message.Message, error) {
+	if len(calldata) < 64 {
+		return nil, errors.New("invalid calldata length: less than 64 bytes")
+	}
+
+	// first 32 bytes are tokenId
+	tokenID := calldata[:32]
+
+	// 32 - 64 is recipient address length
+	recipientAddressLength := big.NewInt(0).SetBytes(calldata[32:64])
+	if !recipientAddressLength.IsInt64() || recipientAddressLength.Int64() > int64(len(calldata)-64) {
+		return nil, errors.New("invalid calldata: recipient length exceeds calldata")
+	}
+
+	// 64 - (64 + recipient address length) is recipient address
+	recipientEnd := 64 + recipientAddressLength.Int64()
+	recipientAddress := calldata[64:recipientEnd]
+
+	// (64 + recipient address length) - ((64 + recipient address length) + 32) is metadata length
+	var metadata []byte
+	if int64(len(calldata)) >= recipientEnd+32 {
+		metadataLength := big.NewInt(0).SetBytes(calldata[recipientEnd : recipientEnd+32])
+		if !metadataLength.IsInt64() || metadataLength.Int64() > int64(len(calldata))-recipientEnd-32 {
+			return nil, errors.New("invalid calldata: metadata length exceeds calldata")
+		}
+		metadataStart := recipientEnd + 32
+		metadata = calldata[metadataStart : metadataStart+metadataLength.Int64()]
+	}
+
+	return message.NewMessage(
+		sourceID,
+		uint8(destID),
+		transfer.TransferMessageData{
+			DepositNonce: uint64(nonce),
+			ResourceId:   resourceID,
+			Payload: []interface{}{
+				tokenID,
+				recipientAddress,
+				metadata,
+			},
+			Type: transfer.NonFungibleTransfer,
+		},
+		messageID,
+		transfer.TransferMessageType,
+		timestamp), nil
+}
+
+// GenericTransferHandler converts data pulled from event logs into generic message.
+// Calldata has the same layout as permissionless generic deposits on EVM chains:
+// 32 bytes max fee, 2 bytes function signature length, function signature,
+// 1 byte contract address length, contract address, 1 byte depositor length,
+// depositor and execution data.
+func GenericTransferHandler(
+	sourceID uint8,
+	destID types.U8,
+	nonce types.U64,
+	resourceID types.Bytes32,
+	calldata []byte,
+	messageID string,
+	timestamp time.Time) (*message.Message, error) {
+	if len(calldata) < 35 {
+		return nil, errors.New("invalid calldata length: less than 35 bytes")
+	}
+
+	maxFee := calldata[:32]
+	offset := 32
+
+	functionSigLen := int(big.NewInt(0).SetBytes(calldata[offset : offset+2]).Int64())
+	offset += 2
+	if len(calldata) < offset+functionSigLen+1 {
+		return nil, errors.New("invalid calldata: function signature length exceeds calldata")
+	}
+	functionSig := calldata[offset : offset+functionSigLen]
+	offset += functionSigLen
+
+	contractAddressLen := int(calldata[offset])
+	offset++
+	if len(calldata) < offset+contractAddressLen+1 {
+		return nil, errors.New("invalid calldata: contract address length exceeds calldata")
+	}
+	contractAddress := calldata[offset : offset+contractAddressLen]
+	offset += contractAddressLen
+
+	depositorLen := int(calldata[offset])
+	offset++
+	if len(calldata) < offset+depositorLen {
+		return nil, errors.New("invalid calldata: depositor length exceeds calldata")
+	}
+	depositor := calldata[offset : offset+depositorLen]
+	offset += depositorLen
+
+	executionData := calldata[offset:]
+
+	return message.NewMessage(
+		sourceID,
+		uint8(destID),
+		transfer.TransferMessageData{
+			DepositNonce: uint64(nonce),
+			ResourceId:   resourceID,
+			Payload: []interface{}{
+				functionSig,
+				contractAddress,
+				maxFee,
+				depositor,
+				executionData,
+			},
+			Metadata: map[string]interface{}{
+				"gasLimit": big.NewInt(0).SetBytes(maxFee).Uint64(),
+			},
+			Type: transfer.PermissionlessGenericTransfer,
+		},
+		messageID,
+		transfer.TransferMessageType,
+		timestamp), nil
+}
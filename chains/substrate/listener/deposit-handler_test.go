@@ -151,3 +151,145 @@ func (s *Erc20HandlerTestSuite) TestSuccesfullyRegisterFungibleTransferHandler()
 	s.NotNil(err2)
 	s.EqualError(err2, errNoCorrespondingDepositHandler.Error())
 }
+
+type NonFungibleTransferHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestRunNonFungibleTransferHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(NonFungibleTransferHandlerTestSuite))
+}
+
+func (s *NonFungibleTransferHandlerTestSuite) TestHandleDeposit() {
+	recipient := []byte{0, 1, 1, 0, 212, 53}
+	metadata := []byte("metadata")
+	var calldata []byte
+	tokenID, _ := types.BigIntToIntBytes(big.NewInt(5), 32)
+	calldata = append(calldata, tokenID...)
+	recipientLen, _ := types.BigIntToIntBytes(big.NewInt(int64(len(recipient))), 32)
+	calldata = append(calldata, recipientLen...)
+	calldata = append(calldata, recipient...)
+	metadataLen, _ := types.BigIntToIntBytes(big.NewInt(int64(len(metadata))), 32)
+	calldata = append(calldata, metadataLen...)
+	calldata = append(calldata, metadata...)
+	timestamp := time.Now()
+
+	depositHandler := listener.NewSubstrateDepositHandler()
+	depositHandler.RegisterDepositHandler(transfer.NonFungibleTransfer, listener.NonFungibleTransferHandler)
+	msg, err := depositHandler.HandleDeposit(1, types.NewU8(2), types.NewU64(1), types.Bytes32{1}, calldata, listener.NonFungibleTransfer, "messageID", timestamp)
+
+	s.Nil(err)
+	s.Equal(msg, message.NewMessage(
+		1,
+		2,
+		transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{1},
+			Payload: []interface{}{
+				tokenID,
+				recipient,
+				metadata,
+			},
+			Type: transfer.NonFungibleTransfer,
+		},
+		"messageID",
+		transfer.TransferMessageType,
+		timestamp,
+	))
+}
+
+func (s *NonFungibleTransferHandlerTestSuite) TestHandleDepositWithoutMetadata() {
+	recipient := []byte{0, 1, 1, 0, 212, 53}
+	var calldata []byte
+	tokenID, _ := types.BigIntToIntBytes(big.NewInt(5), 32)
+	calldata = append(calldata, tokenID...)
+	recipientLen, _ := types.BigIntToIntBytes(big.NewInt(int64(len(recipient))), 32)
+	calldata = append(calldata, recipientLen...)
+	calldata = append(calldata, recipient...)
+
+	msg, err := listener.NonFungibleTransferHandler(1, types.NewU8(2), types.NewU64(1), types.Bytes32{1}, calldata, "messageID", time.Now())
+
+	s.Nil(err)
+	s.Equal(msg.Data.(transfer.TransferMessageData).Payload, []interface{}{tokenID, recipient, []byte(nil)})
+}
+
+func (s *NonFungibleTransferHandlerTestSuite) TestHandleDepositInvalidRecipientLength() {
+	var calldata []byte
+	tokenID, _ := types.BigIntToIntBytes(big.NewInt(5), 32)
+	calldata = append(calldata, tokenID...)
+	recipientLen, _ := types.BigIntToIntBytes(big.NewInt(100), 32)
+	calldata = append(calldata, recipientLen...)
+
+	msg, err := listener.NonFungibleTransferHandler(1, types.NewU8(2), types.NewU64(1), types.Bytes32{1}, calldata, "messageID", time.Now())
+
+	s.Nil(msg)
+	s.NotNil(err)
+}
+
+type GenericTransferHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestRunGenericTransferHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(GenericTransferHandlerTestSuite))
+}
+
+func (s *GenericTransferHandlerTestSuite) TestHandleDeposit() {
+	callIndex := []byte{10, 3}
+	dest := []byte{0, 1, 1, 0, 212, 53}
+	depositor := []byte{1, 2, 3, 4}
+	executionData := []byte{5, 6, 7}
+	var calldata []byte
+	maxFee, _ := types.BigIntToIntBytes(big.NewInt(200000), 32)
+	calldata = append(calldata, maxFee...)
+	calldata = append(calldata, 0, byte(len(callIndex)))
+	calldata = append(calldata, callIndex...)
+	calldata = append(calldata, byte(len(dest)))
+	calldata = append(calldata, dest...)
+	calldata = append(calldata, byte(len(depositor)))
+	calldata = append(calldata, depositor...)
+	calldata = append(calldata, executionData...)
+	timestamp := time.Now()
+
+	depositHandler := listener.NewSubstrateDepositHandler()
+	depositHandler.RegisterDepositHandler(transfer.PermissionlessGenericTransfer, listener.GenericTransferHandler)
+	msg, err := depositHandler.HandleDeposit(1, types.NewU8(2), types.NewU64(1), types.Bytes32{1}, calldata, listener.GenericTransfer, "messageID", timestamp)
+
+	s.Nil(err)
+	s.Equal(msg, message.NewMessage(
+		1,
+		2,
+		transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{1},
+			Payload: []interface{}{
+				callIndex,
+				dest,
+				maxFee,
+				depositor,
+				executionData,
+			},
+			Metadata: map[string]interface{}{
+				"gasLimit": uint64(200000),
+			},
+			Type: transfer.PermissionlessGenericTransfer,
+		},
+		"messageID",
+		transfer.TransferMessageType,
+		timestamp,
+	))
+}
+
+func (s *GenericTransferHandlerTestSuite) TestHandleDepositInvalidDepositorLength() {
+	var calldata []byte
+	maxFee, _ := types.BigIntToIntBytes(big.NewInt(200000), 32)
+	calldata = append(calldata, maxFee...)
+	calldata = append(calldata, 0, 0)
+	calldata = append(calldata, 0)
+	calldata = append(calldata, 20)
+
+	msg, err := listener.GenericTransferHandler(1, types.NewU8(2), types.NewU64(1), types.Bytes32{1}, calldata, "messageID", time.Now())
+
+	s.Nil(msg)
+	s.NotNil(err)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/chains/substrate/events"
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/rs/zerolog"
@@ -25,13 +26,21 @@ type Connection interface {
 	FetchEvents(startBlock, endBlock *big.Int) ([]*parser.Event, error)
 }
 
+type MetadataUpdater interface {
+	UpdateMetatdata() error
+}
+
 type SystemUpdateEventHandler struct {
-	conn Connection
+	conn    Connection
+	updater MetadataUpdater
 }
 
-func NewSystemUpdateEventHandler(conn Connection) *SystemUpdateEventHandler {
+// NewSystemUpdateEventHandler creates handler that refreshes metadata with the updater
+// when runtime upgrade is applied on parachains or solo chains
+func NewSystemUpdateEventHandler(conn Connection, updater MetadataUpdater) *SystemUpdateEventHandler {
 	return &SystemUpdateEventHandler{
-		conn: conn,
+		conn:    conn,
+		updater: updater,
 	}
 }
 
@@ -42,10 +51,10 @@ func (eh *SystemUpdateEventHandler) HandleEvents(startBlock *big.Int, endBlock *
 		return err
 	}
 	for _, e := range evts {
-		if e.Name == events.ParachainUpdatedEvent {
-			log.Info().Msgf("Updating substrate metadata")
+		if e.Name == events.ParachainUpdatedEvent || e.Name == events.CodeUpdatedEvent {
+			log.Info().Msgf("Updating substrate metadata after %s", e.Name)
 
-			err := eh.conn.UpdateMetatdata()
+			err := eh.updater.UpdateMetatdata()
 			if err != nil {
 				log.Error().Err(err).Msg("Unable to update Metadata")
 				return err
@@ -141,23 +150,23 @@ func (eh *FungibleTransferEventHandler) ProcessDeposits(startBlock *big.Int, end
 }
 
 type RetryEventHandler struct {
-	conn           Connection
-	domainID       uint8
-	depositHandler DepositHandler
-	log            zerolog.Logger
-	msgChan        chan []*message.Message
+	conn     Connection
+	domainID uint8
+	log      zerolog.Logger
+	msgChan  chan []*message.Message
 }
 
-func NewRetryEventHandler(logC zerolog.Context, conn Connection, depositHandler DepositHandler, domainID uint8, msgChan chan []*message.Message) *RetryEventHandler {
+func NewRetryEventHandler(logC zerolog.Context, conn Connection, domainID uint8, msgChan chan []*message.Message) *RetryEventHandler {
 	return &RetryEventHandler{
-		depositHandler: depositHandler,
-		domainID:       domainID,
-		conn:           conn,
-		log:            logC.Logger(),
-		msgChan:        msgChan,
+		domainID: domainID,
+		conn:     conn,
+		log:      logC.Logger(),
+		msgChan:  msgChan,
 	}
 }
 
+// HandleEvents converts retry events to retry messages filtered by destination domain and resource.
+// Retried deposits are resolved and filtered against the propstore by the retry message handler.
 func (rh *RetryEventHandler) HandleEvents(startBlock *big.Int, endBlock *big.Int) error {
 	evts, err := rh.conn.FetchEvents(startBlock, endBlock)
 	if err != nil {
@@ -165,76 +174,41 @@ func (rh *RetryEventHandler) HandleEvents(startBlock *big.Int, endBlock *big.Int
 		return err
 	}
 
-	hash, err := rh.conn.GetFinalizedHead()
-	if err != nil {
-		return err
-	}
-	finalized, err := rh.conn.GetBlock(hash)
-	if err != nil {
-		return err
-	}
-	finalizedBlockNumber := big.NewInt(int64(finalized.Block.Header.Number))
-
-	domainDeposits := make(map[uint8][]*message.Message)
 	for _, evt := range evts {
-		if evt.Name == events.RetryEvent {
-			err := func(evt parser.Event) error {
-				defer func() {
-					if r := recover(); r != nil {
-						log.Error().Msgf("panic occured while handling retry event %+v because %s", evt, r)
-					}
-				}()
-				er, err := DecodeRetryEvent(evt.Fields)
-				if err != nil {
-					return err
-				}
-				// (latestBlockNumber - event.DepositOnBlockHeight) == blockConfirmations
-				if big.NewInt(finalizedBlockNumber.Int64()).Cmp(er.DepositOnBlockHeight.Int) == -1 {
-					log.Warn().Msgf("Retry event for block number %d has not enough confirmations", er.DepositOnBlockHeight)
-					return nil
-				}
-
-				bh, err := rh.conn.GetBlockHash(er.DepositOnBlockHeight.Uint64())
-				if err != nil {
-					return err
-				}
-
-				bEvts, err := rh.conn.GetBlockEvents(bh)
-				if err != nil {
-					return err
-				}
-
-				for _, event := range bEvts {
-					if event.Name == events.DepositEvent {
-						d, err := DecodeDepositEvent(event.Fields)
-						if err != nil {
-							return err
-						}
-
-						messageID := fmt.Sprintf("retry-%d-%d-%d-%d", rh.domainID, d.DestDomainID, startBlock, endBlock)
-						m, err := rh.depositHandler.HandleDeposit(
-							rh.domainID, d.DestDomainID, d.DepositNonce, d.ResourceID, d.CallData, d.TransferType, messageID, d.Timestamp,
-						)
-						if err != nil {
-							return err
-						}
-
-						rh.log.Info().Str("messageID", messageID).Msgf("Resolved retry message %+v", d)
+		if evt.Name != events.RetryEvent {
+			continue
+		}
 
-						domainDeposits[m.Destination] = append(domainDeposits[m.Destination], m)
-					}
-				}
+		er, err := DecodeRetryEvent(evt.Fields)
+		if err != nil {
+			rh.log.Error().Err(err).Msgf("Failed decoding retry event %+v", evt)
+			continue
+		}
 
-				return nil
-			}(*evt)
-			if err != nil {
-				return err
-			}
+		retryData := retry.RetryMessageData{
+			SourceDomainID:      rh.domainID,
+			DestinationDomainID: uint8(er.DestDomainID),
+			BlockHeight:         er.DepositOnBlockHeight.Int,
+			AllResources:        er.ResourceID == nil,
+		}
+		if er.ResourceID != nil {
+			retryData.ResourceID = *er.ResourceID
 		}
-	}
 
-	for _, deposits := range domainDeposits {
-		rh.msgChan <- deposits
+		messageID := fmt.Sprintf("retry-%d-%d", rh.domainID, er.DestDomainID)
+		msg := message.NewMessage(
+			rh.domainID,
+			rh.domainID,
+			retryData,
+			messageID,
+			retry.RetryMessageType,
+			time.Now(),
+		)
+
+		rh.log.Info().Str("messageID", messageID).Msgf(
+			"Resolved retry message %+v in block range: %s-%s", msg, startBlock.String(), endBlock.String(),
+		)
+		go func() { rh.msgChan <- []*message.Message{msg} }()
 	}
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ChainSafe/sygma-relayer/chains/substrate/listener"
 	mock_events "github.com/ChainSafe/sygma-relayer/chains/substrate/listener/mock"
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/rs/zerolog"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
@@ -35,7 +36,7 @@ func TestRunSystemUpdateHandlerTestSuite(t *testing.T) {
 func (s *SystemUpdateHandlerTestSuite) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	s.mockConn = mock_events.NewMockConnection(ctrl)
-	s.systemUpdateHandler = listener.NewSystemUpdateEventHandler(s.mockConn)
+	s.systemUpdateHandler = listener.NewSystemUpdateEventHandler(s.mockConn, s.mockConn)
 }
 
 func (s *SystemUpdateHandlerTestSuite) Test_UpdateMetadataFails() {
@@ -73,6 +74,20 @@ func (s *SystemUpdateHandlerTestSuite) Test_SuccesfullMetadataUpdate() {
 	s.Nil(err)
 }
 
+func (s *SystemUpdateHandlerTestSuite) Test_SuccesfullMetadataUpdate_CodeUpdated() {
+	s.mockConn.EXPECT().UpdateMetatdata().Return(nil)
+	evts := []*parser.Event{
+		{
+			Name: "System.CodeUpdated",
+		},
+	}
+	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
+
+	err := s.systemUpdateHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
+
+	s.Nil(err)
+}
+
 type DepositHandlerTestSuite struct {
 	suite.Suite
 	depositEventHandler *listener.FungibleTransferEventHandler
@@ -328,11 +343,10 @@ func (s *DepositHandlerTestSuite) Test_HandleDepositPanics_ExecutionContinues()
 
 type RetryHandlerTestSuite struct {
 	suite.Suite
-	retryHandler       *listener.RetryEventHandler
-	mockDepositHandler *mock_events.MockDepositHandler
-	mockConn           *mock_events.MockConnection
-	domainID           uint8
-	msgChan            chan []*message.Message
+	retryHandler *listener.RetryEventHandler
+	mockConn     *mock_events.MockConnection
+	domainID     uint8
+	msgChan      chan []*message.Message
 }
 
 func TestRunRetryHandlerTestSuite(t *testing.T) {
@@ -342,15 +356,12 @@ func TestRunRetryHandlerTestSuite(t *testing.T) {
 func (s *RetryHandlerTestSuite) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	s.domainID = 1
-	s.mockDepositHandler = mock_events.NewMockDepositHandler(ctrl)
 	s.mockConn = mock_events.NewMockConnection(ctrl)
 	s.msgChan = make(chan []*message.Message, 2)
-	s.retryHandler = listener.NewRetryEventHandler(zerolog.Context{}, s.mockConn, s.mockDepositHandler, s.domainID, s.msgChan)
-
+	s.retryHandler = listener.NewRetryEventHandler(zerolog.Context{}, s.mockConn, s.domainID, s.msgChan)
 }
 
-func (s *RetryHandlerTestSuite) Test_CannotFetchLatestBlock() {
-
+func (s *RetryHandlerTestSuite) Test_FetchingEventsFails() {
 	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return([]*parser.Event{}, fmt.Errorf("error"))
 
 	err := s.retryHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
@@ -358,112 +369,16 @@ func (s *RetryHandlerTestSuite) Test_CannotFetchLatestBlock() {
 	s.NotNil(err)
 }
 
-func (s *RetryHandlerTestSuite) Test_EventTooNew() {
-	s.mockConn.EXPECT().GetFinalizedHead().Return(types.Hash{}, nil)
-	s.mockConn.EXPECT().GetBlock(gomock.Any()).Return(&types.SignedBlock{Block: types.Block{
-		Header: types.Header{
-			Number: types.BlockNumber(uint32(100)),
-		},
-	}}, nil)
-
-	rtry := map[string]any{
-		"deposit_on_block_height": types.NewU128(*big.NewInt(101)),
-	}
-	evts := []*parser.Event{
-		{
-			Name: "SygmaBridge.Retry",
-			Fields: registry.DecodedFields{
-				&registry.DecodedField{Name: "deposit_on_block_height", Value: rtry["deposit_on_block_height"]},
-			},
-		},
-	}
-	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
-
-	err := s.retryHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
-	s.Nil(err)
-	s.Equal(len(s.msgChan), 0)
-}
-
-func (s *RetryHandlerTestSuite) Test_FetchingBlockHashFails() {
-	s.mockConn.EXPECT().GetFinalizedHead().Return(types.Hash{}, nil)
-	s.mockConn.EXPECT().GetBlock(gomock.Any()).Return(&types.SignedBlock{Block: types.Block{
-		Header: types.Header{
-			Number: types.BlockNumber(uint32(100)),
-		},
-	}}, nil)
-
-	s.mockConn.EXPECT().GetBlockHash(uint64(95)).Return(types.Hash{}, fmt.Errorf("error"))
-
-	rtry := map[string]any{
-		"deposit_on_block_height": types.NewU128(*big.NewInt(95)),
-	}
-	evts := []*parser.Event{
-		{
-			Name: "SygmaBridge.Retry",
-			Fields: registry.DecodedFields{
-				&registry.DecodedField{Name: "deposit_on_block_height", Value: rtry["deposit_on_block_height"]},
-			},
-		},
-	}
-	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
-
-	err := s.retryHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
-
-	s.NotNil(err)
-	s.Equal(len(s.msgChan), 0)
-}
-
-func (s *RetryHandlerTestSuite) Test_FetchingBlockEventsFails() {
-	s.mockConn.EXPECT().GetFinalizedHead().Return(types.Hash{}, nil)
-	s.mockConn.EXPECT().GetBlock(gomock.Any()).Return(&types.SignedBlock{Block: types.Block{
-		Header: types.Header{
-			Number: types.BlockNumber(uint32(100)),
-		},
-	}}, nil)
-	s.mockConn.EXPECT().GetBlockHash(uint64(95)).Return(types.Hash{}, nil)
-
-	rtry := map[string]any{
-		"deposit_on_block_height": types.NewU128(*big.NewInt(95)),
-	}
-	evts := []*parser.Event{
-		{
-			Name: "SygmaBridge.Retry",
-			Fields: registry.DecodedFields{
-				&registry.DecodedField{Name: "deposit_on_block_height", Value: rtry["deposit_on_block_height"]},
-			},
-		},
-	}
-	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
-	s.mockConn.EXPECT().GetBlockEvents(gomock.Any()).Return(nil, fmt.Errorf("error"))
-
-	err := s.retryHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
-
-	s.NotNil(err)
-	s.Equal(len(s.msgChan), 0)
-}
-
-func (s *RetryHandlerTestSuite) Test_NoEvents() {
-	s.mockConn.EXPECT().GetFinalizedHead().Return(types.Hash{}, nil)
-	s.mockConn.EXPECT().GetBlock(gomock.Any()).Return(&types.SignedBlock{Block: types.Block{
-		Header: types.Header{
-			Number: types.BlockNumber(uint32(100)),
-		},
-	}}, nil)
-	s.mockConn.EXPECT().GetBlockHash(uint64(95)).Return(types.Hash{}, nil)
-
-	rtry := map[string]any{
-		"deposit_on_block_height": types.NewU128(*big.NewInt(95)),
-	}
+func (s *RetryHandlerTestSuite) Test_InvalidEventSkipped() {
 	evts := []*parser.Event{
 		{
 			Name: "SygmaBridge.Retry",
 			Fields: registry.DecodedFields{
-				&registry.DecodedField{Name: "deposit_on_block_height", Value: rtry["deposit_on_block_height"]},
+				&registry.DecodedField{Name: "dest_domain_id", Value: types.NewU8(2)},
 			},
 		},
 	}
 	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
-	s.mockConn.EXPECT().GetBlockEvents(gomock.Any()).Return([]*parser.Event{}, nil)
 
 	err := s.retryHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
 
@@ -472,208 +387,58 @@ func (s *RetryHandlerTestSuite) Test_NoEvents() {
 }
 
 func (s *RetryHandlerTestSuite) Test_ValidEvents() {
-	s.mockConn.EXPECT().GetFinalizedHead().Return(types.Hash{}, nil)
-	s.mockConn.EXPECT().GetBlock(gomock.Any()).Return(&types.SignedBlock{Block: types.Block{
-		Header: types.Header{
-			Number: types.BlockNumber(uint32(100)),
-		},
-	}}, nil)
-	s.mockConn.EXPECT().GetBlockHash(uint64(95)).Return(types.Hash{}, nil)
-	d1 := map[string]any{
-		"dest_domain_id":            types.NewU8(2),
-		"deposit_nonce":             types.NewU64(1),
-		"resource_id":               types.Bytes32{1},
-		"sygma_traits_TransferType": types.NewU8(0),
-		"handler_response":          [1]byte{0},
-		"deposit_data":              []byte{},
-	}
-	d2 := map[string]any{
-		"deposit_nonce":             types.NewU64(2),
-		"dest_domain_id":            types.NewU8(2),
-		"resource_id":               types.Bytes32{1},
-		"sygma_traits_TransferType": types.NewU8(0),
-		"handler_response":          [1]byte{0},
-		"deposit_data":              []byte{},
-	}
-	blockEvts := []*parser.Event{
-		{
-			Name: "SygmaBridge.Deposit",
-			Fields: registry.DecodedFields{
-				&registry.DecodedField{Name: "dest_domain_id", Value: d1["dest_domain_id"]},
-				&registry.DecodedField{Name: "resource_id", Value: d1["resource_id"]},
-				&registry.DecodedField{Name: "deposit_nonce", Value: d1["deposit_nonce"]},
-				&registry.DecodedField{Name: "sygma_traits_TransferType", Value: d1["sygma_traits_TransferType"]},
-				&registry.DecodedField{Name: "deposit_data", Value: d1["deposit_data"]},
-				&registry.DecodedField{Name: "handler_response", Value: d1["handler_response"]},
-			},
-		},
+	evts := []*parser.Event{
 		{
 			Name: "SygmaBridge.Deposit",
-			Fields: registry.DecodedFields{
-				&registry.DecodedField{Name: "dest_domain_id", Value: d2["dest_domain_id"]},
-				&registry.DecodedField{Name: "resource_id", Value: d2["resource_id"]},
-				&registry.DecodedField{Name: "deposit_nonce", Value: d2["deposit_nonce"]},
-				&registry.DecodedField{Name: "sygma_traits_TransferType", Value: d2["sygma_traits_TransferType"]},
-				&registry.DecodedField{Name: "deposit_data", Value: d2["deposit_data"]},
-				&registry.DecodedField{Name: "handler_response", Value: d2["handler_response"]},
-			},
 		},
-	}
-	msgID := fmt.Sprintf("retry-%d-%d-%d-%d", 1, 2, 0, 1)
-	s.mockDepositHandler.EXPECT().HandleDeposit(
-		s.domainID,
-		d1["dest_domain_id"],
-		d1["deposit_nonce"],
-		d1["resource_id"],
-		d1["deposit_data"],
-		d1["sygma_traits_TransferType"],
-		msgID,
-		gomock.Any(),
-	).Return(
-		&message.Message{Data: transfer.TransferMessageData{DepositNonce: 1}},
-		nil,
-	)
-	s.mockDepositHandler.EXPECT().HandleDeposit(
-		s.domainID,
-		d2["dest_domain_id"],
-		d2["deposit_nonce"],
-		d2["resource_id"],
-		d2["deposit_data"],
-		d2["sygma_traits_TransferType"],
-		msgID,
-		gomock.Any(),
-	).Return(
-		&message.Message{Data: transfer.TransferMessageData{DepositNonce: 2}},
-		nil,
-	)
-
-	rtry := map[string]any{
-		"deposit_on_block_height": types.NewU128(*big.NewInt(95)),
-	}
-	evts := []*parser.Event{
 		{
 			Name: "SygmaBridge.Retry",
 			Fields: registry.DecodedFields{
-				&registry.DecodedField{Name: "deposit_on_block_height", Value: rtry["deposit_on_block_height"]},
+				&registry.DecodedField{Name: "deposit_on_block_height", Value: types.NewU128(*big.NewInt(95))},
+				&registry.DecodedField{Name: "dest_domain_id", Value: types.NewU8(2)},
+				&registry.DecodedField{Name: "resource_id", Value: types.Bytes32{1}},
 			},
 		},
 	}
 	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
-	s.mockConn.EXPECT().GetBlockEvents(gomock.Any()).Return(blockEvts, nil)
 
 	err := s.retryHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
 	msgs := <-s.msgChan
 
 	s.Nil(err)
-	s.Equal(len(s.msgChan), 0)
-	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 1}}, {Data: transfer.TransferMessageData{DepositNonce: 2}}})
-}
-
-func (s *RetryHandlerTestSuite) Test_EventPanics() {
-	s.mockConn.EXPECT().GetFinalizedHead().Return(types.Hash{}, nil)
-	s.mockConn.EXPECT().GetBlock(gomock.Any()).Return(&types.SignedBlock{Block: types.Block{
-		Header: types.Header{
-			Number: types.BlockNumber(uint32(100)),
-		},
-	}}, nil)
-	s.mockConn.EXPECT().GetBlockHash(uint64(95)).Return(types.Hash{}, nil)
-	s.mockConn.EXPECT().GetBlockHash(uint64(95)).Return(types.Hash{}, nil)
-	d1 := map[string]any{
-		"dest_domain_id":            types.NewU8(2),
-		"deposit_nonce":             types.NewU64(1),
-		"resource_id":               types.Bytes32{1},
-		"sygma_traits_TransferType": types.NewU8(0),
-		"handler_response":          [1]byte{0},
-		"deposit_data":              []byte{},
-	}
-	d2 := map[string]any{
-		"deposit_nonce":             types.NewU64(2),
-		"dest_domain_id":            types.NewU8(2),
-		"resource_id":               types.Bytes32{1},
-		"sygma_traits_TransferType": types.NewU8(0),
-		"handler_response":          [1]byte{0},
-		"deposit_data":              []byte{},
-	}
-
-	blockEvts1 := []*parser.Event{
-		{
-			Name: "SygmaBridge.Deposit",
-			Fields: registry.DecodedFields{
-				&registry.DecodedField{Name: "dest_domain_id", Value: d1["dest_domain_id"]},
-				&registry.DecodedField{Name: "resource_id", Value: d1["resource_id"]},
-				&registry.DecodedField{Name: "deposit_nonce", Value: d1["deposit_nonce"]},
-				&registry.DecodedField{Name: "sygma_traits_TransferType", Value: d1["sygma_traits_TransferType"]},
-				&registry.DecodedField{Name: "deposit_data", Value: d1["deposit_data"]},
-				&registry.DecodedField{Name: "handler_response", Value: d1["handler_response"]},
-			},
-		},
-	}
-	blockEvts2 := []*parser.Event{
-		{
-			Name: "SygmaBridge.Deposit",
-			Fields: registry.DecodedFields{
-				&registry.DecodedField{Name: "dest_domain_id", Value: d2["dest_domain_id"]},
-				&registry.DecodedField{Name: "resource_id", Value: d2["resource_id"]},
-				&registry.DecodedField{Name: "deposit_nonce", Value: d2["deposit_nonce"]},
-				&registry.DecodedField{Name: "sygma_traits_TransferType", Value: d2["sygma_traits_TransferType"]},
-				&registry.DecodedField{Name: "deposit_data", Value: d2["deposit_data"]},
-				&registry.DecodedField{Name: "handler_response", Value: d2["handler_response"]},
-			},
-		},
-	}
-
-	msgID := fmt.Sprintf("retry-%d-%d-%d-%d", 1, 2, 0, 1)
-	s.mockDepositHandler.EXPECT().HandleDeposit(
-		s.domainID,
-		d1["dest_domain_id"],
-		d1["deposit_nonce"],
-		d1["resource_id"],
-		d1["deposit_data"],
-		d1["sygma_traits_TransferType"],
-		msgID,
-		gomock.Any(),
-	).Do(func(sourceID, destID, nonce, resourceID, calldata, depositType, msgID, timestamp interface{}) {
-		panic("error")
+	s.Equal(len(msgs), 1)
+	s.Equal(msgs[0].Source, s.domainID)
+	s.Equal(msgs[0].Destination, s.domainID)
+	s.Equal(msgs[0].Type, retry.RetryMessageType)
+	s.Equal(msgs[0].Data, retry.RetryMessageData{
+		SourceDomainID:      s.domainID,
+		DestinationDomainID: 2,
+		BlockHeight:         big.NewInt(95),
+		ResourceID:          [32]byte{1},
 	})
-	s.mockDepositHandler.EXPECT().HandleDeposit(
-		s.domainID,
-		d2["dest_domain_id"],
-		d2["deposit_nonce"],
-		d2["resource_id"],
-		d2["deposit_data"],
-		d2["sygma_traits_TransferType"],
-		msgID,
-		gomock.Any(),
-	).Return(
-		&message.Message{Data: transfer.TransferMessageData{DepositNonce: 2}},
-		nil,
-	)
+}
 
-	rtry := map[string]any{
-		"deposit_on_block_height": types.NewU128(*big.NewInt(95)),
-	}
+func (s *RetryHandlerTestSuite) Test_EventWithoutResource() {
 	evts := []*parser.Event{
 		{
 			Name: "SygmaBridge.Retry",
 			Fields: registry.DecodedFields{
-				&registry.DecodedField{Name: "deposit_on_block_height", Value: rtry["deposit_on_block_height"]},
-			},
-		},
-		{
-			Name: "SygmaBridge.Retry",
-			Fields: registry.DecodedFields{
-				&registry.DecodedField{Name: "deposit_on_block_height", Value: rtry["deposit_on_block_height"]},
+				&registry.DecodedField{Name: "deposit_on_block_height", Value: types.NewU128(*big.NewInt(95))},
+				&registry.DecodedField{Name: "dest_domain_id", Value: types.NewU8(2)},
 			},
 		},
 	}
 	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
-	s.mockConn.EXPECT().GetBlockEvents(gomock.Any()).Return(blockEvts1, nil)
-	s.mockConn.EXPECT().GetBlockEvents(gomock.Any()).Return(blockEvts2, nil)
 
 	err := s.retryHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
 	msgs := <-s.msgChan
 
 	s.Nil(err)
-	s.Equal(len(s.msgChan), 0)
-	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2}}})
+	s.Equal(len(msgs), 1)
+	s.Equal(msgs[0].Data, retry.RetryMessageData{
+		SourceDomainID:      s.domainID,
+		DestinationDomainID: 2,
+		BlockHeight:         big.NewInt(95),
+		AllResources:        true,
+	})
 }
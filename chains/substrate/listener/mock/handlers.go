@@ -127,6 +127,43 @@ func (mr *MockConnectionMockRecorder) UpdateMetatdata() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetatdata", reflect.TypeOf((*MockConnection)(nil).UpdateMetatdata))
 }
 
+// MockMetadataUpdater is a mock of MetadataUpdater interface.
+type MockMetadataUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetadataUpdaterMockRecorder
+}
+
+// MockMetadataUpdaterMockRecorder is the mock recorder for MockMetadataUpdater.
+type MockMetadataUpdaterMockRecorder struct {
+	mock *MockMetadataUpdater
+}
+
+// NewMockMetadataUpdater creates a new mock instance.
+func NewMockMetadataUpdater(ctrl *gomock.Controller) *MockMetadataUpdater {
+	mock := &MockMetadataUpdater{ctrl: ctrl}
+	mock.recorder = &MockMetadataUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetadataUpdater) EXPECT() *MockMetadataUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdateMetatdata mocks base method.
+func (m *MockMetadataUpdater) UpdateMetatdata() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetatdata")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMetatdata indicates an expected call of UpdateMetatdata.
+func (mr *MockMetadataUpdaterMockRecorder) UpdateMetatdata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetatdata", reflect.TypeOf((*MockMetadataUpdater)(nil).UpdateMetatdata))
+}
+
 // MockDepositHandler is a mock of DepositHandler interface.
 type MockDepositHandler struct {
 	ctrl     *gomock.Controller
@@ -4,6 +4,8 @@
 package listener
 
 import (
+	"fmt"
+
 	"github.com/ChainSafe/sygma-relayer/chains/substrate/events"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
 	"github.com/mitchellh/mapstructure"
@@ -69,8 +71,16 @@ func DecodeRetryEvent(evtFields registry.DecodedFields) (events.Retry, error) {
 			if err != nil {
 				return events.Retry{}, err
 			}
+		case "resource_id":
+			err := mapstructure.Decode(evtField.Value, &er.ResourceID)
+			if err != nil {
+				return events.Retry{}, err
+			}
 		}
 	}
+	if er.DepositOnBlockHeight.Int == nil {
+		return events.Retry{}, fmt.Errorf("retry event missing deposit block height")
+	}
 
 	return er, nil
 }
@@ -38,6 +38,18 @@ func (s *DecodeEventsSuite) TestDecodeDepositEvent() {
 }
 
 func (s *DecodeEventsSuite) TestDecodeRetryEvent() {
+	evtFields := registry.DecodedFields{
+		&registry.DecodedField{Name: "deposit_on_block_height", Value: types.NewU128(*big.NewInt(1)), LookupIndex: 0},
+		&registry.DecodedField{Name: "dest_domain_id", Value: 2, LookupIndex: 0},
+		&registry.DecodedField{Name: "resource_id", Value: types.Bytes32{3}, LookupIndex: 0},
+	}
+
+	retry, err := listener.DecodeRetryEvent(evtFields)
+	s.Nil(err)
+	s.Equal(retry, events.Retry{DepositOnBlockHeight: types.NewU128(*big.NewInt(1)), DestDomainID: 2, ResourceID: &types.Bytes32{3}})
+}
+
+func (s *DecodeEventsSuite) TestDecodeRetryEvent_WithoutResource() {
 	evtFields := registry.DecodedFields{
 		&registry.DecodedField{Name: "deposit_on_block_height", Value: types.NewU128(*big.NewInt(1)), LookupIndex: 0},
 		&registry.DecodedField{Name: "dest_domain_id", Value: 2, LookupIndex: 0},
@@ -45,5 +57,14 @@ func (s *DecodeEventsSuite) TestDecodeRetryEvent() {
 
 	retry, err := listener.DecodeRetryEvent(evtFields)
 	s.Nil(err)
-	s.Equal(retry, events.Retry{DepositOnBlockHeight: types.NewU128(*big.NewInt(1)), DestDomainID: 2})
+	s.Nil(retry.ResourceID)
+}
+
+func (s *DecodeEventsSuite) TestDecodeRetryEvent_MissingBlockHeight() {
+	evtFields := registry.DecodedFields{
+		&registry.DecodedField{Name: "dest_domain_id", Value: 2, LookupIndex: 0},
+	}
+
+	_, err := listener.DecodeRetryEvent(evtFields)
+	s.NotNil(err)
 }
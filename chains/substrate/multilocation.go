@@ -0,0 +1,386 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	maxJunctions  = 8
+	maxGeneralKey = 32
+)
+
+// XCM versions of the supported MultiLocation encodings
+const (
+	XCMV1 uint8 = 1
+	XCMV3 uint8 = 3
+)
+
+type JunctionType uint8
+
+const (
+	ParachainJunction JunctionType = iota
+	AccountID32Junction
+	AccountIndex64Junction
+	AccountKey20Junction
+	PalletInstanceJunction
+	GeneralIndexJunction
+	GeneralKeyJunction
+	OnlyChildJunction
+	PluralityJunction
+	GlobalConsensusJunction
+)
+
+var junctionNames = map[JunctionType]string{
+	ParachainJunction:       "Parachain",
+	AccountID32Junction:     "AccountId32",
+	AccountIndex64Junction:  "AccountIndex64",
+	AccountKey20Junction:    "AccountKey20",
+	PalletInstanceJunction:  "PalletInstance",
+	GeneralIndexJunction:    "GeneralIndex",
+	GeneralKeyJunction:      "GeneralKey",
+	OnlyChildJunction:       "OnlyChild",
+	PluralityJunction:       "Plurality",
+	GlobalConsensusJunction: "GlobalConsensus",
+}
+
+var networkNames = map[byte]string{
+	2: "Polkadot",
+	3: "Kusama",
+	4: "Westend",
+	5: "Rococo",
+	6: "Wococo",
+	8: "BitcoinCore",
+	9: "BitcoinCash",
+}
+
+// Junction is a single decoded XCM junction
+type Junction struct {
+	Type JunctionType
+	// Network is the optional network of account junctions or the consensus of global consensus junctions
+	Network string
+	// Index is the parachain ID, account index, pallet instance or general index
+	Index *big.Int
+	// Key is the account ID, account key or general key
+	Key []byte
+	// Body is the description of plurality junctions
+	Body string
+}
+
+// IsAccount returns true if the junction identifies an account
+func (j Junction) IsAccount() bool {
+	return j.Type == AccountID32Junction || j.Type == AccountIndex64Junction || j.Type == AccountKey20Junction
+}
+
+func (j Junction) String() string {
+	var args []string
+	if j.Network != "" {
+		args = append(args, j.Network)
+	}
+	if j.Index != nil {
+		args = append(args, j.Index.String())
+	}
+	if j.Key != nil {
+		args = append(args, hexutil.Encode(j.Key))
+	}
+	if j.Body != "" {
+		args = append(args, j.Body)
+	}
+	return fmt.Sprintf("%s(%s)", junctionNames[j.Type], strings.Join(args, ", "))
+}
+
+// MultiLocation is a decoded XCM v1 or v3 MultiLocation
+type MultiLocation struct {
+	// Version is the XCM version of the encoding the location was decoded from
+	Version  uint8
+	Parents  uint8
+	Interior []Junction
+}
+
+func (l *MultiLocation) String() string {
+	if len(l.Interior) == 0 {
+		return fmt.Sprintf("{parents: %d, interior: Here}", l.Parents)
+	}
+
+	junctions := make([]string, len(l.Interior))
+	for i, j := range l.Interior {
+		junctions[i] = j.String()
+	}
+	return fmt.Sprintf("{parents: %d, interior: X%d(%s)}", l.Parents, len(l.Interior), strings.Join(junctions, ", "))
+}
+
+// DecodeMultiLocation decodes SCALE encoded XCM v3 MultiLocation, falling back to
+// XCM v1 encoding, and fails if the encoding is malformed or has trailing bytes
+// in both versions. Encodings that are valid in both versions are decoded as v3.
+func DecodeMultiLocation(data []byte) (*MultiLocation, error) {
+	location, v3Err := decodeMultiLocation(data, XCMV3)
+	if v3Err == nil {
+		return location, nil
+	}
+	location, v1Err := decodeMultiLocation(data, XCMV1)
+	if v1Err == nil {
+		return location, nil
+	}
+	return nil, fmt.Errorf("invalid v3 multilocation: %s, invalid v1 multilocation: %s", v3Err, v1Err)
+}
+
+func decodeMultiLocation(data []byte, version uint8) (*MultiLocation, error) {
+	reader := bytes.NewReader(data)
+	decoder := scale.NewDecoder(reader)
+
+	parents, err := decoder.ReadOneByte()
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding parents: %w", err)
+	}
+	junctions, err := decoder.ReadOneByte()
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding interior: %w", err)
+	}
+	if junctions > maxJunctions {
+		return nil, fmt.Errorf("invalid interior variant %d", junctions)
+	}
+
+	location := &MultiLocation{
+		Version:  version,
+		Parents:  parents,
+		Interior: make([]Junction, junctions),
+	}
+	for i := range location.Interior {
+		junction, err := decodeJunction(decoder, version)
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding junction %d: %w", i, err)
+		}
+		location.Interior[i] = *junction
+	}
+
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("%d trailing bytes after multilocation", reader.Len())
+	}
+	return location, nil
+}
+
+func decodeJunction(decoder *scale.Decoder, version uint8) (*Junction, error) {
+	variant, err := decoder.ReadOneByte()
+	if err != nil {
+		return nil, err
+	}
+
+	junction := &Junction{Type: JunctionType(variant)}
+	switch junction.Type {
+	case ParachainJunction:
+		junction.Index, err = decoder.DecodeUintCompact()
+	case AccountID32Junction:
+		junction.Network, err = decodeAccountNetwork(decoder, version)
+		if err != nil {
+			return nil, err
+		}
+		junction.Key, err = readBytes(decoder, 32)
+	case AccountIndex64Junction:
+		junction.Network, err = decodeAccountNetwork(decoder, version)
+		if err != nil {
+			return nil, err
+		}
+		junction.Index, err = decoder.DecodeUintCompact()
+	case AccountKey20Junction:
+		junction.Network, err = decodeAccountNetwork(decoder, version)
+		if err != nil {
+			return nil, err
+		}
+		junction.Key, err = readBytes(decoder, 20)
+	case PalletInstanceJunction:
+		var instance byte
+		instance, err = decoder.ReadOneByte()
+		junction.Index = big.NewInt(int64(instance))
+	case GeneralIndexJunction:
+		junction.Index, err = decoder.DecodeUintCompact()
+	case GeneralKeyJunction:
+		junction.Key, err = decodeGeneralKey(decoder, version)
+	case OnlyChildJunction:
+	case PluralityJunction:
+		junction.Body, err = decodePlurality(decoder, version)
+	case GlobalConsensusJunction:
+		if version == XCMV1 {
+			return nil, fmt.Errorf("invalid junction variant %d", variant)
+		}
+		junction.Network, err = decodeNetwork(decoder)
+	default:
+		return nil, fmt.Errorf("invalid junction variant %d", variant)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return junction, nil
+}
+
+// decodeAccountNetwork decodes optional v3 network or v1 network of account junctions
+func decodeAccountNetwork(decoder *scale.Decoder, version uint8) (string, error) {
+	if version == XCMV3 {
+		return decodeOptionalNetwork(decoder)
+	}
+
+	variant, err := decoder.ReadOneByte()
+	if err != nil {
+		return "", err
+	}
+	switch variant {
+	case 0:
+		return "", nil
+	case 1:
+		name, err := readVec(decoder, math.MaxInt32)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Named(%s)", hexutil.Encode(name)), nil
+	case 2, 3:
+		return networkNames[variant], nil
+	default:
+		return "", fmt.Errorf("invalid network variant %d", variant)
+	}
+}
+
+// decodeGeneralKey decodes v3 fixed size general key with its length or v1 bounded general key
+func decodeGeneralKey(decoder *scale.Decoder, version uint8) ([]byte, error) {
+	if version == XCMV1 {
+		return readVec(decoder, maxGeneralKey)
+	}
+
+	length, err := decoder.ReadOneByte()
+	if err != nil {
+		return nil, err
+	}
+	if length > maxGeneralKey {
+		return nil, fmt.Errorf("general key length %d exceeds %d bytes", length, maxGeneralKey)
+	}
+	key, err := readBytes(decoder, maxGeneralKey)
+	if err != nil {
+		return nil, err
+	}
+	return key[:length], nil
+}
+
+func decodeOptionalNetwork(decoder *scale.Decoder) (string, error) {
+	isSome, err := decoder.ReadOneByte()
+	if err != nil {
+		return "", err
+	}
+	switch isSome {
+	case 0:
+		return "", nil
+	case 1:
+		return decodeNetwork(decoder)
+	default:
+		return "", fmt.Errorf("invalid network option %d", isSome)
+	}
+}
+
+func decodeNetwork(decoder *scale.Decoder) (string, error) {
+	variant, err := decoder.ReadOneByte()
+	if err != nil {
+		return "", err
+	}
+
+	switch variant {
+	case 0:
+		genesis, err := readBytes(decoder, 32)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("ByGenesis(%s)", hexutil.Encode(genesis)), nil
+	case 1:
+		blockNumber, err := readBytes(decoder, 8)
+		if err != nil {
+			return "", err
+		}
+		blockHash, err := readBytes(decoder, 32)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("ByFork(%d, %s)", binary.LittleEndian.Uint64(blockNumber), hexutil.Encode(blockHash)), nil
+	case 7:
+		chainID, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Ethereum(%s)", chainID), nil
+	}
+
+	name, ok := networkNames[variant]
+	if !ok {
+		return "", fmt.Errorf("invalid network variant %d", variant)
+	}
+	return name, nil
+}
+
+// decodePlurality decodes plurality body ID and body part
+func decodePlurality(decoder *scale.Decoder, version uint8) (string, error) {
+	bodyID, err := decoder.ReadOneByte()
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case bodyID == 1 && version == XCMV1:
+		_, err = readVec(decoder, math.MaxInt32)
+	case bodyID == 1:
+		_, err = readBytes(decoder, 4)
+	case bodyID == 2:
+		_, err = decoder.DecodeUintCompact()
+	case version == XCMV1 && bodyID > 6, bodyID > 9:
+		return "", fmt.Errorf("invalid body ID variant %d", bodyID)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	bodyPart, err := decoder.ReadOneByte()
+	if err != nil {
+		return "", err
+	}
+	fields := 0
+	switch bodyPart {
+	case 0:
+	case 1:
+		fields = 1
+	case 2, 3, 4:
+		fields = 2
+	default:
+		return "", fmt.Errorf("invalid body part variant %d", bodyPart)
+	}
+	for i := 0; i < fields; i++ {
+		_, err = decoder.DecodeUintCompact()
+		if err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("body %d, part %d", bodyID, bodyPart), nil
+}
+
+// readVec reads compact length prefixed bytes of at most maxLength bytes
+func readVec(decoder *scale.Decoder, maxLength int) ([]byte, error) {
+	length, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return nil, err
+	}
+	if !length.IsInt64() || length.Int64() > int64(maxLength) {
+		return nil, fmt.Errorf("length %s exceeds %d bytes", length, maxLength)
+	}
+	return readBytes(decoder, int(length.Int64()))
+}
+
+func readBytes(decoder *scale.Decoder, length int) ([]byte, error) {
+	b := make([]byte, length)
+	err := decoder.Read(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
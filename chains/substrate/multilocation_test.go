@@ -0,0 +1,138 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const accountID = "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+
+type MultiLocationTestSuite struct {
+	suite.Suite
+}
+
+func TestRunMultiLocationTestSuite(t *testing.T) {
+	suite.Run(t, new(MultiLocationTestSuite))
+}
+
+func (s *MultiLocationTestSuite) decode(encoded string) (*MultiLocation, error) {
+	data, err := hex.DecodeString(encoded)
+	s.Nil(err)
+	return DecodeMultiLocation(data)
+}
+
+func (s *MultiLocationTestSuite) Test_LocalAccount() {
+	location, err := s.decode("0001" + "0100" + accountID)
+
+	s.Nil(err)
+	key, _ := hex.DecodeString(accountID)
+	s.Equal(location, &MultiLocation{
+		Version: XCMV3,
+		Parents: 0,
+		Interior: []Junction{
+			{Type: AccountID32Junction, Key: key},
+		},
+	})
+	s.Equal(location.String(), "{parents: 0, interior: X1(AccountId32(0x"+accountID+"))}")
+}
+
+func (s *MultiLocationTestSuite) Test_SiblingParachainAccount() {
+	location, err := s.decode("0102" + "00411f" + "010102" + accountID)
+
+	s.Nil(err)
+	s.Equal(location.Parents, uint8(1))
+	s.Equal(location.Interior[0].Type, ParachainJunction)
+	s.Equal(location.Interior[0].Index, big.NewInt(2000))
+	s.Equal(location.Interior[1].Network, "Polkadot")
+	s.Equal(location.String(), "{parents: 1, interior: X2(Parachain(2000), AccountId32(Polkadot, 0x"+accountID+"))}")
+}
+
+func (s *MultiLocationTestSuite) Test_AccountKey20WithEthereumNetwork() {
+	location, err := s.decode("0001" + "030107" + "04" + "0102030405060708090a0b0c0d0e0f1011121314")
+
+	s.Nil(err)
+	s.Equal(location.Interior[0].Network, "Ethereum(1)")
+	s.Equal(len(location.Interior[0].Key), 20)
+}
+
+func (s *MultiLocationTestSuite) Test_GeneralKey() {
+	location, err := s.decode("0001" + "06" + "02" + "0102" + "000000000000000000000000000000000000000000000000000000000000")
+
+	s.Nil(err)
+	s.Equal(location.Interior[0].Key, []byte{1, 2})
+}
+
+func (s *MultiLocationTestSuite) Test_V1AccountWithNetwork() {
+	location, err := s.decode("0001" + "0102" + accountID)
+
+	s.Nil(err)
+	s.Equal(location.Version, XCMV1)
+	s.Equal(location.Interior[0].Network, "Polkadot")
+	s.Equal(location.String(), "{parents: 0, interior: X1(AccountId32(Polkadot, 0x"+accountID+"))}")
+}
+
+func (s *MultiLocationTestSuite) Test_V1AccountWithNamedNetwork() {
+	location, err := s.decode("0001" + "0101" + "08" + "0102" + accountID)
+
+	s.Nil(err)
+	s.Equal(location.Version, XCMV1)
+	s.Equal(location.Interior[0].Network, "Named(0x0102)")
+}
+
+func (s *MultiLocationTestSuite) Test_V1GeneralKey() {
+	location, err := s.decode("0002" + "06" + "08" + "0102" + "0100" + accountID)
+
+	s.Nil(err)
+	s.Equal(location.Version, XCMV1)
+	s.Equal(location.Interior[0].Key, []byte{1, 2})
+	s.True(location.Interior[1].IsAccount())
+}
+
+func (s *MultiLocationTestSuite) Test_V1GeneralKeyTooLong() {
+	_, err := s.decode("0001" + "06" + "84" + "0000000000000000000000000000000000000000000000000000000000000000" + "00")
+
+	s.NotNil(err)
+}
+
+func (s *MultiLocationTestSuite) Test_Here() {
+	location, err := s.decode("0100")
+
+	s.Nil(err)
+	s.Equal(location.String(), "{parents: 1, interior: Here}")
+}
+
+func (s *MultiLocationTestSuite) Test_TrailingBytes() {
+	_, err := s.decode("0001" + "0100" + accountID + "00")
+
+	s.NotNil(err)
+}
+
+func (s *MultiLocationTestSuite) Test_TruncatedAccount() {
+	_, err := s.decode("0001" + "0100" + accountID[:20])
+
+	s.NotNil(err)
+}
+
+func (s *MultiLocationTestSuite) Test_InvalidJunction() {
+	_, err := s.decode("0001" + "0a")
+
+	s.NotNil(err)
+}
+
+func (s *MultiLocationTestSuite) Test_InvalidInterior() {
+	_, err := s.decode("0009")
+
+	s.NotNil(err)
+}
+
+func (s *MultiLocationTestSuite) Test_InvalidGeneralKeyLength() {
+	_, err := s.decode("0001" + "06" + "21" + "0000000000000000000000000000000000000000000000000000000000000000")
+
+	s.NotNil(err)
+}
@@ -4,15 +4,20 @@
 package pallet
 
 import (
+	"context"
+	"fmt"
+	"math/big"
 	"strconv"
 
 	"github.com/ChainSafe/sygma-relayer/chains"
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/client"
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/events"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
-	"github.com/sygmaprotocol/sygma-core/chains/substrate/client"
+	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
 
-	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/rs/zerolog/log"
 )
@@ -28,21 +33,27 @@ type BridgeProposal struct {
 }
 
 type Pallet struct {
-	*client.SubstrateClient
+	conn      *connection.Connection
+	submitter *client.Submitter
+	chainID   *big.Int
 }
 
 func NewPallet(
-	client *client.SubstrateClient,
+	conn *connection.Connection,
+	submitter *client.Submitter,
+	chainID *big.Int,
 ) *Pallet {
 	return &Pallet{
-		client,
+		conn:      conn,
+		submitter: submitter,
+		chainID:   chainID,
 	}
 }
 
 func (p *Pallet) ExecuteProposals(
 	proposals []*transfer.TransferProposal,
 	signature []byte,
-) (types.Hash, *author.ExtrinsicStatusSubscription, error) {
+) (types.Hash, client.StatusSubscription, error) {
 	bridgeProposals := make([]BridgeProposal, 0)
 	for _, prop := range proposals {
 		bridgeProposals = append(bridgeProposals, BridgeProposal{
@@ -53,7 +64,7 @@ func (p *Pallet) ExecuteProposals(
 		})
 	}
 
-	return p.Transact(
+	return p.submitter.Transact(
 		"SygmaBridge.execute_proposal",
 		bridgeProposals,
 		signature,
@@ -61,7 +72,7 @@ func (p *Pallet) ExecuteProposals(
 }
 
 func (p *Pallet) ProposalsHash(proposals []*transfer.TransferProposal) ([]byte, error) {
-	return chains.ProposalsHash(proposals, p.ChainID.Int64(), verifyingContract, bridgeVersion)
+	return chains.ProposalsHash(proposals, p.chainID.Int64(), verifyingContract, bridgeVersion)
 }
 
 func (p *Pallet) IsProposalExecuted(prop *transfer.TransferProposal) (bool, error) {
@@ -71,10 +82,90 @@ func (p *Pallet) IsProposalExecuted(prop *transfer.TransferProposal) (bool, erro
 		Str("resourceID", hexutil.Encode(prop.Data.ResourceId[:])).
 		Msg("Getting is proposal executed")
 	var res bool
-	err := p.Conn.Call(&res, "sygma_isProposalExecuted", prop.Data.DepositNonce, prop.Source)
+	err := p.conn.Call(&res, "sygma_isProposalExecuted", prop.Data.DepositNonce, prop.Source)
 	if err != nil {
 		return false, err
 	}
 
 	return res, nil
 }
+
+// TrackExtrinsic waits for the extrinsic executing proposals to be finalized until the context is done
+// and decodes the extrinsic outcome from the events of the finalized block. Returns client.ErrAlreadyIncluded
+// if the proposals were executed before the extrinsic had to be resubmitted with a new nonce.
+func (p *Pallet) TrackExtrinsic(
+	ctx context.Context,
+	extHash types.Hash,
+	sub client.StatusSubscription,
+	proposals []*transfer.TransferProposal,
+) (*events.ExtrinsicOutcome, error) {
+	extHash, blockHash, err := p.submitter.Watch(ctx, extHash, sub, func() (bool, error) {
+		for _, prop := range proposals {
+			isExecuted, err := p.IsProposalExecuted(prop)
+			if err != nil || !isExecuted {
+				return false, err
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.extrinsicOutcome(extHash, blockHash)
+}
+
+func (p *Pallet) extrinsicOutcome(extHash types.Hash, blockHash types.Hash) (*events.ExtrinsicOutcome, error) {
+	block, err := p.conn.Chain.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	extrinsicIndex := -1
+	for i, ext := range block.Block.Extrinsics {
+		hash, err := client.ExtrinsicHash(ext)
+		if err != nil {
+			return nil, err
+		}
+		if hash == extHash {
+			extrinsicIndex = i
+			break
+		}
+	}
+	if extrinsicIndex == -1 {
+		return nil, fmt.Errorf("extrinsic %s not found in block %s", extHash.Hex(), blockHash.Hex())
+	}
+
+	evts, err := p.conn.GetBlockEvents(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := p.conn.GetMetadata()
+	return events.DecodeExtrinsicOutcome(&meta, evts, uint32(extrinsicIndex))
+}
+
+// MPCAddress returns the MPC address the bridge pallet verifies proposal signatures with,
+// the zero address is returned if the address is not set
+func (p *Pallet) MPCAddress() (common.Address, error) {
+	meta := p.conn.GetMetadata()
+	key, err := types.CreateStorageKey(&meta, "SygmaBridge", "MpcAddr")
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var address [20]byte
+	_, err = p.conn.State.GetStorageLatest(key, &address)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(address[:]), nil
+}
+
+func (p *Pallet) LatestBlock() (*big.Int, error) {
+	block, err := p.conn.Chain.GetBlockLatest()
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(int64(block.Block.Header.Number)), nil
+}
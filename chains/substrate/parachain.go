@@ -0,0 +1,90 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// ParachainTopology describes XCM destinations reachable from a substrate domain.
+// Domains without a parachain ID are relay or solo chains whose configured parachains
+// are child parachains, while parachain domains can reach the relay chain and configured
+// sibling parachains. Recipients are expected to be SCALE encoded XCM v3 or v1 MultiLocations.
+type ParachainTopology struct {
+	parachainID *uint32
+	parachains  map[uint32]bool
+}
+
+// NewParachainTopology returns nil if neither the parachain ID nor reachable parachains
+// are configured, in which case recipients are not validated
+func NewParachainTopology(parachainID *uint32, parachains []uint32) *ParachainTopology {
+	if parachainID == nil && len(parachains) == 0 {
+		return nil
+	}
+
+	reachable := make(map[uint32]bool)
+	for _, id := range parachains {
+		reachable[id] = true
+	}
+
+	return &ParachainTopology{
+		parachainID: parachainID,
+		parachains:  reachable,
+	}
+}
+
+// ValidateDestination returns an error if the location can not be routed to from the domain
+func (t *ParachainTopology) ValidateDestination(location *MultiLocation) error {
+	if len(location.Interior) == 0 {
+		return fmt.Errorf("location %s has no interior", location)
+	}
+
+	first := location.Interior[0]
+	if first.Type == GlobalConsensusJunction {
+		return fmt.Errorf("bridged consensus %s not reachable", first.Network)
+	}
+	switch location.Parents {
+	case 0:
+		if first.Type == ParachainJunction && t.parachainID != nil {
+			return fmt.Errorf("parachain domain has no child parachains")
+		}
+		if first.Type == ParachainJunction && !t.isReachable(first.Index) {
+			return fmt.Errorf("child parachain %s not reachable", first.Index)
+		}
+	case 1:
+		if t.parachainID == nil {
+			return fmt.Errorf("domain is not a parachain and has no relay chain")
+		}
+		if first.Type == ParachainJunction && !t.isReachable(first.Index) {
+			return fmt.Errorf("sibling parachain %s not reachable", first.Index)
+		}
+	default:
+		return fmt.Errorf("location with %d parents not reachable", location.Parents)
+	}
+	return nil
+}
+
+// ValidateRecipient returns an error if the location is not reachable
+// or does not end with an account
+func (t *ParachainTopology) ValidateRecipient(location *MultiLocation) error {
+	err := t.ValidateDestination(location)
+	if err != nil {
+		return err
+	}
+
+	last := location.Interior[len(location.Interior)-1]
+	if !last.IsAccount() {
+		return fmt.Errorf("location %s does not end with an account", location)
+	}
+	return nil
+}
+
+func (t *ParachainTopology) isReachable(parachainID *big.Int) bool {
+	if !parachainID.IsUint64() || parachainID.Uint64() > math.MaxUint32 {
+		return false
+	}
+	return t.parachains[uint32(parachainID.Uint64())]
+}
@@ -0,0 +1,81 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ParachainTopologyTestSuite struct {
+	suite.Suite
+
+	parachainTopology *ParachainTopology
+	relayTopology     *ParachainTopology
+}
+
+func TestRunParachainTopologyTestSuite(t *testing.T) {
+	suite.Run(t, new(ParachainTopologyTestSuite))
+}
+
+func (s *ParachainTopologyTestSuite) SetupTest() {
+	parachainID := uint32(2004)
+	s.parachainTopology = NewParachainTopology(&parachainID, []uint32{2000})
+	s.relayTopology = NewParachainTopology(nil, []uint32{2000})
+}
+
+func account() Junction {
+	return Junction{Type: AccountID32Junction, Key: make([]byte, 32)}
+}
+
+func parachain(id int64) Junction {
+	return Junction{Type: ParachainJunction, Index: big.NewInt(id)}
+}
+
+func (s *ParachainTopologyTestSuite) Test_LocalAccount() {
+	location := &MultiLocation{Parents: 0, Interior: []Junction{account()}}
+
+	s.Nil(s.parachainTopology.ValidateRecipient(location))
+	s.Nil(s.relayTopology.ValidateRecipient(location))
+}
+
+func (s *ParachainTopologyTestSuite) Test_RelayChainAccount() {
+	location := &MultiLocation{Parents: 1, Interior: []Junction{account()}}
+
+	s.Nil(s.parachainTopology.ValidateRecipient(location))
+	s.NotNil(s.relayTopology.ValidateRecipient(location))
+}
+
+func (s *ParachainTopologyTestSuite) Test_SiblingParachain() {
+	s.Nil(s.parachainTopology.ValidateRecipient(&MultiLocation{Parents: 1, Interior: []Junction{parachain(2000), account()}}))
+	s.NotNil(s.parachainTopology.ValidateRecipient(&MultiLocation{Parents: 1, Interior: []Junction{parachain(2001), account()}}))
+	s.NotNil(s.parachainTopology.ValidateRecipient(&MultiLocation{Parents: 1, Interior: []Junction{parachain(1<<32 + 2000), account()}}))
+}
+
+func (s *ParachainTopologyTestSuite) Test_ChildParachain() {
+	location := &MultiLocation{Parents: 0, Interior: []Junction{parachain(2000), account()}}
+
+	s.Nil(s.relayTopology.ValidateRecipient(location))
+	s.NotNil(s.parachainTopology.ValidateRecipient(location))
+}
+
+func (s *ParachainTopologyTestSuite) Test_RecipientWithoutAccount() {
+	location := &MultiLocation{Parents: 1, Interior: []Junction{parachain(2000)}}
+
+	s.Nil(s.parachainTopology.ValidateDestination(location))
+	s.NotNil(s.parachainTopology.ValidateRecipient(location))
+}
+
+func (s *ParachainTopologyTestSuite) Test_Unreachable() {
+	s.NotNil(s.parachainTopology.ValidateDestination(&MultiLocation{Parents: 1}))
+	s.NotNil(s.parachainTopology.ValidateDestination(&MultiLocation{Parents: 2, Interior: []Junction{account()}}))
+	s.NotNil(s.parachainTopology.ValidateDestination(&MultiLocation{Parents: 1, Interior: []Junction{{Type: GlobalConsensusJunction, Network: "Kusama"}}}))
+}
+
+func (s *ParachainTopologyTestSuite) Test_NotConfigured() {
+	s.Nil(NewParachainTopology(nil, nil))
+	s.Nil(NewParachainTopology(nil, []uint32{}))
+}
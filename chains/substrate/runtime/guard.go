@@ -0,0 +1,107 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/rs/zerolog/log"
+)
+
+var ErrBridgeSignatureChanged = errors.New("bridge pallet signature changed")
+
+type MetadataConnection interface {
+	GetMetadata() types.Metadata
+	UpdateMetatdata() error
+}
+
+type RuntimeMetrics interface {
+	TrackRuntimeStatus(domainID uint8, halted bool)
+}
+
+// RuntimeGuard refreshes metadata on runtime upgrades and verifies that bridge pallet calls
+// and events still match the shape the relayer was started with. Proposal execution is paused
+// while the metadata is refreshed and refused if the bridge pallet changed.
+type RuntimeGuard struct {
+	domainID  uint8
+	conn      MetadataConnection
+	metrics   RuntimeMetrics
+	signature BridgeSignature
+
+	lock sync.RWMutex
+	err  error
+}
+
+func NewRuntimeGuard(domainID uint8, conn MetadataConnection, metrics RuntimeMetrics) (*RuntimeGuard, error) {
+	meta := conn.GetMetadata()
+	signature, err := NewBridgeSignature(&meta)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.TrackRuntimeStatus(domainID, false)
+	return &RuntimeGuard{
+		domainID:  domainID,
+		conn:      conn,
+		metrics:   metrics,
+		signature: signature,
+	}, nil
+}
+
+// UpdateMetatdata fetches latest metadata and re-checks bridge pallet signature.
+// Calls to Check block until the update is finished.
+func (g *RuntimeGuard) UpdateMetatdata() error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	err := g.conn.UpdateMetatdata()
+	if err != nil {
+		return err
+	}
+
+	meta := g.conn.GetMetadata()
+	signature, err := NewBridgeSignature(&meta)
+	if err != nil {
+		g.halt(fmt.Errorf("%w: %s", ErrBridgeSignatureChanged, err))
+		return nil
+	}
+
+	diff := g.signature.Diff(signature)
+	if len(diff) > 0 {
+		g.halt(fmt.Errorf("%w: %s", ErrBridgeSignatureChanged, strings.Join(diff, "; ")))
+		return nil
+	}
+
+	g.err = nil
+	g.metrics.TrackRuntimeStatus(g.domainID, false)
+	log.Info().Uint8("domainID", g.domainID).Msgf("Verified %s pallet signature after runtime upgrade", BridgePallet)
+	return nil
+}
+
+func (g *RuntimeGuard) halt(err error) {
+	g.err = err
+	g.metrics.TrackRuntimeStatus(g.domainID, true)
+	log.Error().Uint8("domainID", g.domainID).Err(err).Msgf("Halting proposal execution after runtime upgrade")
+}
+
+// Check returns an error if proposals should not be submitted to the chain
+func (g *RuntimeGuard) Check() error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return g.err
+}
+
+// Health returns an error if proposal execution on the domain is halted
+func (g *RuntimeGuard) Health() error {
+	err := g.Check()
+	if err != nil {
+		return fmt.Errorf("domain %d: %w", g.domainID, err)
+	}
+	return nil
+}
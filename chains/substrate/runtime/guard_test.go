@@ -0,0 +1,229 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/runtime"
+	mock_runtime "github.com/ChainSafe/sygma-relayer/chains/substrate/runtime/mock"
+)
+
+const (
+	u8Type = iota + 1
+	u64Type
+	u128Type
+	bytesType
+	resourceIDType
+	signatureType
+	proposalType
+	proposalsType
+	callType
+	eventType
+)
+
+func field(name string, typeName string, typeID uint64) types.Si1Field {
+	return types.Si1Field{
+		HasName:     true,
+		Name:        types.Text(name),
+		Type:        types.NewSi1LookupTypeIDFromUInt(typeID),
+		HasTypeName: true,
+		TypeName:    types.Text(typeName),
+	}
+}
+
+func primitive(p types.Si0TypeDefPrimitive) *types.Si1Type {
+	return &types.Si1Type{Def: types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: p}}}
+}
+
+func sequence(typeID uint64) *types.Si1Type {
+	return &types.Si1Type{Def: types.Si1TypeDef{IsSequence: true, Sequence: types.Si1TypeDefSequence{Type: types.NewSi1LookupTypeIDFromUInt(typeID)}}}
+}
+
+func array(typeID uint64, len uint32) *types.Si1Type {
+	return &types.Si1Type{Def: types.Si1TypeDef{IsArray: true, Array: types.Si1TypeDefArray{Type: types.NewSi1LookupTypeIDFromUInt(typeID), Len: types.U32(len)}}}
+}
+
+func variants(variants ...types.Si1Variant) *types.Si1Type {
+	return &types.Si1Type{Def: types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: variants}}}
+}
+
+// bridgeMetadata returns metadata with bridge pallet calls and events resolved through the type registry
+func bridgeMetadata() types.Metadata {
+	return types.Metadata{
+		Version: 14,
+		AsMetadataV14: types.MetadataV14{
+			Pallets: []types.PalletMetadataV14{
+				{
+					Name:      runtime.BridgePallet,
+					HasCalls:  true,
+					Calls:     types.FunctionMetadataV14{Type: types.NewSi1LookupTypeIDFromUInt(callType)},
+					HasEvents: true,
+					Events:    types.EventMetadataV14{Type: types.NewSi1LookupTypeIDFromUInt(eventType)},
+				},
+			},
+			EfficientLookup: map[int64]*types.Si1Type{
+				u8Type:         primitive(types.IsU8),
+				u64Type:        primitive(types.IsU64),
+				u128Type:       primitive(types.IsU128),
+				bytesType:      sequence(u8Type),
+				resourceIDType: array(u8Type, 32),
+				signatureType:  array(u8Type, 65),
+				proposalType: {
+					Def: types.Si1TypeDef{
+						IsComposite: true,
+						Composite: types.Si1TypeDefComposite{Fields: []types.Si1Field{
+							field("origin_domain_id", "DomainID", u8Type),
+							field("deposit_nonce", "DepositNonce", u64Type),
+							field("resource_id", "ResourceId", resourceIDType),
+							field("data", "Vec<u8>", bytesType),
+						}},
+					},
+				},
+				proposalsType: sequence(proposalType),
+				callType: variants(
+					types.Si1Variant{Name: "execute_proposal", Fields: []types.Si1Field{
+						field("proposals", "Vec<Proposal>", proposalsType),
+						field("signature", "Vec<u8>", bytesType),
+					}},
+				),
+				eventType: variants(
+					types.Si1Variant{Name: "Deposit", Fields: []types.Si1Field{field("dest_domain_id", "DomainID", u8Type), field("deposit_data", "Vec<u8>", bytesType)}},
+					types.Si1Variant{Name: "Retry", Index: 1, Fields: []types.Si1Field{field("deposit_on_block_height", "u128", u128Type)}},
+					types.Si1Variant{Name: "FailedHandlerExecution", Index: 2, Fields: []types.Si1Field{field("error", "Vec<u8>", bytesType)}},
+				),
+			},
+		},
+	}
+}
+
+func executeProposal(meta types.Metadata) *types.Si1Variant {
+	return &meta.AsMetadataV14.EfficientLookup[callType].Def.Variant.Variants[0]
+}
+
+type RuntimeGuardTestSuite struct {
+	suite.Suite
+	mockConn    *mock_runtime.MockMetadataConnection
+	mockMetrics *mock_runtime.MockRuntimeMetrics
+	guard       *runtime.RuntimeGuard
+}
+
+func TestRunRuntimeGuardTestSuite(t *testing.T) {
+	suite.Run(t, new(RuntimeGuardTestSuite))
+}
+
+func (s *RuntimeGuardTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockConn = mock_runtime.NewMockMetadataConnection(ctrl)
+	s.mockMetrics = mock_runtime.NewMockRuntimeMetrics(ctrl)
+	s.mockConn.EXPECT().GetMetadata().Return(bridgeMetadata())
+	s.mockMetrics.EXPECT().TrackRuntimeStatus(uint8(1), false)
+
+	guard, err := runtime.NewRuntimeGuard(1, s.mockConn, s.mockMetrics)
+	s.Nil(err)
+	s.guard = guard
+}
+
+func (s *RuntimeGuardTestSuite) Test_NewRuntimeGuard_MissingPallet() {
+	s.mockConn.EXPECT().GetMetadata().Return(types.Metadata{Version: 14})
+
+	_, err := runtime.NewRuntimeGuard(1, s.mockConn, s.mockMetrics)
+
+	s.NotNil(err)
+}
+
+func (s *RuntimeGuardTestSuite) Test_UpdateMetadata_FetchFails() {
+	s.mockConn.EXPECT().UpdateMetatdata().Return(errors.New("error"))
+
+	err := s.guard.UpdateMetatdata()
+
+	s.NotNil(err)
+	s.Nil(s.guard.Check())
+}
+
+func (s *RuntimeGuardTestSuite) Test_UpdateMetadata_SignatureUnchanged() {
+	s.mockConn.EXPECT().UpdateMetatdata().Return(nil)
+	s.mockConn.EXPECT().GetMetadata().Return(bridgeMetadata())
+	s.mockMetrics.EXPECT().TrackRuntimeStatus(uint8(1), false)
+
+	err := s.guard.UpdateMetatdata()
+
+	s.Nil(err)
+	s.Nil(s.guard.Check())
+	s.Nil(s.guard.Health())
+}
+
+func (s *RuntimeGuardTestSuite) Test_UpdateMetadata_TypeRenamed() {
+	s.mockConn.EXPECT().UpdateMetatdata().Return(nil)
+	meta := bridgeMetadata()
+	executeProposal(meta).Fields[1].TypeName = "BoundedVec<u8>"
+	s.mockConn.EXPECT().GetMetadata().Return(meta)
+	s.mockMetrics.EXPECT().TrackRuntimeStatus(uint8(1), false)
+
+	err := s.guard.UpdateMetatdata()
+
+	s.Nil(err)
+	s.Nil(s.guard.Check())
+}
+
+func (s *RuntimeGuardTestSuite) Test_UpdateMetadata_ExecuteProposalChanged() {
+	s.mockConn.EXPECT().UpdateMetatdata().Return(nil)
+	meta := bridgeMetadata()
+	executeProposal(meta).Fields[1].Type = types.NewSi1LookupTypeIDFromUInt(signatureType)
+	s.mockConn.EXPECT().GetMetadata().Return(meta)
+	s.mockMetrics.EXPECT().TrackRuntimeStatus(uint8(1), true)
+
+	err := s.guard.UpdateMetatdata()
+
+	s.Nil(err)
+	s.True(errors.Is(s.guard.Check(), runtime.ErrBridgeSignatureChanged))
+	s.True(errors.Is(s.guard.Health(), runtime.ErrBridgeSignatureChanged))
+}
+
+func (s *RuntimeGuardTestSuite) Test_UpdateMetadata_NestedProposalChanged() {
+	s.mockConn.EXPECT().UpdateMetatdata().Return(nil)
+	meta := bridgeMetadata()
+	meta.AsMetadataV14.EfficientLookup[proposalType].Def.Composite.Fields[1].Type = types.NewSi1LookupTypeIDFromUInt(u128Type)
+	s.mockConn.EXPECT().GetMetadata().Return(meta)
+	s.mockMetrics.EXPECT().TrackRuntimeStatus(uint8(1), true)
+
+	err := s.guard.UpdateMetatdata()
+
+	s.Nil(err)
+	s.True(errors.Is(s.guard.Check(), runtime.ErrBridgeSignatureChanged))
+}
+
+func (s *RuntimeGuardTestSuite) Test_UpdateMetadata_ExecuteProposalRemoved() {
+	s.mockConn.EXPECT().UpdateMetatdata().Return(nil)
+	meta := bridgeMetadata()
+	executeProposal(meta).Name = "execute"
+	s.mockConn.EXPECT().GetMetadata().Return(meta)
+	s.mockMetrics.EXPECT().TrackRuntimeStatus(uint8(1), true)
+
+	err := s.guard.UpdateMetatdata()
+
+	s.Nil(err)
+	s.True(errors.Is(s.guard.Check(), runtime.ErrBridgeSignatureChanged))
+}
+
+func (s *RuntimeGuardTestSuite) Test_UpdateMetadata_FixedAfterSecondUpgrade() {
+	s.mockConn.EXPECT().UpdateMetatdata().Return(nil).Times(2)
+	meta := bridgeMetadata()
+	executeProposal(meta).Fields[1].Type = types.NewSi1LookupTypeIDFromUInt(signatureType)
+	s.mockConn.EXPECT().GetMetadata().Return(meta)
+	s.mockMetrics.EXPECT().TrackRuntimeStatus(uint8(1), true)
+	_ = s.guard.UpdateMetatdata()
+	s.NotNil(s.guard.Check())
+
+	s.mockConn.EXPECT().GetMetadata().Return(bridgeMetadata())
+	s.mockMetrics.EXPECT().TrackRuntimeStatus(uint8(1), false)
+	_ = s.guard.UpdateMetatdata()
+
+	s.Nil(s.guard.Check())
+}
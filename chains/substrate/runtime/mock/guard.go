@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/substrate/runtime/guard.go
+
+// Package mock_runtime is a generated GoMock package.
+package mock_runtime
+
+import (
+	reflect "reflect"
+
+	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetadataConnection is a mock of MetadataConnection interface.
+type MockMetadataConnection struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetadataConnectionMockRecorder
+}
+
+// MockMetadataConnectionMockRecorder is the mock recorder for MockMetadataConnection.
+type MockMetadataConnectionMockRecorder struct {
+	mock *MockMetadataConnection
+}
+
+// NewMockMetadataConnection creates a new mock instance.
+func NewMockMetadataConnection(ctrl *gomock.Controller) *MockMetadataConnection {
+	mock := &MockMetadataConnection{ctrl: ctrl}
+	mock.recorder = &MockMetadataConnectionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetadataConnection) EXPECT() *MockMetadataConnectionMockRecorder {
+	return m.recorder
+}
+
+// GetMetadata mocks base method.
+func (m *MockMetadataConnection) GetMetadata() types.Metadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadata")
+	ret0, _ := ret[0].(types.Metadata)
+	return ret0
+}
+
+// GetMetadata indicates an expected call of GetMetadata.
+func (mr *MockMetadataConnectionMockRecorder) GetMetadata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockMetadataConnection)(nil).GetMetadata))
+}
+
+// UpdateMetatdata mocks base method.
+func (m *MockMetadataConnection) UpdateMetatdata() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetatdata")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMetatdata indicates an expected call of UpdateMetatdata.
+func (mr *MockMetadataConnectionMockRecorder) UpdateMetatdata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetatdata", reflect.TypeOf((*MockMetadataConnection)(nil).UpdateMetatdata))
+}
+
+// MockRuntimeMetrics is a mock of RuntimeMetrics interface.
+type MockRuntimeMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuntimeMetricsMockRecorder
+}
+
+// MockRuntimeMetricsMockRecorder is the mock recorder for MockRuntimeMetrics.
+type MockRuntimeMetricsMockRecorder struct {
+	mock *MockRuntimeMetrics
+}
+
+// NewMockRuntimeMetrics creates a new mock instance.
+func NewMockRuntimeMetrics(ctrl *gomock.Controller) *MockRuntimeMetrics {
+	mock := &MockRuntimeMetrics{ctrl: ctrl}
+	mock.recorder = &MockRuntimeMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRuntimeMetrics) EXPECT() *MockRuntimeMetricsMockRecorder {
+	return m.recorder
+}
+
+// TrackRuntimeStatus mocks base method.
+func (m *MockRuntimeMetrics) TrackRuntimeStatus(domainID uint8, halted bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackRuntimeStatus", domainID, halted)
+}
+
+// TrackRuntimeStatus indicates an expected call of TrackRuntimeStatus.
+func (mr *MockRuntimeMetricsMockRecorder) TrackRuntimeStatus(domainID, halted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackRuntimeStatus", reflect.TypeOf((*MockRuntimeMetrics)(nil).TrackRuntimeStatus), domainID, halted)
+}
@@ -0,0 +1,206 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const BridgePallet = "SygmaBridge"
+
+var (
+	// BridgeCalls are bridge pallet calls submitted by the relayer
+	BridgeCalls = []string{"execute_proposal"}
+	// BridgeEvents are bridge pallet events decoded by the relayer
+	BridgeEvents = []string{"Deposit", "Retry", "FailedHandlerExecution"}
+)
+
+// BridgeSignature describes the shape of bridge pallet calls and events the relayer depends on.
+// Keys are call or event names and values are ordered lists of field names and field type structures
+// resolved through the metadata type registry, so changes of nested types are detected even if
+// the type name of the field stays the same.
+type BridgeSignature map[string][]string
+
+// NewBridgeSignature reads signatures of bridge pallet calls and events from chain metadata
+func NewBridgeSignature(meta *types.Metadata) (BridgeSignature, error) {
+	if meta.Version != 14 {
+		return nil, fmt.Errorf("unsupported metadata version %d", meta.Version)
+	}
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) != BridgePallet {
+			continue
+		}
+
+		signature := make(BridgeSignature)
+		if !pallet.HasCalls || !pallet.HasEvents {
+			return nil, fmt.Errorf("%s pallet missing calls or events", BridgePallet)
+		}
+		err := signature.add(meta, "call", pallet.Calls.Type, BridgeCalls)
+		if err != nil {
+			return nil, err
+		}
+		err = signature.add(meta, "event", pallet.Events.Type, BridgeEvents)
+		if err != nil {
+			return nil, err
+		}
+		return signature, nil
+	}
+	return nil, fmt.Errorf("%s pallet not found in metadata", BridgePallet)
+}
+
+func (s BridgeSignature) add(meta *types.Metadata, kind string, typeID types.Si1LookupTypeID, names []string) error {
+	typ, ok := meta.AsMetadataV14.EfficientLookup[typeID.Int64()]
+	if !ok || !typ.Def.IsVariant {
+		return fmt.Errorf("%s %s type not found", BridgePallet, kind)
+	}
+
+	for _, name := range names {
+		found := false
+		for _, variant := range typ.Def.Variant.Variants {
+			if string(variant.Name) != name {
+				continue
+			}
+
+			fields := make([]string, len(variant.Fields))
+			for i, field := range variant.Fields {
+				structure, err := describeType(meta, field.Type, make(map[int64]bool))
+				if err != nil {
+					return fmt.Errorf("%s %s.%s field %s: %w", kind, BridgePallet, name, field.Name, err)
+				}
+				fields[i] = fmt.Sprintf("%s:%s", field.Name, structure)
+			}
+			s[fmt.Sprintf("%s:%s", kind, name)] = fields
+			found = true
+			break
+		}
+		if !found {
+			return fmt.Errorf("%s %s.%s not found in metadata", kind, BridgePallet, name)
+		}
+	}
+	return nil
+}
+
+// describeType returns canonical description of the type structure. Type names are left out
+// as renaming a type does not change its encoding.
+func describeType(meta *types.Metadata, typeID types.Si1LookupTypeID, visiting map[int64]bool) (string, error) {
+	id := typeID.Int64()
+	typ, ok := meta.AsMetadataV14.EfficientLookup[id]
+	if !ok {
+		return "", fmt.Errorf("type %d not found", id)
+	}
+	if visiting[id] {
+		// recursive types are described by their path on recursion
+		return fmt.Sprintf("rec(%s)", strings.Join(pathNames(typ.Path), "::")), nil
+	}
+	visiting[id] = true
+	defer delete(visiting, id)
+
+	describeFields := func(fields []types.Si1Field) (string, error) {
+		descriptions := make([]string, len(fields))
+		for i, field := range fields {
+			structure, err := describeType(meta, field.Type, visiting)
+			if err != nil {
+				return "", err
+			}
+			descriptions[i] = structure
+			if field.HasName {
+				descriptions[i] = fmt.Sprintf("%s:%s", field.Name, structure)
+			}
+		}
+		return strings.Join(descriptions, ","), nil
+	}
+
+	def := typ.Def
+	switch {
+	case def.IsComposite:
+		fields, err := describeFields(def.Composite.Fields)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("{%s}", fields), nil
+	case def.IsVariant:
+		variants := make([]string, len(def.Variant.Variants))
+		for i, variant := range def.Variant.Variants {
+			fields, err := describeFields(variant.Fields)
+			if err != nil {
+				return "", err
+			}
+			variants[i] = fmt.Sprintf("%d:%s(%s)", variant.Index, variant.Name, fields)
+		}
+		return fmt.Sprintf("enum{%s}", strings.Join(variants, "|")), nil
+	case def.IsSequence:
+		element, err := describeType(meta, def.Sequence.Type, visiting)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("[%s]", element), nil
+	case def.IsArray:
+		element, err := describeType(meta, def.Array.Type, visiting)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("[%s;%d]", element, def.Array.Len), nil
+	case def.IsTuple:
+		elements := make([]string, len(def.Tuple))
+		for i, elementID := range def.Tuple {
+			element, err := describeType(meta, elementID, visiting)
+			if err != nil {
+				return "", err
+			}
+			elements[i] = element
+		}
+		return fmt.Sprintf("(%s)", strings.Join(elements, ",")), nil
+	case def.IsPrimitive:
+		return fmt.Sprintf("primitive(%d)", def.Primitive.Si0TypeDefPrimitive), nil
+	case def.IsCompact:
+		element, err := describeType(meta, def.Compact.Type, visiting)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("compact<%s>", element), nil
+	case def.IsBitSequence:
+		store, err := describeType(meta, def.BitSequence.BitStoreType, visiting)
+		if err != nil {
+			return "", err
+		}
+		order, err := describeType(meta, def.BitSequence.BitOrderType, visiting)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("bits<%s,%s>", store, order), nil
+	case def.IsHistoricMetaCompat:
+		return string(def.HistoricMetaCompat), nil
+	}
+	return "", fmt.Errorf("type %d has unknown definition", id)
+}
+
+func pathNames(path types.Si1Path) []string {
+	names := make([]string, len(path))
+	for i, name := range path {
+		names[i] = string(name)
+	}
+	return names
+}
+
+// Diff returns descriptions of calls and events that changed shape compared to the other signature
+func (s BridgeSignature) Diff(other BridgeSignature) []string {
+	diff := make([]string, 0)
+	for name, fields := range s {
+		otherFields, ok := other[name]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("%s removed", name))
+			continue
+		}
+		if strings.Join(fields, ",") != strings.Join(otherFields, ",") {
+			diff = append(diff, fmt.Sprintf("%s changed from (%s) to (%s)", name, strings.Join(fields, ", "), strings.Join(otherFields, ", ")))
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
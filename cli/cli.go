@@ -9,7 +9,10 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/ChainSafe/sygma-relayer/cli/keygen"
+	"github.com/ChainSafe/sygma-relayer/cli/keyshare"
 	"github.com/ChainSafe/sygma-relayer/cli/peer"
+	"github.com/ChainSafe/sygma-relayer/cli/proposal"
+	"github.com/ChainSafe/sygma-relayer/cli/quarantine"
 	"github.com/ChainSafe/sygma-relayer/cli/topology"
 	"github.com/ChainSafe/sygma-relayer/cli/utils"
 	"github.com/ChainSafe/sygma-relayer/config"
@@ -31,7 +34,7 @@ func init() {
 }
 
 func Execute() {
-	rootCMD.AddCommand(runCMD, peer.PeerCLI, topology.TopologyCLI, utils.UtilsCLI, keygen.KeygenCLI)
+	rootCMD.AddCommand(runCMD, peer.PeerCLI, topology.TopologyCLI, utils.UtilsCLI, keygen.KeygenCLI, quarantine.QuarantineCLI, keyshare.KeyshareCLI, proposal.ProposalCLI)
 	if err := rootCMD.Execute(); err != nil {
 		log.Fatal().Err(err).Msg("failed to execute root cmd")
 	}
@@ -0,0 +1,94 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+)
+
+var KeyshareCLI = &cobra.Command{
+	Use:   "keyshare",
+	Short: "utility commands to list, inspect and restore keyshare generations, print keyshare public keys and export or import keyshares",
+}
+
+var (
+	path               string
+	frost              bool
+	keyshareEncryption relayer.KeyshareEncryptionConfig
+)
+
+func init() {
+	KeyshareCLI.PersistentFlags().StringVar(&path, "path", "", "path to the keyshare file")
+	_ = KeyshareCLI.MarkPersistentFlagRequired("path")
+	KeyshareCLI.PersistentFlags().BoolVar(&frost, "frost", false, "keyshare is a FROST keyshare")
+	KeyshareCLI.PersistentFlags().StringVar(&keyshareEncryption.Wrapper, "wrapper", "", "key wrapper used to encrypt keyshares: passphrase, file or pkcs11")
+	KeyshareCLI.PersistentFlags().StringVar(&keyshareEncryption.Passphrase, "passphrase", "", "passphrase of the passphrase key wrapper")
+	KeyshareCLI.PersistentFlags().StringVar(&keyshareEncryption.MasterKeyPath, "master-key-path", "", "master key file of the file key wrapper")
+	KeyshareCLI.PersistentFlags().StringVar(&keyshareEncryption.Pkcs11Library, "pkcs11-library", "", "PKCS#11 module of the pkcs11 key wrapper")
+	KeyshareCLI.PersistentFlags().StringVar(&keyshareEncryption.Pkcs11TokenLabel, "pkcs11-token-label", "", "PKCS#11 token label of the pkcs11 key wrapper")
+	KeyshareCLI.PersistentFlags().StringVar(&keyshareEncryption.Pkcs11Pin, "pkcs11-pin", "", "PKCS#11 user PIN of the pkcs11 key wrapper")
+	KeyshareCLI.PersistentFlags().StringVar(&keyshareEncryption.Pkcs11KeyLabel, "pkcs11-key-label", "", "PKCS#11 AES key label of the pkcs11 key wrapper")
+
+	KeyshareCLI.AddCommand(listCMD)
+	KeyshareCLI.AddCommand(inspectCMD)
+	KeyshareCLI.AddCommand(restoreCMD)
+	KeyshareCLI.AddCommand(publicKeyCMD)
+	KeyshareCLI.AddCommand(exportCMD)
+	KeyshareCLI.AddCommand(importCMD)
+}
+
+// generationStore is the keyshare history of either ECDSA or FROST keyshare store
+type generationStore interface {
+	Generations() ([]keyshare.Generation, error)
+	RestoreGeneration(number uint64, publicKey string) error
+}
+
+func newGenerationStore() (generationStore, error) {
+	err := keyshareEncryption.Validate()
+	if err != nil {
+		return nil, err
+	}
+	wrapper, err := keyshare.NewKeyWrapper(keyshareEncryption)
+	if err != nil {
+		return nil, err
+	}
+
+	if frost {
+		return keyshare.NewFrostKeyshareStore(path, wrapper), nil
+	}
+	return keyshare.NewECDSAKeyshareStore(path, wrapper), nil
+}
+
+// newKeyshareStores returns ECDSA keyshare store of the keyshare from the path
+// and FROST keyshare store of the keyshare from the FROST path
+func newKeyshareStores() (*keyshare.ECDSAKeyshareStore, *keyshare.FrostKeyshareStore, error) {
+	err := keyshareEncryption.Validate()
+	if err != nil {
+		return nil, nil, err
+	}
+	wrapper, err := keyshare.NewKeyWrapper(keyshareEncryption)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return keyshare.NewECDSAKeyshareStore(path, wrapper), keyshare.NewFrostKeyshareStore(frostPath, wrapper), nil
+}
+
+func findGeneration(store generationStore, number uint64) (keyshare.Generation, error) {
+	generations, err := store.Generations()
+	if err != nil {
+		return keyshare.Generation{}, err
+	}
+	for _, g := range generations {
+		if g.Number == number {
+			return g, nil
+		}
+	}
+	return keyshare.Generation{}, fmt.Errorf("keyshare generation %d not found", number)
+}
@@ -0,0 +1,110 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/topology"
+)
+
+var (
+	exportCMD = &cobra.Command{
+		Use:   "export",
+		Short: "Export keyshares into an encrypted bundle",
+		Long: "Bundles the ECDSA keyshare from --path, the FROST keyshare and the topology into one encrypted and checksummed file. " +
+			"The bundle is encrypted with a random export key that is printed or split into parts for several custodians.",
+		RunE: export,
+	}
+)
+
+var (
+	frostPath      string
+	topologyPath   string
+	privateKey     string
+	bundlePath     string
+	parts          int
+	partsThreshold int
+	exportKey      string
+	exportKeyParts []string
+)
+
+func init() {
+	exportCMD.Flags().StringVar(&frostPath, "frost-path", "", "path to the FROST keyshare file")
+	_ = exportCMD.MarkFlagRequired("frost-path")
+	exportCMD.Flags().StringVar(&topologyPath, "topology-path", "", "path to the topology file")
+	_ = exportCMD.MarkFlagRequired("topology-path")
+	exportCMD.Flags().StringVar(&privateKey, "private-key", "", "base64 encoded libp2p private key of the relayer")
+	_ = exportCMD.MarkFlagRequired("private-key")
+	exportCMD.Flags().StringVar(&bundlePath, "output", "", "path of the exported bundle")
+	_ = exportCMD.MarkFlagRequired("output")
+	exportCMD.Flags().IntVar(&parts, "parts", 0, "number of parts the export key is split into, the key is not split if not set")
+	exportCMD.Flags().IntVar(&partsThreshold, "parts-threshold", 0, "number of parts required to recover the export key")
+}
+
+func export(cmd *cobra.Command, args []string) error {
+	peerID, err := peerIDFromKey(privateKey)
+	if err != nil {
+		return err
+	}
+	ecdsaStore, frostStore, err := newKeyshareStores()
+	if err != nil {
+		return err
+	}
+
+	bundle, err := keyshare.NewBundle(peerID, ecdsaStore, frostStore, topology.NewTopologyStore(topologyPath))
+	if err != nil {
+		return err
+	}
+	key, err := keyshare.NewExportKey()
+	if err != nil {
+		return err
+	}
+	var keyParts [][]byte
+	if parts != 0 {
+		keyParts, err = keyshare.SplitSecret(key, parts, partsThreshold)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := keyshare.EncryptBundle(bundle, key)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(bundlePath, data, 0600)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported keyshares of peer %s to %s\n", peerID, bundlePath)
+	if keyParts == nil {
+		fmt.Printf("Export key: %s\n", hex.EncodeToString(key))
+		return nil
+	}
+	fmt.Printf("Export key split into %d parts, %d required to import the bundle:\n", parts, partsThreshold)
+	for i, part := range keyParts {
+		fmt.Printf("Part %d: %s\n", i+1, hex.EncodeToString(part))
+	}
+	return nil
+}
+
+func peerIDFromKey(privateKey string) (peer.ID, error) {
+	privBytes, err := crypto.ConfigDecodeKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	priv, err := crypto.UnmarshalPrivateKey(privBytes)
+	if err != nil {
+		return "", err
+	}
+	return peer.IDFromPrivateKey(priv)
+}
@@ -0,0 +1,95 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/topology"
+)
+
+var (
+	importCMD = &cobra.Command{
+		Use:   "import",
+		Short: "Import keyshares from an encrypted bundle",
+		Long: "Decrypts the bundle with the export key or its parts, validates that the bundle belongs to the relayer libp2p identity " +
+			"and installs the ECDSA keyshare into --path, the FROST keyshare and the topology. " +
+			"Replaced keyshares are kept in history. Relayer should be stopped while keyshares are imported.",
+		RunE: importBundle,
+	}
+)
+
+func init() {
+	importCMD.Flags().StringVar(&frostPath, "frost-path", "", "path to the FROST keyshare file")
+	_ = importCMD.MarkFlagRequired("frost-path")
+	importCMD.Flags().StringVar(&topologyPath, "topology-path", "", "path to the topology file")
+	_ = importCMD.MarkFlagRequired("topology-path")
+	importCMD.Flags().StringVar(&privateKey, "private-key", "", "base64 encoded libp2p private key of the relayer")
+	_ = importCMD.MarkFlagRequired("private-key")
+	importCMD.Flags().StringVar(&bundlePath, "input", "", "path of the exported bundle")
+	_ = importCMD.MarkFlagRequired("input")
+	importCMD.Flags().StringVar(&exportKey, "export-key", "", "hex encoded export key")
+	importCMD.Flags().StringSliceVar(&exportKeyParts, "export-key-part", []string{}, "hex encoded part of the export key, repeated for each part")
+}
+
+func importBundle(cmd *cobra.Command, args []string) error {
+	peerID, err := peerIDFromKey(privateKey)
+	if err != nil {
+		return err
+	}
+	key, err := readExportKey()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return err
+	}
+	bundle, err := keyshare.DecryptBundle(data, key)
+	if err != nil {
+		return err
+	}
+	err = bundle.Validate(peerID)
+	if err != nil {
+		return err
+	}
+
+	ecdsaStore, frostStore, err := newKeyshareStores()
+	if err != nil {
+		return err
+	}
+	err = bundle.Install(ecdsaStore, frostStore, topology.NewTopologyStore(topologyPath))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported keyshares of peer %s exported at %s\n", peerID, bundle.CreatedAt)
+	return nil
+}
+
+// readExportKey returns the export key from the flag or combines it from its parts
+func readExportKey() ([]byte, error) {
+	if exportKey != "" {
+		return hex.DecodeString(exportKey)
+	}
+	if len(exportKeyParts) == 0 {
+		return nil, fmt.Errorf("export key or its parts are required")
+	}
+
+	keyParts := make([][]byte, len(exportKeyParts))
+	for i, part := range exportKeyParts {
+		var err error
+		keyParts[i], err = hex.DecodeString(part)
+		if err != nil {
+			return nil, fmt.Errorf("export key part %d is not hex encoded: %w", i+1, err)
+		}
+	}
+	return keyshare.CombineSecret(keyParts)
+}
@@ -0,0 +1,52 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	inspectCMD = &cobra.Command{
+		Use:   "inspect",
+		Short: "Inspect keyshare generation",
+		Long:  "Prints session, committee and public key of the keyshare generation without the private share",
+		RunE:  inspect,
+	}
+)
+
+var (
+	generation uint64
+)
+
+func init() {
+	inspectCMD.Flags().Uint64Var(&generation, "generation", 0, "keyshare generation number")
+	_ = inspectCMD.MarkFlagRequired("generation")
+}
+
+func inspect(cmd *cobra.Command, args []string) error {
+	store, err := newGenerationStore()
+	if err != nil {
+		return err
+	}
+	g, err := findGeneration(store, generation)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Generation: %d\n", g.Number)
+	fmt.Printf("Session: %s\n", g.SessionID)
+	fmt.Printf("Topology hash: %s\n", g.TopologyHash)
+	fmt.Printf("Threshold: %d\n", g.Threshold)
+	fmt.Printf("Public key: %s\n", g.PublicKey)
+	fmt.Printf("Stored at: %s\n", g.StoredAt.Format(time.RFC3339))
+	fmt.Printf("Peers:\n")
+	for _, p := range g.Peers {
+		fmt.Printf("    %s\n", p)
+	}
+	return nil
+}
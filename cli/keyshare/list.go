@@ -0,0 +1,38 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	listCMD = &cobra.Command{
+		Use:   "list",
+		Short: "List keyshare generations",
+		Long:  "Lists previous keyshare generations kept in the keyshare history",
+		RunE:  list,
+	}
+)
+
+func list(cmd *cobra.Command, args []string) error {
+	store, err := newGenerationStore()
+	if err != nil {
+		return err
+	}
+	generations, err := store.Generations()
+	if err != nil {
+		return err
+	}
+
+	for _, g := range generations {
+		fmt.Printf(
+			"generation: %d, session: %s, topologyHash: %s, threshold: %d, publicKey: %s, storedAt: %s\n",
+			g.Number, g.SessionID, g.TopologyHash, g.Threshold, g.PublicKey, g.StoredAt.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	btcConfig "github.com/ChainSafe/sygma-relayer/chains/btc/config"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+)
+
+var (
+	publicKeyCMD = &cobra.Command{
+		Use:   "public-key",
+		Short: "Export keyshare public key",
+		Long: "Prints the MPC address of the ECDSA keyshare or the taproot public key of the FROST keyshare. " +
+			"For FROST keyshares, taproot addresses of the key tweaked with each provided tweak are printed " +
+			"so that they can be compared with the configured resource addresses.",
+		RunE: exportPublicKey,
+	}
+)
+
+var (
+	tweaks  []string
+	network string
+)
+
+func init() {
+	publicKeyCMD.Flags().StringSliceVar(&tweaks, "tweak", []string{}, "hex encoded tweak of a FROST resource")
+	publicKeyCMD.Flags().StringVar(&network, "network", "mainnet", "bitcoin network of the tweaked taproot addresses")
+}
+
+func exportPublicKey(cmd *cobra.Command, args []string) error {
+	err := keyshareEncryption.Validate()
+	if err != nil {
+		return err
+	}
+	wrapper, err := keyshare.NewKeyWrapper(keyshareEncryption)
+	if err != nil {
+		return err
+	}
+
+	if !frost {
+		key, err := keyshare.NewECDSAKeyshareStore(path, wrapper).GetKeyshare()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("MPC address: %s\n", key.PublicKey())
+		return nil
+	}
+
+	key, err := keyshare.NewFrostKeyshareStore(path, wrapper).GetKeyshare()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Public key: %s\n", key.PublicKey())
+	if len(tweaks) == 0 {
+		return nil
+	}
+
+	params, err := btcConfig.NetworkParams(network)
+	if err != nil {
+		return err
+	}
+	for _, tweak := range tweaks {
+		address, err := key.TaprootAddress(tweak, &params)
+		if err != nil {
+			return fmt.Errorf("failed deriving taproot address with tweak %s: %w", tweak, err)
+		}
+		fmt.Printf("Tweak %s: %s\n", tweak, address.EncodeAddress())
+	}
+	return nil
+}
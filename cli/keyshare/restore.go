@@ -0,0 +1,170 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	btcConfig "github.com/ChainSafe/sygma-relayer/chains/btc/config"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/consts"
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/topology"
+)
+
+var (
+	restoreCMD = &cobra.Command{
+		Use:   "restore",
+		Short: "Restore keyshare generation",
+		Long: "Replaces the keyshare with the generation from history if the generation public key matches the key the bridge currently expects " +
+			"and the generation threshold and peers match the stored topology. " +
+			"ECDSA keyshares are checked against the MPC address of the bridge contract and FROST keyshares against the resource addresses of btc domains from the relayer configuration. " +
+			"Relayer should be stopped while the keyshare is restored.",
+		RunE: restore,
+	}
+)
+
+var (
+	url    string
+	bridge string
+)
+
+func init() {
+	restoreCMD.Flags().Uint64Var(&generation, "generation", 0, "keyshare generation number")
+	_ = restoreCMD.MarkFlagRequired("generation")
+	restoreCMD.Flags().StringVar(&url, "url", "", "RPC url of an EVM chain with the bridge contract, required for ECDSA keyshares")
+	restoreCMD.Flags().StringVar(&bridge, "bridge", "", "bridge contract address, required for ECDSA keyshares")
+	restoreCMD.Flags().StringVar(&topologyPath, "topology-path", "", "path to the topology file")
+	_ = restoreCMD.MarkFlagRequired("topology-path")
+}
+
+func restore(cmd *cobra.Command, args []string) error {
+	store, err := newGenerationStore()
+	if err != nil {
+		return err
+	}
+	g, err := findGeneration(store, generation)
+	if err != nil {
+		return err
+	}
+	err = verifyTopology(g)
+	if err != nil {
+		return err
+	}
+
+	if frost {
+		err = verifyFrostGeneration(store.(*keyshare.FrostKeyshareStore), generation)
+		if err != nil {
+			return err
+		}
+	} else {
+		if url == "" || !common.IsHexAddress(bridge) {
+			return fmt.Errorf("url and bridge address are required to restore ECDSA keyshare")
+		}
+		address, err := mpcAddress(url, common.HexToAddress(bridge))
+		if err != nil {
+			return fmt.Errorf("failed reading bridge MPC address: %w", err)
+		}
+		if !strings.EqualFold(g.PublicKey, address.Hex()) {
+			return fmt.Errorf("keyshare generation %d address %s does not match bridge MPC address %s", generation, g.PublicKey, address.Hex())
+		}
+	}
+
+	err = store.RestoreGeneration(generation, g.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored keyshare generation %d with public key %s\n", generation, g.PublicKey)
+	return nil
+}
+
+// verifyTopology returns error if the generation was generated for a different
+// threshold or peers than the stored topology
+func verifyTopology(g keyshare.Generation) error {
+	nt, err := topology.NewTopologyStore(topologyPath).Topology()
+	if err != nil {
+		return fmt.Errorf("failed reading topology: %w", err)
+	}
+	peers := make([]peer.ID, len(nt.Peers))
+	for i, p := range nt.Peers {
+		peers[i] = p.ID
+	}
+
+	if keyshare.TopologyHash(nt.Threshold, peers) != g.TopologyHash {
+		return fmt.Errorf("keyshare generation %d threshold %d and peers %v do not match topology threshold %d and peers %v", g.Number, g.Threshold, g.Peers, nt.Threshold, peers)
+	}
+	return nil
+}
+
+// verifyFrostGeneration derives taproot addresses from the FROST generation and
+// compares them with resource addresses of btc domains from the relayer configuration
+func verifyFrostGeneration(store *keyshare.FrostKeyshareStore, number uint64) error {
+	configuration, err := config.GetConfigFromFile(viper.GetString(config.ConfigFlagName), nil)
+	if err != nil {
+		return fmt.Errorf("failed reading relayer configuration: %w", err)
+	}
+	key, err := store.Generation(number)
+	if err != nil {
+		return err
+	}
+
+	domains := 0
+	for _, chainConfig := range configuration.ChainConfigs {
+		if chainConfig["type"] != "btc" {
+			continue
+		}
+		c, err := btcConfig.NewBtcConfig(chainConfig)
+		if err != nil {
+			return err
+		}
+
+		resources := make([]keyshare.TaprootResource, len(c.Resources))
+		for i, resource := range c.Resources {
+			resources[i] = keyshare.TaprootResource{Address: resource.Address, Tweak: resource.Tweak}
+		}
+		err = keyshare.VerifyTaprootResources(key, &c.Network, resources)
+		if err != nil {
+			return fmt.Errorf("keyshare generation %d does not control resources of domain %d: %w", number, *c.GeneralChainConfig.Id, err)
+		}
+		domains++
+	}
+	if domains == 0 {
+		return fmt.Errorf("no btc domains configured to restore FROST keyshare")
+	}
+	return nil
+}
+
+func mpcAddress(url string, bridge common.Address) (common.Address, error) {
+	client, err := ethclient.Dial(url)
+	if err != nil {
+		return common.Address{}, err
+	}
+	defer client.Close()
+
+	a, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
+	input, err := a.Pack("_MPCAddress")
+	if err != nil {
+		return common.Address{}, err
+	}
+	output, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &bridge, Data: input}, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	res, err := a.Unpack("_MPCAddress", output)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(res[0], new(common.Address)).(*common.Address), nil
+}
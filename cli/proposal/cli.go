@@ -0,0 +1,21 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proposal
+
+import "github.com/spf13/cobra"
+
+var ProposalCLI = &cobra.Command{
+	Use:   "proposal",
+	Short: "utility commands to inspect proposal execution records",
+}
+
+var blockstorePath string
+
+func init() {
+	ProposalCLI.PersistentFlags().StringVar(&blockstorePath, "blockstore", "", "path to the relayer blockstore")
+	_ = ProposalCLI.MarkPersistentFlagRequired("blockstore")
+
+	ProposalCLI.AddCommand(showCMD)
+	ProposalCLI.AddCommand(listCMD)
+}
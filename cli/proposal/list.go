@@ -0,0 +1,75 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proposal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+)
+
+var (
+	listCMD = &cobra.Command{
+		Use:   "list",
+		Short: "List proposal execution records",
+		Long: "Lists proposals by status, destination domain and time of the last status change " +
+			"using the proposal indexes. Results are paged, the printed cursor continues the listing.",
+		RunE: list,
+	}
+)
+
+var (
+	status    string
+	olderThan time.Duration
+	newerThan time.Duration
+	limit     int
+	cursor    string
+)
+
+func init() {
+	listCMD.Flags().StringVar(&status, "status", "", "proposal status: pending, failed, executed or permanentlyFailed")
+	listCMD.Flags().Uint8Var(&destination, "destination", 0, "destination domain ID")
+	listCMD.Flags().DurationVar(&olderThan, "older-than", 0, "list proposals with status changed more than the duration ago, e.g. 1h")
+	listCMD.Flags().DurationVar(&newerThan, "newer-than", 0, "list proposals with status changed less than the duration ago, e.g. 24h")
+	listCMD.Flags().IntVar(&limit, "limit", 100, "maximum number of listed proposals")
+	listCMD.Flags().StringVar(&cursor, "cursor", "", "cursor printed by the previous listing")
+}
+
+func list(cmd *cobra.Command, args []string) error {
+	db, err := store.NewLvlDB(blockstorePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	query := store.ProposalQuery{
+		Status:      store.PropStatus(status),
+		Destination: destination,
+	}
+	now := time.Now()
+	if olderThan != 0 {
+		query.ChangedBefore = now.Add(-olderThan)
+	}
+	if newerThan != 0 {
+		query.ChangedAfter = now.Add(-newerThan)
+	}
+
+	records, next, err := store.NewPropStore(db).Proposals(query, cursor, limit)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		fmt.Printf(
+			"source: %d, destination: %d, depositNonce: %d, messageID: %s, status: %s, since: %s, attempts: %d, tx hash: %s\n",
+			r.Source, r.Destination, r.DepositNonce, r.MessageID, r.Status, r.StatusChangedAt(), r.Attempts, r.TxHash)
+	}
+	if next != "" {
+		fmt.Printf("Next page: --cursor %s\n", next)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proposal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+)
+
+var (
+	showCMD = &cobra.Command{
+		Use:   "show",
+		Short: "Show proposal execution record",
+		Long: "Prints status transitions, signing sessions, execution transaction hash " +
+			"and failure reasons of the proposal recorded by the executor",
+		RunE: show,
+	}
+)
+
+var (
+	source       uint8
+	destination  uint8
+	depositNonce uint64
+)
+
+func init() {
+	showCMD.Flags().Uint8Var(&source, "source", 0, "source domain ID")
+	_ = showCMD.MarkFlagRequired("source")
+	showCMD.Flags().Uint8Var(&destination, "destination", 0, "destination domain ID")
+	_ = showCMD.MarkFlagRequired("destination")
+	showCMD.Flags().Uint64Var(&depositNonce, "nonce", 0, "deposit nonce")
+	_ = showCMD.MarkFlagRequired("nonce")
+}
+
+func show(cmd *cobra.Command, args []string) error {
+	db, err := store.NewLvlDB(blockstorePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	r, err := store.NewPropStore(db).ProposalRecord(source, destination, depositNonce)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return fmt.Errorf("no record of proposal %d-%d-%d", source, destination, depositNonce)
+	}
+
+	fmt.Printf("source: %d, destination: %d, depositNonce: %d\n", r.Source, r.Destination, r.DepositNonce)
+	fmt.Printf("messageID: %s\n", r.MessageID)
+	fmt.Printf("status: %s\n", r.Status)
+	if r.Recipient != "" {
+		fmt.Printf("recipient: %s\n", r.Recipient)
+	}
+	fmt.Printf("first seen: %s\n", r.FirstSeen)
+	for _, t := range r.Transitions {
+		fmt.Printf("  %s: %s\n", t.Timestamp, t.Status)
+	}
+	fmt.Printf("attempts: %d\n", r.Attempts)
+	fmt.Printf("sessions: %s\n", strings.Join(r.SessionIDs, ", "))
+	fmt.Printf("tx hash: %s\n", r.TxHash)
+	for _, reason := range r.FailureReasons {
+		fmt.Printf("failure: %s\n", reason)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package quarantine
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+)
+
+var (
+	approveCMD = &cobra.Command{
+		Use:   "approve",
+		Short: "Approve quarantined proposal",
+		Long: "Marks quarantined proposal as reviewed. " +
+			"Approved proposal is executed once the deposit is retried. " +
+			"The blockstore is locked while the relayer is running, so the relayer has to be stopped first.",
+		RunE: approve,
+	}
+)
+
+var (
+	source       uint8
+	destination  uint8
+	depositNonce uint64
+)
+
+func init() {
+	approveCMD.Flags().Uint8Var(&source, "source", 0, "source domain ID")
+	_ = approveCMD.MarkFlagRequired("source")
+	approveCMD.Flags().Uint8Var(&destination, "destination", 0, "destination domain ID")
+	_ = approveCMD.MarkFlagRequired("destination")
+	approveCMD.Flags().Uint64Var(&depositNonce, "nonce", 0, "deposit nonce")
+	_ = approveCMD.MarkFlagRequired("nonce")
+}
+
+func approve(cmd *cobra.Command, args []string) error {
+	db, err := store.NewLvlDB(blockstorePath)
+	if err != nil {
+		return fmt.Errorf("failed opening blockstore, stop the relayer before approving proposals: %w", err)
+	}
+	defer db.Close()
+
+	err = store.NewQuarantineStore(db).Approve(source, destination, depositNonce)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Approved proposal %d-%d-%d\n", source, destination, depositNonce)
+	return nil
+}
@@ -0,0 +1,21 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package quarantine
+
+import "github.com/spf13/cobra"
+
+var QuarantineCLI = &cobra.Command{
+	Use:   "quarantine",
+	Short: "utility commands to review proposals held back by the generic policy",
+}
+
+var blockstorePath string
+
+func init() {
+	QuarantineCLI.PersistentFlags().StringVar(&blockstorePath, "blockstore", "", "path to the relayer blockstore")
+	_ = QuarantineCLI.MarkPersistentFlagRequired("blockstore")
+
+	QuarantineCLI.AddCommand(listCMD)
+	QuarantineCLI.AddCommand(approveCMD)
+}
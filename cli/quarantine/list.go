@@ -0,0 +1,42 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package quarantine
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+)
+
+var (
+	listCMD = &cobra.Command{
+		Use:   "list",
+		Short: "List quarantined proposals",
+		Long: "Lists proposals that violated the generic policy and were held back from execution " +
+			"and proposals that were rejected as not executable",
+		RunE: list,
+	}
+)
+
+func list(cmd *cobra.Command, args []string) error {
+	db, err := store.NewLvlDB(blockstorePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	records, err := store.NewQuarantineStore(db).QuarantinedProposals()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		fmt.Printf(
+			"source: %d, destination: %d, depositNonce: %d, resourceID: %s, status: %s, time: %s, reason: %s\n",
+			r.Source, r.Destination, r.DepositNonce, r.ResourceID, r.Status, r.Timestamp, r.Reason)
+	}
+	return nil
+}
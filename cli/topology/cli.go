@@ -7,10 +7,12 @@ import "github.com/spf13/cobra"
 
 var TopologyCLI = &cobra.Command{
 	Use:   "topology",
-	Short: "utility commands that helps to encrypt and test p2p TopologyMap",
+	Short: "utility commands that helps to sign, encrypt and test p2p TopologyMap",
 }
 
 func init() {
 	TopologyCLI.AddCommand(encryptTopologyCMD)
 	TopologyCLI.AddCommand(testTopologyCMD)
+	TopologyCLI.AddCommand(signTopologyCMD)
+	TopologyCLI.AddCommand(diffTopologyCMD)
 }
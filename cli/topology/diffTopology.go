@@ -0,0 +1,78 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package topology
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/topology"
+)
+
+var (
+	diffTopologyCMD = &cobra.Command{
+		Use:   "diff",
+		Short: "Show changes between two encrypted topologies",
+		Long: "CLI decrypts old and new topology read from a file or url and prints added and removed peers, " +
+			"changed peer addresses and threshold change",
+		RunE: diffTopology,
+	}
+)
+
+var (
+	oldTopology string
+	newTopology string
+)
+
+func init() {
+	diffTopologyCMD.PersistentFlags().StringVar(&oldTopology, "old", "", "file path or url of the old encrypted topology")
+	_ = diffTopologyCMD.MarkFlagRequired("old")
+	diffTopologyCMD.PersistentFlags().StringVar(&newTopology, "new", "", "file path or url of the new encrypted topology")
+	_ = diffTopologyCMD.MarkFlagRequired("new")
+	diffTopologyCMD.PersistentFlags().StringVar(&decryptionKey, "decryption-key", "", "password to decrypt topology")
+	_ = diffTopologyCMD.MarkFlagRequired("decryption-key")
+	diffTopologyCMD.PersistentFlags().StringVar(&adminKeys, "admin-keys", "", "comma separated admin addresses that sign topology manifests")
+	diffTopologyCMD.PersistentFlags().StringVar(&adminThreshold, "admin-threshold", "", "number of admin signatures required on topology manifests")
+}
+
+func diffTopology(cmd *cobra.Command, args []string) error {
+	old, err := readTopology(oldTopology)
+	if err != nil {
+		return fmt.Errorf("failed reading old topology: %w", err)
+	}
+	new, err := readTopology(newTopology)
+	if err != nil {
+		return fmt.Errorf("failed reading new topology: %w", err)
+	}
+
+	diff := topology.Diff(old, new)
+	fmt.Print(diff)
+	if !diff.IsEmpty() && diff.IsAddressOnly() {
+		fmt.Printf("Only peer addresses changed, refresh will not start resharing\n")
+	}
+	return nil
+}
+
+func readTopology(location string) (*topology.NetworkTopology, error) {
+	config := relayer.TopologyConfiguration{
+		EncryptionKey:  decryptionKey,
+		AdminKeys:      adminKeys,
+		AdminThreshold: adminThreshold,
+	}
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		config.Url = location
+	} else {
+		config.File = location
+	}
+
+	nt, err := topology.NewNetworkTopologyProvider(config, &http.Client{Timeout: topology.FetchTimeout})
+	if err != nil {
+		return nil, err
+	}
+	return nt.NetworkTopology("")
+}
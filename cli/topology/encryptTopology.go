@@ -19,27 +19,41 @@ import (
 var (
 	encryptTopologyCMD = &cobra.Command{
 		Use:   "encrypt",
-		Short: "encrypt provided topology with AES",
-		Long:  "Algorithm used is AES CTR. IV and CT returned are in hex.",
-		RunE:  encryptTopology,
+		Short: "encrypt provided topology with AEAD",
+		Long: "Algorithm used is AES-GCM or XChaCha20-Poly1305 with the key derived from the encryption key with argon2id. " +
+			"Header, nonce and CT returned are in hex.",
+		RunE: encryptTopology,
 	}
 )
 
 var (
 	path          string
 	encryptionKey string
+	algorithm     string
 )
 
+var algorithms = map[string]topology.Algorithm{
+	"aes-gcm":            topology.AESGCM,
+	"xchacha20-poly1305": topology.XChaCha20Poly1305,
+}
+
 func init() {
 	encryptTopologyCMD.PersistentFlags().StringVar(&path, "path", "", "path to json file with network topology")
 	_ = encryptTopologyCMD.MarkFlagRequired("path")
 	encryptTopologyCMD.PersistentFlags().StringVar(&encryptionKey, "encryption-key", "", "password to encrypt topology")
 	_ = encryptTopologyCMD.MarkFlagRequired("encryption-key")
+	encryptTopologyCMD.PersistentFlags().StringVar(&algorithm, "algorithm", "aes-gcm", "encryption algorithm, aes-gcm or xchacha20-poly1305")
 }
 
 func encryptTopology(cmd *cobra.Command, args []string) error {
-	cipherKey := []byte(encryptionKey)
-	aesEncryption, _ := topology.NewAESEncryption(cipherKey)
+	alg, ok := algorithms[algorithm]
+	if !ok {
+		return fmt.Errorf("unsupported algorithm %s", algorithm)
+	}
+	encryption, err := topology.NewEncryption([]byte(encryptionKey), alg, topology.DefaultKDFParams)
+	if err != nil {
+		return err
+	}
 	topologyFile, err := os.Open(path)
 	defer func() {
 		err := topologyFile.Close()
@@ -60,7 +74,7 @@ func encryptTopology(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("topology was wrong formed %s", err.Error())
 	}
-	ct, err := aesEncryption.Encrypt(byteValue)
+	ct, err := encryption.Encrypt(byteValue)
 	if err != nil {
 		return err
 	}
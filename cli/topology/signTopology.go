@@ -0,0 +1,83 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package topology
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/topology"
+)
+
+var (
+	signTopologyCMD = &cobra.Command{
+		Use:   "sign",
+		Short: "sign topology manifest with admin key",
+		Long: "Signs topology manifest with the provided admin key and appends the signature to the signed manifest file. " +
+			"If the file contains an unsigned manifest it is wrapped into a signed manifest.",
+		RunE: signTopology,
+	}
+)
+
+var (
+	adminKey string
+)
+
+func init() {
+	signTopologyCMD.PersistentFlags().StringVar(&path, "path", "", "path to json file with topology manifest or signed manifest")
+	_ = signTopologyCMD.MarkFlagRequired("path")
+	signTopologyCMD.PersistentFlags().StringVar(&adminKey, "private-key", "", "hex encoded admin private key")
+	_ = signTopologyCMD.MarkFlagRequired("private-key")
+}
+
+func signTopology(cmd *cobra.Command, args []string) error {
+	key, err := crypto.HexToECDSA(adminKey)
+	if err != nil {
+		return err
+	}
+	byteValue, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	signedManifest := &topology.SignedManifest{}
+	err = json.Unmarshal(byteValue, signedManifest)
+	if err != nil {
+		return fmt.Errorf("manifest was wrong formed %s", err.Error())
+	}
+	if signedManifest.Manifest == nil {
+		signedManifest.Manifest = byteValue
+	}
+	manifest := &topology.TopologyManifest{}
+	err = json.Unmarshal(signedManifest.Manifest, manifest)
+	if err != nil {
+		return fmt.Errorf("manifest was wrong formed %s", err.Error())
+	}
+	_, err = topology.ProcessRawTopology(&manifest.Topology)
+	if err != nil {
+		return err
+	}
+
+	signature, err := topology.SignManifest(signedManifest.Manifest, key)
+	if err != nil {
+		return err
+	}
+	signedManifest.Signatures = append(signedManifest.Signatures, signature)
+	signedBytes, err := json.MarshalIndent(signedManifest, "", "    ")
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(path, signedBytes, 0600)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Manifest version %d signed by %s, it has %d signatures\n",
+		manifest.Version, crypto.PubkeyToAddress(key.PublicKey), len(signedManifest.Signatures))
+	return nil
+}
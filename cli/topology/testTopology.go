@@ -18,15 +18,17 @@ var (
 		Use:   "test",
 		Short: "Test topology url",
 		Long: "CLI tests does provided url contain topology that could be well " +
-			"decrypted with provided password and then parsed accordingly",
+			"decrypted with provided password, verified against provided admin keys and then parsed accordingly",
 		RunE: testTopology,
 	}
 )
 
 var (
-	url           string
-	hash          string
-	decryptionKey string
+	url            string
+	hash           string
+	decryptionKey  string
+	adminKeys      string
+	adminThreshold string
 )
 
 func init() {
@@ -35,16 +37,20 @@ func init() {
 	testTopologyCMD.PersistentFlags().StringVar(&url, "url", "", "url to fetch topology")
 	_ = testTopologyCMD.MarkFlagRequired("url")
 	testTopologyCMD.PersistentFlags().StringVar(&hash, "hash", "", "hash of topology")
+	testTopologyCMD.PersistentFlags().StringVar(&adminKeys, "admin-keys", "", "comma separated admin addresses that sign topology manifests")
+	testTopologyCMD.PersistentFlags().StringVar(&adminThreshold, "admin-threshold", "", "number of admin signatures required on topology manifests")
 
 }
 
 func testTopology(cmd *cobra.Command, args []string) error {
 	config := relayer.TopologyConfiguration{
-		EncryptionKey: decryptionKey,
-		Url:           url,
-		Path:          "",
+		EncryptionKey:  decryptionKey,
+		Url:            url,
+		Path:           "",
+		AdminKeys:      adminKeys,
+		AdminThreshold: adminThreshold,
 	}
-	nt, err := topology.NewNetworkTopologyProvider(config, http.DefaultClient)
+	nt, err := topology.NewNetworkTopologyProvider(config, &http.Client{Timeout: topology.FetchTimeout})
 	if err != nil {
 		return err
 	}
@@ -4,6 +4,8 @@
 package p2p
 
 import (
+	"sync"
+
 	"github.com/libp2p/go-libp2p/core/control"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -15,7 +17,8 @@ import (
 // ConnectionGate implements libp2p ConnectionGater to prevent inbound and
 // outbound requests to peers not specified in topology
 type ConnectionGate struct {
-	topology *topology.NetworkTopology
+	topology     *topology.NetworkTopology
+	topologyLock sync.RWMutex
 }
 
 func NewConnectionGate(topology *topology.NetworkTopology) *ConnectionGate {
@@ -24,16 +27,19 @@ func NewConnectionGate(topology *topology.NetworkTopology) *ConnectionGate {
 	}
 }
 
+// SetTopology replaces topology used to filter connections
 func (cg *ConnectionGate) SetTopology(topology *topology.NetworkTopology) {
+	cg.topologyLock.Lock()
+	defer cg.topologyLock.Unlock()
 	cg.topology = topology
 }
 
 func (cg *ConnectionGate) InterceptPeerDial(p peer.ID) (allow bool) {
-	return cg.topology.IsAllowedPeer(p)
+	return cg.isAllowedPeer(p)
 }
 
 func (cg *ConnectionGate) InterceptSecured(nd network.Direction, p peer.ID, cm network.ConnMultiaddrs) (allow bool) {
-	return cg.topology.IsAllowedPeer(p)
+	return cg.isAllowedPeer(p)
 }
 
 func (cg *ConnectionGate) InterceptAddrDial(peer.ID, ma.Multiaddr) (allow bool) {
@@ -47,3 +53,9 @@ func (cg *ConnectionGate) InterceptAccept(network.ConnMultiaddrs) (allow bool) {
 func (cg *ConnectionGate) InterceptUpgraded(network.Conn) (allow bool, reason control.DisconnectReason) {
 	return true, 0
 }
+
+func (cg *ConnectionGate) isAllowedPeer(p peer.ID) bool {
+	cg.topologyLock.RLock()
+	defer cg.topologyLock.RUnlock()
+	return cg.topology.IsAllowedPeer(p)
+}
@@ -53,7 +53,7 @@ func LoadPeers(h host.Host, peers []*peer.AddrInfo) {
 	}
 
 	for _, p := range peers {
-		log.Debug().Msgf("Adding new peer with ID %s", p.ID)
-		h.Peerstore().AddAddr(p.ID, p.Addrs[0], peerstore.PermanentAddrTTL)
+		log.Debug().Msgf("Adding new peer with ID %s and addresses %s", p.ID, p.Addrs)
+		h.Peerstore().AddAddrs(p.ID, p.Addrs, peerstore.PermanentAddrTTL)
 	}
 }
@@ -118,3 +118,13 @@ func (s *LoadPeersTestSuite) Test_LoadPeers_RemovesOldAndSetsNewPeers() {
 	s.Equal(peerInSlice(newP2.ID, s.host.Peerstore().Peers()), true)
 	s.Equal(len(s.host.Peerstore().Peers()), 2)
 }
+
+func (s *LoadPeersTestSuite) Test_LoadPeers_AddsAllPeerAddresses() {
+	newP1, _ := peer.AddrInfoFromString("/dns4/relayer2/tcp/9001/p2p/QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT")
+	secondAddr, _ := peer.AddrInfoFromString("/ip4/10.0.0.2/tcp/9001/p2p/QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT")
+	newP1.Addrs = append(newP1.Addrs, secondAddr.Addrs...)
+
+	p2p.LoadPeers(s.host, []*peer.AddrInfo{newP1})
+
+	s.ElementsMatch(s.host.Peerstore().Addrs(newP1.ID), newP1.Addrs)
+}
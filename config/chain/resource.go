@@ -0,0 +1,46 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package chain
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type RawResourceConfig struct {
+	ResourceID string `mapstructure:"resourceID"`
+	Decimals   uint8  `mapstructure:"decimals"`
+	// Collection is the substrate NFT pallet collection the resource maps to
+	Collection *uint32 `mapstructure:"collection"`
+}
+
+type ResourceConfig struct {
+	ResourceID [32]byte
+	Decimals   uint8
+	Collection *uint32
+}
+
+// NewResourceConfigs parses raw resource configuration
+func NewResourceConfigs(rawResources []RawResourceConfig) ([]ResourceConfig, error) {
+	var resources []ResourceConfig
+	for _, r := range rawResources {
+		resourceBytes, err := hexutil.Decode(r.ResourceID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resourceID %s: %w", r.ResourceID, err)
+		}
+		if len(resourceBytes) != 32 {
+			return nil, fmt.Errorf("invalid resourceID %s length", r.ResourceID)
+		}
+
+		var resourceID [32]byte
+		copy(resourceID[:], resourceBytes)
+		resources = append(resources, ResourceConfig{
+			ResourceID: resourceID,
+			Decimals:   r.Decimals,
+			Collection: r.Collection,
+		})
+	}
+	return resources, nil
+}
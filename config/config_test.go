@@ -103,6 +103,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV() {
 				FrostKeysharePath:       "/cfg/keyshares/0-frost.keyshare",
 				Key:                     "test-pk",
 				CommHealthCheckInterval: 5 * time.Minute,
+				KeyshareCheckInterval:   10 * time.Minute,
 			},
 			BullyConfig: relayer.BullyConfig{
 				PingWaitTime:     1 * time.Second,
@@ -220,6 +221,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV_RandomOrder() {
 				FrostKeysharePath:       "/cfg/keyshares/0-frost.keyshare",
 				Key:                     "test-pk",
 				CommHealthCheckInterval: 5 * time.Minute,
+				KeyshareCheckInterval:   10 * time.Minute,
 			},
 			BullyConfig: relayer.BullyConfig{
 				PingWaitTime:     1 * time.Second,
@@ -453,6 +455,33 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 			errorMsg:   "topology configuration encryption key not provided",
 			outConfig:  config.Config{},
 		},
+		{
+			name: "missing keyshare encryption passphrase",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						KeyshareEncryption: relayer.KeyshareEncryptionConfig{
+							Wrapper: "passphrase",
+						},
+						Port: "2020",
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "chain1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "keyshare encryption passphrase not provided",
+			outConfig:  config.Config{},
+		},
 		{
 			name: "set default values in config",
 			inConfig: config.RawConfig{
@@ -496,6 +525,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 							Path:          "path",
 						},
 						CommHealthCheckInterval: 5 * time.Minute,
+						KeyshareCheckInterval:   10 * time.Minute,
 					},
 					BullyConfig: relayer.BullyConfig{
 						PingWaitTime:     1 * time.Second,
@@ -535,6 +565,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 						KeysharePath:            "./share.key",
 						Key:                     "./key.pk",
 						CommHealthCheckInterval: "10m",
+						KeyRefreshInterval:      "24h",
 					},
 					BullyConfig: relayer.RawBullyConfig{
 						PingWaitTime:     "1s",
@@ -574,6 +605,8 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 							Path:          "path",
 						},
 						CommHealthCheckInterval: 10 * time.Minute,
+						KeyshareCheckInterval:   10 * time.Minute,
+						KeyRefreshInterval:      24 * time.Hour,
 					},
 					BullyConfig: relayer.BullyConfig{
 						PingWaitTime:     time.Second,
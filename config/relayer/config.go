@@ -29,8 +29,11 @@ type MpcRelayerConfig struct {
 	Port                    uint16
 	KeysharePath            string
 	FrostKeysharePath       string
+	KeyshareEncryption      KeyshareEncryptionConfig
 	Key                     string
 	CommHealthCheckInterval time.Duration
+	KeyshareCheckInterval   time.Duration
+	KeyRefreshInterval      time.Duration
 }
 
 type BullyConfig struct {
@@ -45,6 +48,57 @@ type TopologyConfiguration struct {
 	EncryptionKey string `mapstructure:"EncryptionKey" json:"encryptionKey"`
 	Url           string `mapstructure:"Url" json:"url"`
 	Path          string `mapstructure:"Path" json:"path"`
+	// AdminKeys are comma separated addresses of admins that sign topology manifests
+	AdminKeys      string `mapstructure:"AdminKeys" json:"adminKeys"`
+	AdminThreshold string `mapstructure:"AdminThreshold" json:"adminThreshold"`
+	// File is a local file with encrypted topology that is tried before remote sources
+	File string `mapstructure:"File" json:"file"`
+	// Mirrors are comma separated urls tried after Url
+	Mirrors string `mapstructure:"Mirrors" json:"mirrors"`
+	// IpfsGateways are comma separated gateways used to fetch topology with IpfsCid
+	IpfsCid      string `mapstructure:"IpfsCid" json:"ipfsCid"`
+	IpfsGateways string `mapstructure:"IpfsGateways" json:"ipfsGateways"`
+	// RegistryAddress is the address of the contract that returns encrypted topology
+	// from its topology() function on the RegistryEndpoint chain
+	RegistryEndpoint string `mapstructure:"RegistryEndpoint" json:"registryEndpoint"`
+	RegistryAddress  string `mapstructure:"RegistryAddress" json:"registryAddress"`
+}
+
+type KeyshareEncryptionConfig struct {
+	// Wrapper is the key wrapper used to encrypt keyshares at rest: passphrase, file or pkcs11.
+	// Keyshares are stored unencrypted if it is not set.
+	Wrapper    string `mapstructure:"Wrapper" json:"wrapper"`
+	Passphrase string `mapstructure:"Passphrase" json:"passphrase"`
+	// MasterKeyPath is a file with hex encoded 32 byte master key
+	MasterKeyPath string `mapstructure:"MasterKeyPath" json:"masterKeyPath"`
+	// Pkcs11Library is the PKCS#11 module used to wrap keys with the AES key
+	// labeled Pkcs11KeyLabel on the token labeled Pkcs11TokenLabel
+	Pkcs11Library    string `mapstructure:"Pkcs11Library" json:"pkcs11Library"`
+	Pkcs11TokenLabel string `mapstructure:"Pkcs11TokenLabel" json:"pkcs11TokenLabel"`
+	Pkcs11Pin        string `mapstructure:"Pkcs11Pin" json:"pkcs11Pin"`
+	Pkcs11KeyLabel   string `mapstructure:"Pkcs11KeyLabel" json:"pkcs11KeyLabel"`
+}
+
+func (c KeyshareEncryptionConfig) Validate() error {
+	switch c.Wrapper {
+	case "":
+		return nil
+	case "passphrase":
+		if c.Passphrase == "" {
+			return errors.New("keyshare encryption passphrase not provided")
+		}
+	case "file":
+		if c.MasterKeyPath == "" {
+			return errors.New("keyshare encryption master key path not provided")
+		}
+	case "pkcs11":
+		if c.Pkcs11Library == "" || c.Pkcs11TokenLabel == "" || c.Pkcs11KeyLabel == "" {
+			return errors.New("keyshare encryption pkcs11 library, token label and key label have to be provided")
+		}
+	default:
+		return fmt.Errorf("unsupported keyshare encryption wrapper %s", c.Wrapper)
+	}
+	return nil
 }
 
 type UploaderConfig struct {
@@ -66,12 +120,15 @@ type RawRelayerConfig struct {
 }
 
 type RawMpcRelayerConfig struct {
-	KeysharePath            string                `mapstructure:"KeysharePath" json:"keysharePath"`
-	FrostKeysharePath       string                `mapstructure:"FrostKeysharePath" json:"frostKeysharePath"`
-	Key                     string                `mapstructure:"Key" json:"key"`
-	Port                    string                `mapstructure:"Port" json:"port" default:"9000"`
-	TopologyConfiguration   TopologyConfiguration `mapstructure:"TopologyConfiguration" json:"topologyConfiguration"`
-	CommHealthCheckInterval string                `mapstructure:"CommHealthCheckInterval" json:"commHealthCheckInterval" default:"5m"`
+	KeysharePath            string                   `mapstructure:"KeysharePath" json:"keysharePath"`
+	FrostKeysharePath       string                   `mapstructure:"FrostKeysharePath" json:"frostKeysharePath"`
+	KeyshareEncryption      KeyshareEncryptionConfig `mapstructure:"KeyshareEncryption" json:"keyshareEncryption"`
+	Key                     string                   `mapstructure:"Key" json:"key"`
+	Port                    string                   `mapstructure:"Port" json:"port" default:"9000"`
+	TopologyConfiguration   TopologyConfiguration    `mapstructure:"TopologyConfiguration" json:"topologyConfiguration"`
+	CommHealthCheckInterval string                   `mapstructure:"CommHealthCheckInterval" json:"commHealthCheckInterval" default:"5m"`
+	KeyshareCheckInterval   string                   `mapstructure:"KeyshareCheckInterval" json:"keyshareCheckInterval" default:"10m"`
+	KeyRefreshInterval      string                   `mapstructure:"KeyRefreshInterval" json:"keyRefreshInterval"`
 }
 
 type RawBullyConfig struct {
@@ -86,13 +143,15 @@ func (c *RawRelayerConfig) Validate() error {
 	if c.MpcConfig.TopologyConfiguration.EncryptionKey == "" {
 		return errors.New("topology configuration encryption key not provided")
 	}
-	if c.MpcConfig.TopologyConfiguration.Url == "" {
-		return errors.New("topology configuration url not provided")
+	topologyConfig := c.MpcConfig.TopologyConfiguration
+	if topologyConfig.Url == "" && topologyConfig.Mirrors == "" && topologyConfig.File == "" &&
+		topologyConfig.IpfsCid == "" && topologyConfig.RegistryAddress == "" {
+		return errors.New("topology configuration source not provided")
 	}
 	if c.MpcConfig.TopologyConfiguration.Path == "" {
 		return errors.New("topology configuration path not provided")
 	}
-	return nil
+	return c.MpcConfig.KeyshareEncryption.Validate()
 }
 
 // NewRelayerConfig parses RawRelayerConfig into RelayerConfig
@@ -147,6 +206,7 @@ func parseMpcConfig(rawConfig RawRelayerConfig) (MpcRelayerConfig, error) {
 	mpcConfig.TopologyConfiguration = rawConfig.MpcConfig.TopologyConfiguration
 	mpcConfig.KeysharePath = rawConfig.MpcConfig.KeysharePath
 	mpcConfig.FrostKeysharePath = rawConfig.MpcConfig.FrostKeysharePath
+	mpcConfig.KeyshareEncryption = rawConfig.MpcConfig.KeyshareEncryption
 	mpcConfig.Key = rawConfig.MpcConfig.Key
 
 	duration, err := time.ParseDuration(rawConfig.MpcConfig.CommHealthCheckInterval)
@@ -155,6 +215,23 @@ func parseMpcConfig(rawConfig RawRelayerConfig) (MpcRelayerConfig, error) {
 	}
 	mpcConfig.CommHealthCheckInterval = duration
 
+	keyshareCheckInterval, err := time.ParseDuration(rawConfig.MpcConfig.KeyshareCheckInterval)
+	if err != nil {
+		return MpcRelayerConfig{}, fmt.Errorf("unable to parse keyshare check interval time: %w", err)
+	}
+	mpcConfig.KeyshareCheckInterval = keyshareCheckInterval
+
+	if rawConfig.MpcConfig.KeyRefreshInterval != "" {
+		keyRefreshInterval, err := time.ParseDuration(rawConfig.MpcConfig.KeyRefreshInterval)
+		if err != nil {
+			return MpcRelayerConfig{}, fmt.Errorf("unable to parse key refresh interval time: %w", err)
+		}
+		if keyRefreshInterval < 0 {
+			return MpcRelayerConfig{}, fmt.Errorf("key refresh interval has to be positive")
+		}
+		mpcConfig.KeyRefreshInterval = keyRefreshInterval
+	}
+
 	return mpcConfig, nil
 }
 
@@ -4,7 +4,6 @@
 package substrate
 
 import (
-	"bytes"
 	"context"
 	"encoding/binary"
 	"errors"
@@ -119,33 +118,17 @@ type Account struct {
 	Balance substrateTypes.U128
 }
 
+// ConstructRecipientData encodes the recipient as XCM v3 MultiLocation
+// {parents: 0, interior: X1(AccountId32 {network: None, id: recipient})}
 func ConstructRecipientData(recipient []substrateTypes.U8) []byte {
-	rec := substrateTypes.MultiLocationV1{
-		Parents: 0,
-		Interior: substrateTypes.JunctionsV1{
-			IsX1: true,
-			X1: substrateTypes.JunctionV1{
-				IsAccountID32: true,
-				AccountID32NetworkID: substrateTypes.NetworkID{
-					IsAny: true,
-				},
-				AccountID: recipient,
-			},
-		},
+	encodedRecipient := []byte{
+		0, // parents
+		1, // X1 interior
+		1, // AccountId32 junction
+		0, // no network
 	}
-
-	encodedRecipient := bytes.NewBuffer([]byte{})
-	encoder := scale.NewEncoder(encodedRecipient)
-	_ = rec.Encode(*encoder)
-
-	recipientBytes := encodedRecipient.Bytes()
-	var finalRecipient []byte
-
-	// remove accountID size data
-	// this is a fix because the substrate decoder is not able to parse the data with extra data
-	// that represents size of the recipient byte array
-	finalRecipient = append(finalRecipient, recipientBytes[:4]...)
-	finalRecipient = append(finalRecipient, recipientBytes[5:]...)
-
-	return finalRecipient
+	for _, b := range recipient {
+		encodedRecipient = append(encodedRecipient, byte(b))
+	}
+	return encodedRecipient
 }
@@ -16,24 +16,25 @@ import (
 	"github.com/ChainSafe/sygma-relayer/chains/btc"
 	"github.com/ChainSafe/sygma-relayer/chains/btc/mempool"
 	"github.com/ChainSafe/sygma-relayer/chains/btc/uploader"
+	substrateClient "github.com/ChainSafe/sygma-relayer/chains/substrate/client"
 	substrateListener "github.com/ChainSafe/sygma-relayer/chains/substrate/listener"
 	substratePallet "github.com/ChainSafe/sygma-relayer/chains/substrate/pallet"
+	substrateRuntime "github.com/ChainSafe/sygma-relayer/chains/substrate/runtime"
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	propStore "github.com/ChainSafe/sygma-relayer/store"
 	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/refresh"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/listener"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/gas"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/transaction"
 	coreSubstrate "github.com/sygmaprotocol/sygma-core/chains/substrate"
-	substrateClient "github.com/sygmaprotocol/sygma-core/chains/substrate/client"
 	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
 	coreSubstrateListener "github.com/sygmaprotocol/sygma-core/chains/substrate/listener"
 	"github.com/sygmaprotocol/sygma-core/crypto/secp256k1"
 	"github.com/sygmaprotocol/sygma-core/observability"
 	"github.com/sygmaprotocol/sygma-core/relayer"
 	"github.com/sygmaprotocol/sygma-core/store"
-	"github.com/sygmaprotocol/sygma-core/store/lvldb"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p/core/crypto"
@@ -60,6 +61,7 @@ import (
 	"github.com/ChainSafe/sygma-relayer/chains/evm/executor"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
 	hubEventHandlers "github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/plugins"
 	"github.com/ChainSafe/sygma-relayer/comm/elector"
 	"github.com/ChainSafe/sygma-relayer/comm/p2p"
 	"github.com/ChainSafe/sygma-relayer/config"
@@ -83,7 +85,7 @@ func Run() error {
 		Threshold: "2",
 	})
 
-	db, err := lvldb.NewLvlDB(viper.GetString(config.BlockstoreFlagName))
+	db, err := propStore.NewLvlDB(viper.GetString(config.BlockstoreFlagName))
 	if err != nil {
 		panic(err)
 	}
@@ -107,9 +109,18 @@ func Run() error {
 	communication := p2p.NewCommunication(host, "p2p/sygma")
 	electorFactory := elector.NewCoordinatorElectorFactory(host, configuration.RelayerConfig.BullyConfig)
 	coordinator := tss.NewCoordinator(host, communication, electorFactory)
-	keyshareStore := keyshare.NewECDSAKeyshareStore(configuration.RelayerConfig.MpcConfig.KeysharePath)
-	frostKeyshareStore := keyshare.NewFrostKeyshareStore(configuration.RelayerConfig.MpcConfig.FrostKeysharePath)
+	keyWrapper, err := keyshare.NewKeyWrapper(configuration.RelayerConfig.MpcConfig.KeyshareEncryption)
+	panicOnError(err)
+	keyshareStore := keyshare.NewECDSAKeyshareStore(configuration.RelayerConfig.MpcConfig.KeysharePath, keyWrapper)
+	frostKeyshareStore := keyshare.NewFrostKeyshareStore(configuration.RelayerConfig.MpcConfig.FrostKeysharePath, keyWrapper)
+	// reading existing keyshares migrates them to encrypted storage
+	_, _ = keyshareStore.GetKeyshare()
+	_, _ = frostKeyshareStore.GetKeyshare()
+	keyshareVerifier := keyshare.NewVerifier()
+	quarantineStore := propStore.NewQuarantineStore(db)
 	propStore := propStore.NewPropStore(db)
+	err = propStore.MigrateIndexes()
+	panicOnError(err)
 
 	// wait until executions are done and then stop further executions before exiting
 	exitLock := &sync.RWMutex{}
@@ -132,7 +143,9 @@ func Run() error {
 	if err != nil {
 		panic(err)
 	}
+	keyRefresher := refresh.NewRefresher(coordinator, host, communication, keyshareStore, frostKeyshareStore, staticTopology{networkTopology}, sygmaMetrics)
 
+	handlerPlugins := plugins.NewRegistry()
 	msgChan := make(chan []*message.Message)
 	domains := make(map[uint8]relayer.RelayedChain)
 	for _, chainConfig := range configuration.ChainConfigs {
@@ -160,26 +173,9 @@ func Run() error {
 				bridgeContract := bridge.NewBridgeContract(client, bridgeAddress, t)
 
 				depositHandler := depositHandlers.NewETHDepositHandler(bridgeContract)
-				for _, handler := range config.Handlers {
-					switch handler.Type {
-					case "erc20", "native":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc20DepositHandler{})
-						}
-					case "permissionlessGeneric":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.PermissionlessGenericDepositHandler{})
-						}
-					case "erc721":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc721DepositHandler{})
-						}
-					case "erc1155":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc1155DepositHandler{})
-						}
-					}
-				}
+				transferMessageHandler := executor.NewTransferMessageHandler(nil, config.GenericPolicy, quarantineStore)
+				handlerPlugins.RegisterDepositHandlers(config.Handlers, depositHandler)
+				handlerPlugins.RegisterTransferHandlers(transferMessageHandler)
 				depositListener := events.NewListener(client)
 				tssListener := events.NewListener(client)
 				eventHandlers := make([]listener.EventHandler, 0)
@@ -189,7 +185,7 @@ func Run() error {
 				eventHandlers = append(eventHandlers, depositEventHandler)
 				eventHandlers = append(eventHandlers, hubEventHandlers.NewKeygenEventHandler(l, tssListener, coordinator, host, communication, keyshareStore, bridgeAddress, networkTopology.Threshold))
 				eventHandlers = append(eventHandlers, hubEventHandlers.NewFrostKeygenEventHandler(l, tssListener, coordinator, host, communication, frostKeyshareStore, frostAddress, networkTopology.Threshold))
-				eventHandlers = append(eventHandlers, hubEventHandlers.NewRefreshEventHandler(l, nil, nil, tssListener, coordinator, host, communication, connectionGate, keyshareStore, frostKeyshareStore, bridgeAddress))
+				eventHandlers = append(eventHandlers, hubEventHandlers.NewRefreshEventHandler(l, nil, nil, tssListener, coordinator, host, communication, connectionGate, keyshareStore, frostKeyshareStore, keyRefresher, bridgeAddress))
 				eventHandlers = append(eventHandlers, hubEventHandlers.NewRetryV1EventHandler(l, tssListener, depositHandler, propStore, bridgeAddress, *config.GeneralChainConfig.Id, config.BlockConfirmations, msgChan))
 				if config.Retry != "" {
 					eventHandlers = append(eventHandlers, hubEventHandlers.NewRetryV2EventHandler(l, tssListener, common.HexToAddress(config.Retry), *config.GeneralChainConfig.Id, msgChan))
@@ -198,8 +194,10 @@ func Run() error {
 
 				mh := message.NewMessageHandler()
 				mh.RegisterMessageHandler(retry.RetryMessageType, executor.NewRetryMessageHandler(depositEventHandler, client, propStore, config.BlockConfirmations, msgChan))
-				mh.RegisterMessageHandler(transfer.TransferMessageType, &executor.TransferMessageHandler{})
-				executor := executor.NewExecutor(host, communication, coordinator, bridgeContract, keyshareStore, exitLock, config.GasLimit.Uint64(), config.TransferGas)
+				mh.RegisterMessageHandler(transfer.TransferMessageType, transferMessageHandler)
+				gasEstimator := executor.NewProposalGasEstimator(client, bridgeContract, bridgeAddress, config.GasEstimationMargin, config.TransferGas)
+				keyshareVerifier.AddCheck(keyshare.NewECDSAPublicKeyCheck(*config.GeneralChainConfig.Id, bridgeContract, keyshareStore))
+				executor := executor.NewExecutor(host, communication, coordinator, bridgeContract, keyshareVerifier.ECDSAFetcher(*config.GeneralChainConfig.Id, keyshareStore), exitLock, config.GasLimit.Uint64(), config.TransferGas, gasEstimator, propStore)
 
 				startBlock, err := blockstore.GetStartBlock(*config.GeneralChainConfig.Id, config.StartBlock, config.GeneralChainConfig.LatestBlock, config.GeneralChainConfig.FreshStart)
 				if err != nil {
@@ -236,8 +234,10 @@ func Run() error {
 					panic(err)
 				}
 
-				substrateClient := substrateClient.NewSubstrateClient(conn, &keyPair, config.ChainID, config.Tip)
-				bridgePallet := substratePallet.NewPallet(substrateClient)
+				submitter := substrateClient.NewSubmitter(substrateClient.NewRPCConnection(conn), &keyPair, config.Tip, config.MaxTip, config.TipIncrease, config.EraPeriod)
+				bridgePallet := substratePallet.NewPallet(conn, submitter, config.ChainID)
+				runtimeGuard, err := substrateRuntime.NewRuntimeGuard(*config.GeneralChainConfig.Id, conn, sygmaMetrics)
+				panicOnError(err)
 
 				log.Info().Str("domain", config.String()).Msgf("Registering substrate domain")
 
@@ -246,7 +246,8 @@ func Run() error {
 				depositHandler.RegisterDepositHandler(transfer.FungibleTransfer, substrateListener.FungibleTransferHandler)
 				eventHandlers := make([]coreSubstrateListener.EventHandler, 0)
 				depositEventHandler := substrateListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgChan, conn)
-				eventHandlers = append(eventHandlers, substrateListener.NewRetryEventHandler(l, conn, depositHandler, *config.GeneralChainConfig.Id, msgChan))
+				eventHandlers = append(eventHandlers, substrateListener.NewSystemUpdateEventHandler(conn, runtimeGuard))
+				eventHandlers = append(eventHandlers, substrateListener.NewRetryEventHandler(l, conn, *config.GeneralChainConfig.Id, msgChan))
 				eventHandlers = append(eventHandlers, depositEventHandler)
 				substrateListener := coreSubstrateListener.NewSubstrateListener(conn, eventHandlers, blockstore, sygmaMetrics, *config.GeneralChainConfig.Id, config.BlockRetryInterval, config.BlockInterval)
 
@@ -254,14 +255,15 @@ func Run() error {
 				mh.RegisterMessageHandler(transfer.TransferMessageType, &substrateExecutor.SubstrateMessageHandler{})
 				mh.RegisterMessageHandler(retry.RetryMessageType, substrateExecutor.NewRetryMessageHandler(depositEventHandler, conn, propStore, msgChan))
 
-				sExecutor := substrateExecutor.NewExecutor(host, communication, coordinator, bridgePallet, keyshareStore, conn, exitLock)
+				keyshareVerifier.AddCheck(keyshare.NewECDSAPublicKeyCheck(*config.GeneralChainConfig.Id, bridgePallet, keyshareStore))
+				sExecutor := substrateExecutor.NewExecutor(host, communication, coordinator, bridgePallet, keyshareVerifier.ECDSAFetcher(*config.GeneralChainConfig.Id, keyshareStore), conn, exitLock, runtimeGuard, propStore)
 
 				startBlock, err := blockstore.GetStartBlock(*config.GeneralChainConfig.Id, config.StartBlock, config.GeneralChainConfig.LatestBlock, config.GeneralChainConfig.FreshStart)
 				if err != nil {
 					panic(err)
 				}
 				if startBlock == nil {
-					head, err := substrateClient.LatestBlock()
+					head, err := bridgePallet.LatestBlock()
 					if err != nil {
 						panic(err)
 					}
@@ -294,9 +296,12 @@ func Run() error {
 
 				l := log.With().Str("chain", fmt.Sprintf("%v", config.GeneralChainConfig.Name)).Uint8("domainID", *config.GeneralChainConfig.Id)
 				resources := make(map[[32]byte]btcConfig.Resource)
+				taprootResources := make([]keyshare.TaprootResource, 0)
 				for _, resource := range config.Resources {
 					resources[resource.ResourceID] = resource
+					taprootResources = append(taprootResources, keyshare.TaprootResource{Address: resource.Address, Tweak: resource.Tweak})
 				}
+				keyshareVerifier.AddCheck(keyshare.NewFrostPublicKeyCheck(*config.GeneralChainConfig.Id, &config.Network, taprootResources, frostKeyshareStore))
 				depositHandler := &btcListener.BtcDepositHandler{}
 				depositEventHandler := btcListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgChan, conn, resources, config.FeeAddress)
 				eventHandlers := make([]btcListener.EventHandler, 0)
@@ -314,7 +319,7 @@ func Run() error {
 					host,
 					communication,
 					coordinator,
-					frostKeyshareStore,
+					keyshareVerifier.FrostFetcher(*config.GeneralChainConfig.Id, frostKeyshareStore),
 					conn,
 					mempool,
 					resources,
@@ -332,6 +337,16 @@ func Run() error {
 	}
 
 	go jobs.StartCommunicationHealthCheckJob(host, configuration.RelayerConfig.MpcConfig.CommHealthCheckInterval, sygmaMetrics)
+	if configuration.RelayerConfig.MpcConfig.KeyRefreshInterval > 0 {
+		go jobs.StartKeyRefreshJob(ctx, keyRefresher, configuration.RelayerConfig.MpcConfig.KeyRefreshInterval)
+	}
+	domainIDs := make([]uint8, 0, len(domains))
+	for id := range domains {
+		domainIDs = append(domainIDs, id)
+	}
+	go jobs.StartStuckProposalsJob(ctx, propStore, domainIDs, jobs.StuckCheckInterval)
+	keyshareVerifier.Verify()
+	go keyshareVerifier.Start(ctx, configuration.RelayerConfig.MpcConfig.KeyshareCheckInterval, sygmaMetrics)
 	r := relayer.NewRelayer(domains, sygmaMetrics)
 
 	go r.Start(ctx, msgChan)
@@ -354,3 +369,12 @@ func panicOnError(err error) {
 		panic(err)
 	}
 }
+
+// staticTopology provides the hardcoded example topology
+type staticTopology struct {
+	topology *topology.NetworkTopology
+}
+
+func (t staticTopology) Topology() (*topology.NetworkTopology, error) {
+	return t.topology, nil
+}
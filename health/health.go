@@ -6,13 +6,60 @@ package health
 import (
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/rs/zerolog/log"
 )
 
+// HealthCheck reports an error if a relayer component is unhealthy
+type HealthCheck interface {
+	Health() error
+}
+
+// Checks is a set of health checks that can be extended after the health endpoint is started
+type Checks struct {
+	lock   sync.RWMutex
+	checks []HealthCheck
+}
+
+func NewChecks(checks ...HealthCheck) *Checks {
+	return &Checks{
+		checks: checks,
+	}
+}
+
+// Add adds health check to the set
+func (c *Checks) Add(check HealthCheck) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.checks = append(c.checks, check)
+}
+
+// Health returns error of the first failing check in the set
+func (c *Checks) Health() error {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	for _, check := range c.checks {
+		err := check.Health()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // StartHealthEndpoint starts /health endpoint on provided port that returns ok on invocation
-func StartHealthEndpoint(port uint16) {
+// if all health checks pass and service unavailable with check errors otherwise
+func StartHealthEndpoint(port uint16, checks ...HealthCheck) {
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		for _, check := range checks {
+			err := check.Health()
+			if err != nil {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+		}
 		_, _ = w.Write([]byte("ok"))
 	})
 
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./jobs/stuck.go
+
+// Package mock_jobs is a generated GoMock package.
+package mock_jobs
+
+import (
+	reflect "reflect"
+
+	store "github.com/ChainSafe/sygma-relayer/store"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProposalFetcher is a mock of ProposalFetcher interface.
+type MockProposalFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockProposalFetcherMockRecorder
+}
+
+// MockProposalFetcherMockRecorder is the mock recorder for MockProposalFetcher.
+type MockProposalFetcherMockRecorder struct {
+	mock *MockProposalFetcher
+}
+
+// NewMockProposalFetcher creates a new mock instance.
+func NewMockProposalFetcher(ctrl *gomock.Controller) *MockProposalFetcher {
+	mock := &MockProposalFetcher{ctrl: ctrl}
+	mock.recorder = &MockProposalFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProposalFetcher) EXPECT() *MockProposalFetcherMockRecorder {
+	return m.recorder
+}
+
+// Proposals mocks base method.
+func (m *MockProposalFetcher) Proposals(query store.ProposalQuery, cursor string, limit int) ([]*store.ProposalRecord, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Proposals", query, cursor, limit)
+	ret0, _ := ret[0].([]*store.ProposalRecord)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Proposals indicates an expected call of Proposals.
+func (mr *MockProposalFetcherMockRecorder) Proposals(query, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Proposals", reflect.TypeOf((*MockProposalFetcher)(nil).Proposals), query, cursor, limit)
+}
@@ -0,0 +1,41 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type KeyRefresher interface {
+	Refresh(sessionID string) error
+}
+
+// StartKeyRefreshJob proactively refreshes keyshares on every interval. Refreshes are aligned
+// to multiples of the interval so that all relayers start the refresh with the same session.
+func StartKeyRefreshJob(ctx context.Context, refresher KeyRefresher, interval time.Duration) {
+	for {
+		next := time.Now().Truncate(interval).Add(interval)
+		select {
+		case <-time.After(time.Until(next)):
+			{
+				log.Info().Msgf("Starting proactive key refresh")
+				err := refresher.Refresh(refreshSessionID(next))
+				if err != nil {
+					log.Err(err).Msgf("Failed proactive key refresh")
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refreshSessionID returns session ID of the proactive key refresh scheduled at the time
+func refreshSessionID(at time.Time) string {
+	return fmt.Sprintf("proactive-refresh-%d", at.Unix())
+}
@@ -0,0 +1,48 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/rs/zerolog/log"
+)
+
+// StuckCheckInterval is the interval on which stuck proposals are checked
+var StuckCheckInterval = time.Minute * 10
+
+type ProposalFetcher interface {
+	Proposals(query store.ProposalQuery, cursor string, limit int) ([]*store.ProposalRecord, string, error)
+}
+
+// StartStuckProposalsJob warns about proposals to the domains that are pending
+// for longer than retry.StuckPeriod on every interval until the context is done
+func StartStuckProposalsJob(ctx context.Context, fetcher ProposalFetcher, domains []uint8, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			{
+				for _, domain := range domains {
+					stuckProposals, err := retry.StuckProposals(fetcher, domain)
+					if err != nil {
+						log.Err(err).Msgf("Failed fetching stuck proposals to domain %d", domain)
+						continue
+					}
+					for _, p := range stuckProposals {
+						log.Warn().Str("messageID", p.MessageID).Msgf(
+							"Proposal %d-%d-%d pending since %s, retry its deposit to execute it",
+							p.Source, p.Destination, p.DepositNonce, p.StatusChangedAt())
+					}
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/jobs"
+	mock_jobs "github.com/ChainSafe/sygma-relayer/jobs/mock"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type StuckProposalsTestSuite struct {
+	suite.Suite
+
+	mockFetcher *mock_jobs.MockProposalFetcher
+}
+
+func TestRunStuckProposalsTestSuite(t *testing.T) {
+	suite.Run(t, new(StuckProposalsTestSuite))
+}
+
+func (s *StuckProposalsTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockFetcher = mock_jobs.NewMockProposalFetcher(ctrl)
+}
+
+func (s *StuckProposalsTestSuite) Test_StartStuckProposalsJob_ChecksEachDomain() {
+	checked := make(chan struct{})
+	gomock.InOrder(
+		s.mockFetcher.EXPECT().Proposals(gomock.Any(), "", gomock.Any()).DoAndReturn(func(query store.ProposalQuery, cursor string, limit int) ([]*store.ProposalRecord, string, error) {
+			s.Equal(query.Destination, uint8(1))
+			return []*store.ProposalRecord{{Source: 2, Destination: 1, DepositNonce: 1}}, "", nil
+		}),
+		s.mockFetcher.EXPECT().Proposals(gomock.Any(), "", gomock.Any()).DoAndReturn(func(query store.ProposalQuery, cursor string, limit int) ([]*store.ProposalRecord, string, error) {
+			s.Equal(query.Destination, uint8(2))
+			close(checked)
+			return []*store.ProposalRecord{}, "", nil
+		}),
+	)
+	// the job can tick again before it stops
+	s.mockFetcher.EXPECT().Proposals(gomock.Any(), "", gomock.Any()).Return([]*store.ProposalRecord{}, "", nil).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		jobs.StartStuckProposalsJob(ctx, s.mockFetcher, []uint8{1, 2}, time.Millisecond*10)
+		close(done)
+	}()
+
+	select {
+	case <-checked:
+	case <-time.After(time.Second):
+		s.FailNow("job did not check domains")
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("job did not stop after context is done")
+	}
+}
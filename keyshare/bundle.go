@@ -0,0 +1,200 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+
+	"github.com/ChainSafe/sygma-relayer/topology"
+)
+
+const (
+	bundleVersion = 1
+
+	// ExportKeyLength is the length of the key that encrypts keyshare bundles
+	ExportKeyLength = dataKeyLength
+)
+
+// Bundle is a backup of relayer keyshares together with the topology snapshot
+// that is used to move keyshares between hosts
+type Bundle struct {
+	PeerID    peer.ID
+	CreatedAt time.Time
+	// ECDSAKeyshare and FrostKeyshare are unencrypted keyshare files
+	ECDSAKeyshare []byte `json:",omitempty"`
+	FrostKeyshare []byte `json:",omitempty"`
+	Topology      *topology.NetworkTopology
+}
+
+// encryptedBundle is the exported bundle encrypted with AES-GCM.
+// Checksum is the hex encoded sha256 of the encrypted bundle and detects
+// corrupted exports before decryption is attempted.
+type encryptedBundle struct {
+	Version  int    `json:"version"`
+	Checksum string `json:"checksum"`
+	Bundle   []byte `json:"bundle"`
+}
+
+// NewBundle reads keyshares and topology of the relayer with the peer ID.
+// Keyshares that do not exist yet are skipped.
+func NewBundle(
+	peerID peer.ID,
+	ecdsaStore *ECDSAKeyshareStore,
+	frostStore *FrostKeyshareStore,
+	topologyStore *topology.TopologyStore,
+) (Bundle, error) {
+	bundle := Bundle{
+		PeerID:    peerID,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	var err error
+	bundle.ECDSAKeyshare, err = ecdsaStore.file.read()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return bundle, err
+	}
+	bundle.FrostKeyshare, err = frostStore.file.read()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return bundle, err
+	}
+	bundle.Topology, err = topologyStore.Topology()
+	if err != nil {
+		return bundle, fmt.Errorf("error on reading topology: %w", err)
+	}
+
+	return bundle, bundle.Validate(peerID)
+}
+
+// Validate checks that the bundle contains keyshares and topology of the peer
+func (b Bundle) Validate(peerID peer.ID) error {
+	if b.PeerID != peerID {
+		return fmt.Errorf("bundle belongs to peer %s instead of %s", b.PeerID, peerID)
+	}
+	if b.ECDSAKeyshare == nil && b.FrostKeyshare == nil {
+		return fmt.Errorf("bundle contains no keyshares")
+	}
+	if b.Topology == nil || !b.Topology.IsAllowedPeer(peerID) {
+		return fmt.Errorf("peer %s is not part of the bundle topology", peerID)
+	}
+
+	if b.ECDSAKeyshare != nil {
+		k, err := decodeECDSAKeyshare(b.ECDSAKeyshare)
+		if err != nil {
+			return err
+		}
+		if !containsPeer(k.Peers, peerID) {
+			return fmt.Errorf("peer %s is not part of the ecdsa keyshare committee", peerID)
+		}
+	}
+	if b.FrostKeyshare != nil {
+		k, err := decodeFrostKeyshare(b.FrostKeyshare)
+		if err != nil {
+			return err
+		}
+		if k.Key.ID != party.ID(peerID.String()) || !containsPeer(k.Peers, peerID) {
+			return fmt.Errorf("frost keyshare does not belong to peer %s", peerID)
+		}
+	}
+	return nil
+}
+
+// Install stores topology and keyshares from the bundle. Keyshares are encrypted
+// with the key wrapper of the stores and replaced keyshares are kept in history.
+func (b Bundle) Install(
+	ecdsaStore *ECDSAKeyshareStore,
+	frostStore *FrostKeyshareStore,
+	topologyStore *topology.TopologyStore,
+) error {
+	err := topologyStore.StoreTopology(b.Topology)
+	if err != nil {
+		return fmt.Errorf("error on storing topology: %w", err)
+	}
+
+	if b.ECDSAKeyshare != nil {
+		err = ecdsaStore.file.write(b.ECDSAKeyshare)
+		if err != nil {
+			return fmt.Errorf("error on storing ecdsa keyshare: %w", err)
+		}
+	}
+	if b.FrostKeyshare != nil {
+		err = frostStore.file.write(b.FrostKeyshare)
+		if err != nil {
+			return fmt.Errorf("error on storing frost keyshare: %w", err)
+		}
+	}
+	return nil
+}
+
+// NewExportKey returns random key used to encrypt the bundle
+func NewExportKey() ([]byte, error) {
+	key := make([]byte, ExportKeyLength)
+	_, err := rand.Read(key)
+	return key, err
+}
+
+// EncryptBundle encrypts the bundle with the export key
+func EncryptBundle(bundle Bundle, key []byte) ([]byte, error) {
+	pt, err := json.Marshal(&bundle)
+	if err != nil {
+		return nil, err
+	}
+	ct, err := seal(key, pt, bundleAdditionalData(bundleVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(ct)
+	return json.Marshal(&encryptedBundle{
+		Version:  bundleVersion,
+		Checksum: hex.EncodeToString(checksum[:]),
+		Bundle:   ct,
+	})
+}
+
+// DecryptBundle verifies the checksum of the encrypted bundle and decrypts it with the export key
+func DecryptBundle(data []byte, key []byte) (Bundle, error) {
+	bundle := Bundle{}
+	eb := encryptedBundle{}
+	err := json.Unmarshal(data, &eb)
+	if err != nil {
+		return bundle, fmt.Errorf("error on unmarshaling bundle: %w", err)
+	}
+	if eb.Version != bundleVersion {
+		return bundle, fmt.Errorf("unsupported bundle version %d", eb.Version)
+	}
+	checksum := sha256.Sum256(eb.Bundle)
+	if hex.EncodeToString(checksum[:]) != eb.Checksum {
+		return bundle, fmt.Errorf("bundle checksum mismatch")
+	}
+
+	pt, err := open(key, eb.Bundle, bundleAdditionalData(eb.Version))
+	if err != nil {
+		return bundle, fmt.Errorf("error on decrypting bundle, invalid export key: %w", err)
+	}
+	err = json.Unmarshal(pt, &bundle)
+	return bundle, err
+}
+
+func bundleAdditionalData(version int) []byte {
+	return []byte(fmt.Sprintf("sygma-keyshare-bundle-%d", version))
+}
+
+func containsPeer(peers []peer.ID, peerID peer.ID) bool {
+	for _, p := range peers {
+		if p == peerID {
+			return true
+		}
+	}
+	return false
+}
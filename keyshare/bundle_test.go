@@ -0,0 +1,164 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/topology"
+)
+
+var (
+	testPeerID, _  = peer.Decode("QmcvEg7jGvuxdsUFRUiE4VdrL2P1Yeju5L83BsJvvXz7zX")
+	otherPeerID, _ = peer.Decode("QmU9bdPZxLiF3miGGtjtA5CXYcbneKk1Co42fXrZ7KAnXj")
+)
+
+type BundleTestSuite struct {
+	suite.Suite
+	dir           string
+	ecdsaStore    *keyshare.ECDSAKeyshareStore
+	frostStore    *keyshare.FrostKeyshareStore
+	topologyStore *topology.TopologyStore
+}
+
+func TestRunBundleTestSuite(t *testing.T) {
+	suite.Run(t, new(BundleTestSuite))
+}
+
+func (s *BundleTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.ecdsaStore = keyshare.NewECDSAKeyshareStore(s.copyFile("../tss/test/keyshares/0.keyshare"), nil)
+	s.frostStore = keyshare.NewFrostKeyshareStore(s.copyFile("../tss/test/keyshares/0-frost.keyshare"), nil)
+
+	peers := []*peer.AddrInfo{}
+	for _, address := range []string{
+		"/ip4/127.0.0.1/tcp/4000/p2p/QmcvEg7jGvuxdsUFRUiE4VdrL2P1Yeju5L83BsJvvXz7zX",
+		"/ip4/127.0.0.1/tcp/4001/p2p/QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT",
+		"/ip4/127.0.0.1/tcp/4002/p2p/QmYAYuLUPNwYEBYJaKHcE7NKjUhiUV8txx2xDXHvcYa1xK",
+	} {
+		p, _ := peer.AddrInfoFromString(address)
+		peers = append(peers, p)
+	}
+	s.topologyStore = topology.NewTopologyStore(fmt.Sprintf("%s/topology.json", s.dir))
+	_ = s.topologyStore.StoreTopology(&topology.NetworkTopology{
+		Peers:     peers,
+		Threshold: 1,
+	})
+}
+
+func (s *BundleTestSuite) copyFile(path string) string {
+	data, err := os.ReadFile(path)
+	s.Require().Nil(err)
+	f, err := os.CreateTemp(s.dir, "*.keyshare")
+	s.Require().Nil(err)
+	defer f.Close()
+	_, err = f.Write(data)
+	s.Require().Nil(err)
+	return f.Name()
+}
+
+func (s *BundleTestSuite) Test_ExportAndImport() {
+	bundle, err := keyshare.NewBundle(testPeerID, s.ecdsaStore, s.frostStore, s.topologyStore)
+	s.Nil(err)
+	key, _ := keyshare.NewExportKey()
+	data, err := keyshare.EncryptBundle(bundle, key)
+	s.Nil(err)
+
+	importedBundle, err := keyshare.DecryptBundle(data, key)
+	s.Nil(err)
+	s.Nil(importedBundle.Validate(testPeerID))
+
+	wrapper, _ := keyshare.NewPassphraseKeyWrapper([]byte("passphrase"), testKDFParams)
+	ecdsaStore := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("%s/imported.keyshare", s.dir), wrapper)
+	frostStore := keyshare.NewFrostKeyshareStore(fmt.Sprintf("%s/imported-frost.keyshare", s.dir), wrapper)
+	topologyStore := topology.NewTopologyStore(fmt.Sprintf("%s/imported-topology.json", s.dir))
+	err = importedBundle.Install(ecdsaStore, frostStore, topologyStore)
+	s.Nil(err)
+
+	ecdsaKey, _ := s.ecdsaStore.GetKeyshare()
+	importedECDSAKey, err := ecdsaStore.GetKeyshare()
+	s.Nil(err)
+	s.Equal(importedECDSAKey.PublicKey(), ecdsaKey.PublicKey())
+	frostKey, _ := s.frostStore.GetKeyshare()
+	importedFrostKey, err := frostStore.GetKeyshare()
+	s.Nil(err)
+	s.Equal(importedFrostKey.PublicKey(), frostKey.PublicKey())
+	topology, _ := s.topologyStore.Topology()
+	importedTopology, err := topologyStore.Topology()
+	s.Nil(err)
+	s.Equal(importedTopology, topology)
+
+	encrypted, _ := os.ReadFile(fmt.Sprintf("%s/imported.keyshare", s.dir))
+	s.NotContains(string(encrypted), "ECDSAPub")
+}
+
+func (s *BundleTestSuite) Test_ExportWithoutFrostKeyshare() {
+	frostStore := keyshare.NewFrostKeyshareStore(fmt.Sprintf("%s/missing-frost.keyshare", s.dir), nil)
+
+	bundle, err := keyshare.NewBundle(testPeerID, s.ecdsaStore, frostStore, s.topologyStore)
+
+	s.Nil(err)
+	s.NotNil(bundle.ECDSAKeyshare)
+	s.Nil(bundle.FrostKeyshare)
+}
+
+func (s *BundleTestSuite) Test_ExportInvalidPeer() {
+	_, err := keyshare.NewBundle(otherPeerID, s.ecdsaStore, s.frostStore, s.topologyStore)
+
+	s.NotNil(err)
+}
+
+func (s *BundleTestSuite) Test_ImportInvalidPeer() {
+	bundle, _ := keyshare.NewBundle(testPeerID, s.ecdsaStore, s.frostStore, s.topologyStore)
+
+	err := bundle.Validate(otherPeerID)
+
+	s.NotNil(err)
+}
+
+func (s *BundleTestSuite) Test_ImportInvalidKey() {
+	bundle, _ := keyshare.NewBundle(testPeerID, s.ecdsaStore, s.frostStore, s.topologyStore)
+	key, _ := keyshare.NewExportKey()
+	data, _ := keyshare.EncryptBundle(bundle, key)
+	otherKey, _ := keyshare.NewExportKey()
+
+	_, err := keyshare.DecryptBundle(data, otherKey)
+
+	s.NotNil(err)
+}
+
+func (s *BundleTestSuite) Test_ImportCorruptedBundle() {
+	bundle, _ := keyshare.NewBundle(testPeerID, s.ecdsaStore, s.frostStore, s.topologyStore)
+	key, _ := keyshare.NewExportKey()
+	data, _ := keyshare.EncryptBundle(bundle, key)
+	encrypted := make(map[string]interface{})
+	_ = json.Unmarshal(data, &encrypted)
+	encrypted["checksum"] = "00"
+	data, _ = json.Marshal(encrypted)
+
+	_, err := keyshare.DecryptBundle(data, key)
+
+	s.EqualError(err, "bundle checksum mismatch")
+}
+
+func (s *BundleTestSuite) Test_ImportWithSplitKey() {
+	bundle, _ := keyshare.NewBundle(testPeerID, s.ecdsaStore, s.frostStore, s.topologyStore)
+	key, _ := keyshare.NewExportKey()
+	data, _ := keyshare.EncryptBundle(bundle, key)
+	parts, err := keyshare.SplitSecret(key, 3, 2)
+	s.Nil(err)
+
+	combinedKey, err := keyshare.CombineSecret([][]byte{parts[2], parts[0]})
+	s.Nil(err)
+	_, err = keyshare.DecryptBundle(data, combinedKey)
+
+	s.Nil(err)
+}
@@ -6,10 +6,12 @@ package keyshare
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
@@ -19,6 +21,8 @@ type ECDSAKeyshare struct {
 	Key       keygen.LocalPartySaveData
 	Threshold int
 	Peers     []peer.ID
+	// SessionID is the keygen or resharing session that generated the keyshare
+	SessionID string `json:",omitempty"`
 }
 
 func NewECDSAKeyshare(key keygen.LocalPartySaveData, threshold int, peers []peer.ID) ECDSAKeyshare {
@@ -29,14 +33,24 @@ func NewECDSAKeyshare(key keygen.LocalPartySaveData, threshold int, peers []peer
 	}
 }
 
+// PublicKey returns hex encoded MPC address of the keyshare
+func (k ECDSAKeyshare) PublicKey() string {
+	if k.Key.ECDSAPub == nil {
+		return ""
+	}
+	return crypto.PubkeyToAddress(*k.Key.ECDSAPub.ToBtcecPubKey().ToECDSA()).Hex()
+}
+
 type ECDSAKeyshareStore struct {
 	mu   sync.Mutex
-	path string
+	file *keyshareFile
 }
 
-func NewECDSAKeyshareStore(filePath string) *ECDSAKeyshareStore {
+// NewECDSAKeyshareStore creates keyshare store that encrypts keyshare with the key wrapper.
+// Keyshare is stored unencrypted if the key wrapper is nil.
+func NewECDSAKeyshareStore(filePath string, wrapper KeyWrapper) *ECDSAKeyshareStore {
 	return &ECDSAKeyshareStore{
-		path: filePath,
+		file: newKeyshareFile(filePath, wrapper),
 	}
 }
 
@@ -52,22 +66,15 @@ func (ks *ECDSAKeyshareStore) UnlockKeyshare() {
 	ks.mu.Unlock()
 }
 
-// StoreKeyshare stores keyshare generated by keygen or reshare into file and truncates
-// old keyshare.
+// StoreKeyshare atomically stores keyshare generated by keygen or reshare into file
+// and keeps the old keyshare in history.
 func (ks *ECDSAKeyshareStore) StoreKeyshare(keyshare ECDSAKeyshare) error {
-	f, err := os.OpenFile(ks.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
 	kb, err := json.Marshal(&keyshare)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.Write(kb)
-	return err
+	return ks.file.write(kb)
 }
 
 // GetECDSAKeyshare fetches current keyshare from file.
@@ -75,15 +82,66 @@ func (ks *ECDSAKeyshareStore) StoreKeyshare(keyshare ECDSAKeyshare) error {
 func (ks *ECDSAKeyshareStore) GetKeyshare() (ECDSAKeyshare, error) {
 	k := ECDSAKeyshare{}
 
-	kb, err := os.ReadFile(ks.path)
+	kb, err := ks.file.read()
 	if err != nil {
-		return k, fmt.Errorf("error on reading keyshare file: %s", err)
+		return k, err
 	}
 
-	err = json.Unmarshal(kb, &k)
+	return decodeECDSAKeyshare(kb)
+}
+
+// Generations returns keyshare generations kept in history
+func (ks *ECDSAKeyshareStore) Generations() ([]Generation, error) {
+	numbers, err := ks.file.generations()
 	if err != nil {
-		return k, fmt.Errorf("error on unmarshaling keyshare file: %s", err)
+		return nil, err
 	}
 
+	generations := make([]Generation, len(numbers))
+	for i, number := range numbers {
+		k, storedAt, err := ks.generation(number)
+		if err != nil {
+			return nil, err
+		}
+		generations[i] = newGeneration(number, k.SessionID, k.Threshold, k.Peers, k.PublicKey(), storedAt)
+	}
+	return generations, nil
+}
+
+// Generation returns keyshare generation from history
+func (ks *ECDSAKeyshareStore) Generation(number uint64) (ECDSAKeyshare, error) {
+	k, _, err := ks.generation(number)
 	return k, err
 }
+
+// RestoreGeneration replaces keyshare with the generation from history if the generation
+// has the expected public key. The replaced keyshare is kept in history.
+func (ks *ECDSAKeyshareStore) RestoreGeneration(number uint64, publicKey string) error {
+	k, _, err := ks.generation(number)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(k.PublicKey(), publicKey) {
+		return fmt.Errorf("keyshare generation %d public key %s does not match expected public key %s", number, k.PublicKey(), publicKey)
+	}
+
+	return ks.file.restore(number)
+}
+
+func (ks *ECDSAKeyshareStore) generation(number uint64) (ECDSAKeyshare, time.Time, error) {
+	kb, storedAt, err := ks.file.readGeneration(number)
+	if err != nil {
+		return ECDSAKeyshare{}, storedAt, err
+	}
+	k, err := decodeECDSAKeyshare(kb)
+	return k, storedAt, err
+}
+
+func decodeECDSAKeyshare(kb []byte) (ECDSAKeyshare, error) {
+	k := ECDSAKeyshare{}
+	err := json.Unmarshal(kb, &k)
+	if err != nil {
+		return k, fmt.Errorf("error on unmarshaling keyshare file: %s", err)
+	}
+	return k, nil
+}
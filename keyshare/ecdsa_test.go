@@ -4,7 +4,9 @@
 package keyshare_test
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ChainSafe/sygma-relayer/keyshare"
@@ -25,10 +27,11 @@ func TestRunECDSAKeyshareStoreTestSuite(t *testing.T) {
 
 func (s *ECDSAKeyshareStoreTestSuite) SetupTest() {
 	s.path = "share.json"
-	s.keyshareStore = keyshare.NewECDSAKeyshareStore(s.path)
+	s.keyshareStore = keyshare.NewECDSAKeyshareStore(s.path, nil)
 }
 func (s *ECDSAKeyshareStoreTestSuite) TearDownTest() {
 	os.Remove(s.path)
+	os.RemoveAll(s.path + ".history")
 }
 
 func (s *ECDSAKeyshareStoreTestSuite) Test_RetrieveInvalidFile() {
@@ -51,3 +54,114 @@ func (s *ECDSAKeyshareStoreTestSuite) Test_StoreAndRetrieveShare() {
 
 	s.Equal(keyshare, storedKeyshare)
 }
+
+func (s *ECDSAKeyshareStoreTestSuite) Test_StoreAndRetrieveEncryptedShare() {
+	wrapper, _ := keyshare.NewPassphraseKeyWrapper([]byte("passphrase"), testKDFParams)
+	keyshareStore := keyshare.NewECDSAKeyshareStore(s.path, wrapper)
+	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	share := keyshare.NewECDSAKeyshare(keygen.NewLocalPartySaveData(5), 3, []peer.ID{peer1})
+
+	err := keyshareStore.StoreKeyshare(share)
+	s.Nil(err)
+
+	data, _ := os.ReadFile(s.path)
+	s.NotContains(string(data), "Threshold")
+	info, _ := os.Stat(s.path)
+	s.Equal(info.Mode().Perm(), os.FileMode(0600))
+	storedKeyshare, err := keyshareStore.GetKeyshare()
+	s.Nil(err)
+	s.Equal(share, storedKeyshare)
+
+	_, err = s.keyshareStore.GetKeyshare()
+	s.NotNil(err)
+}
+
+func (s *ECDSAKeyshareStoreTestSuite) Test_MigrateUnencryptedShare() {
+	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	share := keyshare.NewECDSAKeyshare(keygen.NewLocalPartySaveData(5), 3, []peer.ID{peer1})
+	err := s.keyshareStore.StoreKeyshare(share)
+	s.Nil(err)
+	wrapper, _ := keyshare.NewPassphraseKeyWrapper([]byte("passphrase"), testKDFParams)
+	keyshareStore := keyshare.NewECDSAKeyshareStore(s.path, wrapper)
+
+	storedKeyshare, err := keyshareStore.GetKeyshare()
+
+	s.Nil(err)
+	s.Equal(share, storedKeyshare)
+	data, _ := os.ReadFile(s.path)
+	s.NotContains(string(data), "Threshold")
+	storedKeyshare, err = keyshareStore.GetKeyshare()
+	s.Nil(err)
+	s.Equal(share, storedKeyshare)
+}
+
+func (s *ECDSAKeyshareStoreTestSuite) Test_StoreKeepsHistory() {
+	path := fmt.Sprintf("%s/share.json", s.T().TempDir())
+	keyshareStore := keyshare.NewECDSAKeyshareStore(path, nil)
+	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	for i := 1; i <= keyshare.HistorySize+2; i++ {
+		share := keyshare.NewECDSAKeyshare(keygen.NewLocalPartySaveData(5), i, []peer.ID{peer1})
+		share.SessionID = fmt.Sprintf("resharing-%d", i)
+		err := keyshareStore.StoreKeyshare(share)
+		s.Nil(err)
+	}
+
+	generations, err := keyshareStore.Generations()
+
+	s.Nil(err)
+	s.Equal(len(generations), keyshare.HistorySize)
+	s.Equal(generations[0].Number, uint64(2))
+	s.Equal(generations[0].SessionID, "resharing-2")
+	s.Equal(generations[0].TopologyHash, keyshare.TopologyHash(2, []peer.ID{peer1}))
+	s.Equal(generations[keyshare.HistorySize-1].Number, uint64(6))
+	s.Equal(generations[keyshare.HistorySize-1].SessionID, "resharing-6")
+	current, _ := keyshareStore.GetKeyshare()
+	s.Equal(current.SessionID, "resharing-7")
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	s.Equal(len(entries), 2)
+}
+
+func (s *ECDSAKeyshareStoreTestSuite) Test_RestoreGeneration() {
+	path := fmt.Sprintf("%s/share.json", s.T().TempDir())
+	keyshareStore := keyshare.NewECDSAKeyshareStore(path, nil)
+	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	first := keyshare.NewECDSAKeyshare(keygen.NewLocalPartySaveData(5), 1, []peer.ID{peer1})
+	first.SessionID = "keygen-1"
+	second := keyshare.NewECDSAKeyshare(keygen.NewLocalPartySaveData(5), 2, []peer.ID{peer1})
+	second.SessionID = "resharing-2"
+	_ = keyshareStore.StoreKeyshare(first)
+	_ = keyshareStore.StoreKeyshare(second)
+
+	err := keyshareStore.RestoreGeneration(1, "0x0000000000000000000000000000000000000001")
+	s.NotNil(err)
+	err = keyshareStore.RestoreGeneration(3, "")
+	s.NotNil(err)
+
+	err = keyshareStore.RestoreGeneration(1, first.PublicKey())
+	s.Nil(err)
+	current, _ := keyshareStore.GetKeyshare()
+	s.Equal(current, first)
+	generations, _ := keyshareStore.Generations()
+	s.Equal(len(generations), 2)
+	s.Equal(generations[1].SessionID, "resharing-2")
+}
+
+func (s *ECDSAKeyshareStoreTestSuite) Test_MigrateHistory() {
+	path := fmt.Sprintf("%s/share.json", s.T().TempDir())
+	keyshareStore := keyshare.NewECDSAKeyshareStore(path, nil)
+	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	_ = keyshareStore.StoreKeyshare(keyshare.NewECDSAKeyshare(keygen.NewLocalPartySaveData(5), 1, []peer.ID{peer1}))
+	_ = keyshareStore.StoreKeyshare(keyshare.NewECDSAKeyshare(keygen.NewLocalPartySaveData(5), 2, []peer.ID{peer1}))
+	wrapper, _ := keyshare.NewPassphraseKeyWrapper([]byte("passphrase"), testKDFParams)
+	encryptedStore := keyshare.NewECDSAKeyshareStore(path, wrapper)
+
+	_, err := encryptedStore.GetKeyshare()
+	s.Nil(err)
+
+	data, _ := os.ReadFile(fmt.Sprintf("%s.history/1.keyshare", path))
+	s.NotContains(string(data), "Threshold")
+	generations, err := encryptedStore.Generations()
+	s.Nil(err)
+	s.Equal(len(generations), 1)
+	s.Equal(generations[0].Threshold, 1)
+}
@@ -4,11 +4,15 @@
 package keyshare
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"os"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
@@ -20,6 +24,8 @@ type FrostKeyshare struct {
 	Key       *frost.TaprootConfig
 	Threshold int
 	Peers     []peer.ID
+	// SessionID is the keygen or resharing session that generated the keyshare
+	SessionID string
 }
 
 type frostKey struct {
@@ -35,6 +41,7 @@ type frostKeyshareStore struct {
 	Key       frostKey
 	Threshold int
 	Peers     []peer.ID
+	SessionID string `json:",omitempty"`
 }
 
 func NewFrostKeyshare(key *frost.TaprootConfig, threshold int, peers []peer.ID) FrostKeyshare {
@@ -45,14 +52,50 @@ func NewFrostKeyshare(key *frost.TaprootConfig, threshold int, peers []peer.ID)
 	}
 }
 
+// PublicKey returns hex encoded taproot public key of the keyshare
+func (k FrostKeyshare) PublicKey() string {
+	if k.Key == nil {
+		return ""
+	}
+	return hex.EncodeToString(k.Key.PublicKey)
+}
+
+// Tweak returns keyshare with the key derived from the hex encoded tweak
+// that is used to sign for resources controlled by the tweaked key
+func (k FrostKeyshare) Tweak(tweak string) (FrostKeyshare, error) {
+	tweakBytes, err := hex.DecodeString(tweak)
+	if err != nil {
+		return k, err
+	}
+
+	h := &curve.Secp256k1Scalar{}
+	err = h.UnmarshalBinary(tweakBytes)
+	if err != nil {
+		return k, err
+	}
+	k.Key, err = k.Key.Derive(h, nil)
+	return k, err
+}
+
+// TaprootAddress returns P2TR address of the key derived from the tweak
+func (k FrostKeyshare) TaprootAddress(tweak string, params *chaincfg.Params) (btcutil.Address, error) {
+	tweaked, err := k.Tweak(tweak)
+	if err != nil {
+		return nil, err
+	}
+	return btcutil.NewAddressTaproot(tweaked.Key.PublicKey, params)
+}
+
 type FrostKeyshareStore struct {
 	mu   sync.Mutex
-	path string
+	file *keyshareFile
 }
 
-func NewFrostKeyshareStore(filePath string) *FrostKeyshareStore {
+// NewFrostKeyshareStore creates keyshare store that encrypts keyshare with the key wrapper.
+// Keyshare is stored unencrypted if the key wrapper is nil.
+func NewFrostKeyshareStore(filePath string, wrapper KeyWrapper) *FrostKeyshareStore {
 	return &FrostKeyshareStore{
-		path: filePath,
+		file: newKeyshareFile(filePath, wrapper),
 	}
 }
 
@@ -68,15 +111,9 @@ func (ks *FrostKeyshareStore) UnlockKeyshare() {
 	ks.mu.Unlock()
 }
 
-// StoreFrostKeyshare stores frost keyshare generated by keygen or reshare into file and truncates
-// old keyshare.
+// StoreFrostKeyshare atomically stores frost keyshare generated by keygen or reshare into file
+// and keeps the old keyshare in history.
 func (ks *FrostKeyshareStore) StoreKeyshare(keyshare FrostKeyshare) error {
-	f, err := os.OpenFile(ks.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
 	privateShareBytes, err := keyshare.Key.PrivateShare.MarshalBinary()
 	if err != nil {
 		return err
@@ -101,33 +138,85 @@ func (ks *FrostKeyshareStore) StoreKeyshare(keyshare FrostKeyshare) error {
 		Key:       fKey,
 		Threshold: keyshare.Threshold,
 		Peers:     keyshare.Peers,
+		SessionID: keyshare.SessionID,
 	}
 	kb, err := json.Marshal(&fStore)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.Write(kb)
-	return err
+	return ks.file.write(kb)
 }
 
 // GetFrostKeyshare fetches current keyshare from file.
 // Can be a blocking call if keygen or resharing are pending.
 func (ks *FrostKeyshareStore) GetKeyshare() (FrostKeyshare, error) {
-	fStore := frostKeyshareStore{}
-	k := FrostKeyshare{}
+	kb, err := ks.file.read()
+	if err != nil {
+		return FrostKeyshare{}, err
+	}
 
-	kb, err := os.ReadFile(ks.path)
+	return decodeFrostKeyshare(kb)
+}
+
+// Generations returns keyshare generations kept in history
+func (ks *FrostKeyshareStore) Generations() ([]Generation, error) {
+	numbers, err := ks.file.generations()
+	if err != nil {
+		return nil, err
+	}
+
+	generations := make([]Generation, len(numbers))
+	for i, number := range numbers {
+		k, storedAt, err := ks.generation(number)
+		if err != nil {
+			return nil, err
+		}
+		generations[i] = newGeneration(number, k.SessionID, k.Threshold, k.Peers, k.PublicKey(), storedAt)
+	}
+	return generations, nil
+}
+
+// Generation returns keyshare generation from history
+func (ks *FrostKeyshareStore) Generation(number uint64) (FrostKeyshare, error) {
+	k, _, err := ks.generation(number)
+	return k, err
+}
+
+// RestoreGeneration replaces keyshare with the generation from history if the generation
+// has the expected public key. The replaced keyshare is kept in history.
+func (ks *FrostKeyshareStore) RestoreGeneration(number uint64, publicKey string) error {
+	k, _, err := ks.generation(number)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(k.PublicKey(), publicKey) {
+		return fmt.Errorf("keyshare generation %d public key %s does not match expected public key %s", number, k.PublicKey(), publicKey)
+	}
+
+	return ks.file.restore(number)
+}
+
+func (ks *FrostKeyshareStore) generation(number uint64) (FrostKeyshare, time.Time, error) {
+	kb, storedAt, err := ks.file.readGeneration(number)
 	if err != nil {
-		return k, fmt.Errorf("error on reading keyshare file: %s", err)
+		return FrostKeyshare{}, storedAt, err
 	}
+	k, err := decodeFrostKeyshare(kb)
+	return k, storedAt, err
+}
+
+func decodeFrostKeyshare(kb []byte) (FrostKeyshare, error) {
+	fStore := frostKeyshareStore{}
+	k := FrostKeyshare{}
 
-	err = json.Unmarshal(kb, &fStore)
+	err := json.Unmarshal(kb, &fStore)
 	if err != nil {
 		return k, fmt.Errorf("error on unmarshaling keyshare file: %s", err)
 	}
 	k.Threshold = fStore.Threshold
 	k.Peers = fStore.Peers
+	k.SessionID = fStore.SessionID
 
 	privateShare := &curve.Secp256k1Scalar{}
 	err = privateShare.UnmarshalBinary(fStore.Key.PrivateShare)
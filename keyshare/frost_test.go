@@ -5,6 +5,7 @@ package keyshare_test
 
 import (
 	"encoding/base64"
+	"fmt"
 	"os"
 	"testing"
 
@@ -29,10 +30,11 @@ func TestRunFrostKeyshareStoreTestSuite(t *testing.T) {
 
 func (s *FrostKeyshareStoreTestSuite) SetupTest() {
 	s.path = "share.json"
-	s.keyshareStore = keyshare.NewFrostKeyshareStore(s.path)
+	s.keyshareStore = keyshare.NewFrostKeyshareStore(s.path, nil)
 }
 func (s *FrostKeyshareStoreTestSuite) TearDownTest() {
 	os.Remove(s.path)
+	os.RemoveAll(s.path + ".history")
 }
 
 func (s *FrostKeyshareStoreTestSuite) Test_RetrieveInvalidFile() {
@@ -73,3 +75,29 @@ func (s *FrostKeyshareStoreTestSuite) Test_StoreAndRetrieveShare() {
 
 	s.Equal(keyshare, storedKeyshare)
 }
+
+func (s *FrostKeyshareStoreTestSuite) Test_StoreAndRetrieveEncryptedShare() {
+	privateShare := &curve.Secp256k1Scalar{}
+	privateShareBytes, _ := base64.StdEncoding.DecodeString("hpUx9M/dN7lAF20Jum3/4sgmfty5W4VNeGoEEB18870=")
+	_ = privateShare.UnmarshalBinary(privateShareBytes)
+	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	share := keyshare.NewFrostKeyshare(&frost.TaprootConfig{
+		ID:                 party.ID(peer1.Pretty()),
+		Threshold:          1,
+		PrivateShare:       privateShare,
+		VerificationShares: map[party.ID]*curve.Secp256k1Point{},
+		PublicKey:          taproot.PublicKey{},
+		ChainKey:           []byte{},
+	}, 1, []peer.ID{peer1})
+	masterKeyPath := fmt.Sprintf("%s/master.key", s.T().TempDir())
+	_ = os.WriteFile(masterKeyPath, []byte("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), 0600)
+	wrapper, _ := keyshare.NewFileKeyWrapper(masterKeyPath)
+	keyshareStore := keyshare.NewFrostKeyshareStore(s.path, wrapper)
+
+	err := keyshareStore.StoreKeyshare(share)
+	s.Nil(err)
+
+	storedKeyshare, err := keyshareStore.GetKeyshare()
+	s.Nil(err)
+	s.Equal(share, storedKeyshare)
+}
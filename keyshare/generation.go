@@ -0,0 +1,52 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Generation describes a previous keyshare kept in history
+type Generation struct {
+	Number    uint64
+	SessionID string
+	// TopologyHash identifies the threshold and peers of the committee the keyshare was generated for
+	TopologyHash string
+	Threshold    int
+	Peers        []peer.ID
+	// PublicKey is the MPC address of ECDSA keyshares and the taproot public key of FROST keyshares
+	PublicKey string
+	StoredAt  time.Time
+}
+
+func newGeneration(number uint64, sessionID string, threshold int, peers []peer.ID, publicKey string, storedAt time.Time) Generation {
+	return Generation{
+		Number:       number,
+		SessionID:    sessionID,
+		TopologyHash: TopologyHash(threshold, peers),
+		Threshold:    threshold,
+		Peers:        peers,
+		PublicKey:    publicKey,
+		StoredAt:     storedAt,
+	}
+}
+
+// TopologyHash returns hash of the threshold and sorted peers of the committee
+func TopologyHash(threshold int, peers []peer.ID) string {
+	sorted := make([]string, len(peers))
+	for i, p := range peers {
+		sorted[i] = p.String()
+	}
+	sort.Strings(sorted)
+
+	h := sha256.Sum256([]byte(fmt.Sprintf("%d|%s", threshold, strings.Join(sorted, "|"))))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./keyshare/verifier.go
+
+// Package mock_keyshare is a generated GoMock package.
+package mock_keyshare
+
+import (
+	reflect "reflect"
+
+	keyshare "github.com/ChainSafe/sygma-relayer/keyshare"
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPublicKeyCheck is a mock of PublicKeyCheck interface.
+type MockPublicKeyCheck struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublicKeyCheckMockRecorder
+}
+
+// MockPublicKeyCheckMockRecorder is the mock recorder for MockPublicKeyCheck.
+type MockPublicKeyCheckMockRecorder struct {
+	mock *MockPublicKeyCheck
+}
+
+// NewMockPublicKeyCheck creates a new mock instance.
+func NewMockPublicKeyCheck(ctrl *gomock.Controller) *MockPublicKeyCheck {
+	mock := &MockPublicKeyCheck{ctrl: ctrl}
+	mock.recorder = &MockPublicKeyCheckMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublicKeyCheck) EXPECT() *MockPublicKeyCheckMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockPublicKeyCheck) Check() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockPublicKeyCheckMockRecorder) Check() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockPublicKeyCheck)(nil).Check))
+}
+
+// DomainID mocks base method.
+func (m *MockPublicKeyCheck) DomainID() uint8 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DomainID")
+	ret0, _ := ret[0].(uint8)
+	return ret0
+}
+
+// DomainID indicates an expected call of DomainID.
+func (mr *MockPublicKeyCheckMockRecorder) DomainID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DomainID", reflect.TypeOf((*MockPublicKeyCheck)(nil).DomainID))
+}
+
+// MockKeyshareStatusMeter is a mock of KeyshareStatusMeter interface.
+type MockKeyshareStatusMeter struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyshareStatusMeterMockRecorder
+}
+
+// MockKeyshareStatusMeterMockRecorder is the mock recorder for MockKeyshareStatusMeter.
+type MockKeyshareStatusMeterMockRecorder struct {
+	mock *MockKeyshareStatusMeter
+}
+
+// NewMockKeyshareStatusMeter creates a new mock instance.
+func NewMockKeyshareStatusMeter(ctrl *gomock.Controller) *MockKeyshareStatusMeter {
+	mock := &MockKeyshareStatusMeter{ctrl: ctrl}
+	mock.recorder = &MockKeyshareStatusMeterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyshareStatusMeter) EXPECT() *MockKeyshareStatusMeterMockRecorder {
+	return m.recorder
+}
+
+// TrackKeyshareStatus mocks base method.
+func (m *MockKeyshareStatusMeter) TrackKeyshareStatus(domainID uint8, stale bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackKeyshareStatus", domainID, stale)
+}
+
+// TrackKeyshareStatus indicates an expected call of TrackKeyshareStatus.
+func (mr *MockKeyshareStatusMeterMockRecorder) TrackKeyshareStatus(domainID, stale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackKeyshareStatus", reflect.TypeOf((*MockKeyshareStatusMeter)(nil).TrackKeyshareStatus), domainID, stale)
+}
+
+// MockMPCAddressFetcher is a mock of MPCAddressFetcher interface.
+type MockMPCAddressFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockMPCAddressFetcherMockRecorder
+}
+
+// MockMPCAddressFetcherMockRecorder is the mock recorder for MockMPCAddressFetcher.
+type MockMPCAddressFetcherMockRecorder struct {
+	mock *MockMPCAddressFetcher
+}
+
+// NewMockMPCAddressFetcher creates a new mock instance.
+func NewMockMPCAddressFetcher(ctrl *gomock.Controller) *MockMPCAddressFetcher {
+	mock := &MockMPCAddressFetcher{ctrl: ctrl}
+	mock.recorder = &MockMPCAddressFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMPCAddressFetcher) EXPECT() *MockMPCAddressFetcherMockRecorder {
+	return m.recorder
+}
+
+// MPCAddress mocks base method.
+func (m *MockMPCAddressFetcher) MPCAddress() (common.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MPCAddress")
+	ret0, _ := ret[0].(common.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MPCAddress indicates an expected call of MPCAddress.
+func (mr *MockMPCAddressFetcherMockRecorder) MPCAddress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MPCAddress", reflect.TypeOf((*MockMPCAddressFetcher)(nil).MPCAddress))
+}
+
+// MockECDSAKeyshareFetcher is a mock of ECDSAKeyshareFetcher interface.
+type MockECDSAKeyshareFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockECDSAKeyshareFetcherMockRecorder
+}
+
+// MockECDSAKeyshareFetcherMockRecorder is the mock recorder for MockECDSAKeyshareFetcher.
+type MockECDSAKeyshareFetcherMockRecorder struct {
+	mock *MockECDSAKeyshareFetcher
+}
+
+// NewMockECDSAKeyshareFetcher creates a new mock instance.
+func NewMockECDSAKeyshareFetcher(ctrl *gomock.Controller) *MockECDSAKeyshareFetcher {
+	mock := &MockECDSAKeyshareFetcher{ctrl: ctrl}
+	mock.recorder = &MockECDSAKeyshareFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockECDSAKeyshareFetcher) EXPECT() *MockECDSAKeyshareFetcherMockRecorder {
+	return m.recorder
+}
+
+// GetKeyshare mocks base method.
+func (m *MockECDSAKeyshareFetcher) GetKeyshare() (keyshare.ECDSAKeyshare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyshare")
+	ret0, _ := ret[0].(keyshare.ECDSAKeyshare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyshare indicates an expected call of GetKeyshare.
+func (mr *MockECDSAKeyshareFetcherMockRecorder) GetKeyshare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyshare", reflect.TypeOf((*MockECDSAKeyshareFetcher)(nil).GetKeyshare))
+}
+
+// MockFrostKeyshareFetcher is a mock of FrostKeyshareFetcher interface.
+type MockFrostKeyshareFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockFrostKeyshareFetcherMockRecorder
+}
+
+// MockFrostKeyshareFetcherMockRecorder is the mock recorder for MockFrostKeyshareFetcher.
+type MockFrostKeyshareFetcherMockRecorder struct {
+	mock *MockFrostKeyshareFetcher
+}
+
+// NewMockFrostKeyshareFetcher creates a new mock instance.
+func NewMockFrostKeyshareFetcher(ctrl *gomock.Controller) *MockFrostKeyshareFetcher {
+	mock := &MockFrostKeyshareFetcher{ctrl: ctrl}
+	mock.recorder = &MockFrostKeyshareFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFrostKeyshareFetcher) EXPECT() *MockFrostKeyshareFetcherMockRecorder {
+	return m.recorder
+}
+
+// GetKeyshare mocks base method.
+func (m *MockFrostKeyshareFetcher) GetKeyshare() (keyshare.FrostKeyshare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyshare")
+	ret0, _ := ret[0].(keyshare.FrostKeyshare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyshare indicates an expected call of GetKeyshare.
+func (mr *MockFrostKeyshareFetcherMockRecorder) GetKeyshare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyshare", reflect.TypeOf((*MockFrostKeyshareFetcher)(nil).GetKeyshare))
+}
@@ -0,0 +1,145 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+const (
+	gcmNonceLength = 12
+	gcmTagBits     = 128
+)
+
+// PKCS11KeyWrapper wraps data keys with AES-GCM using an AES key that never leaves the HSM.
+// Wrapped key is nonce | ciphertext.
+type PKCS11KeyWrapper struct {
+	lock    sync.Mutex
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	key     pkcs11.ObjectHandle
+}
+
+// NewPKCS11KeyWrapper loads the PKCS#11 module, logs into the token with the label
+// and finds the AES secret key with the key label
+func NewPKCS11KeyWrapper(library string, tokenLabel string, pin string, keyLabel string) (*PKCS11KeyWrapper, error) {
+	ctx := pkcs11.New(library)
+	if ctx == nil {
+		return nil, fmt.Errorf("unable to load pkcs11 module %s", library)
+	}
+	err := ctx.Initialize()
+	if err != nil {
+		ctx.Destroy()
+		return nil, err
+	}
+
+	w := &PKCS11KeyWrapper{ctx: ctx}
+	err = w.openSession(tokenLabel, pin, keyLabel)
+	if err != nil {
+		w.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *PKCS11KeyWrapper) openSession(tokenLabel string, pin string, keyLabel string) error {
+	slots, err := w.ctx.GetSlotList(true)
+	if err != nil {
+		return err
+	}
+	slot, found := uint(0), false
+	for _, s := range slots {
+		info, err := w.ctx.GetTokenInfo(s)
+		if err != nil {
+			return err
+		}
+		if info.Label == tokenLabel {
+			slot, found = s, true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("pkcs11 token %s not found", tokenLabel)
+	}
+
+	w.session, err = w.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		return err
+	}
+	err = w.ctx.Login(w.session, pkcs11.CKU_USER, pin)
+	if err != nil {
+		return err
+	}
+
+	err = w.ctx.FindObjectsInit(w.session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyLabel),
+	})
+	if err != nil {
+		return err
+	}
+	keys, _, err := w.ctx.FindObjects(w.session, 1)
+	_ = w.ctx.FindObjectsFinal(w.session)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("pkcs11 AES key %s not found", keyLabel)
+	}
+	w.key = keys[0]
+	return nil
+}
+
+func (w *PKCS11KeyWrapper) WrapKey(key []byte) ([]byte, error) {
+	nonce := make([]byte, gcmNonceLength)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	params := pkcs11.NewGCMParams(nonce, nil, gcmTagBits)
+	defer params.Free()
+	err = w.ctx.EncryptInit(w.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, w.key)
+	if err != nil {
+		return nil, err
+	}
+	ct, err := w.ctx.Encrypt(w.session, key)
+	if err != nil {
+		return nil, err
+	}
+	return append(nonce, ct...), nil
+}
+
+func (w *PKCS11KeyWrapper) UnwrapKey(wrappedKey []byte) ([]byte, error) {
+	if len(wrappedKey) < gcmNonceLength+gcmTagBits/8 {
+		return nil, fmt.Errorf("wrapped key too short")
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	params := pkcs11.NewGCMParams(wrappedKey[:gcmNonceLength], nil, gcmTagBits)
+	defer params.Free()
+	err := w.ctx.DecryptInit(w.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, w.key)
+	if err != nil {
+		return nil, err
+	}
+	return w.ctx.Decrypt(w.session, wrappedKey[gcmNonceLength:])
+}
+
+// Close logs out of the token and unloads the PKCS#11 module
+func (w *PKCS11KeyWrapper) Close() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	_ = w.ctx.Logout(w.session)
+	_ = w.ctx.CloseSession(w.session)
+	_ = w.ctx.Finalize()
+	w.ctx.Destroy()
+}
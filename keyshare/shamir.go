@@ -0,0 +1,116 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// SplitSecret splits the secret into parts with Shamir secret sharing over GF(2^8)
+// so that any threshold of the parts recover the secret.
+// Each part is the x coordinate followed by the evaluation for each secret byte.
+func SplitSecret(secret []byte, parts int, threshold int) ([][]byte, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("empty secret")
+	}
+	if parts < 2 || parts > 255 {
+		return nil, fmt.Errorf("number of parts must be between 2 and 255")
+	}
+	if threshold < 2 || threshold > parts {
+		return nil, fmt.Errorf("threshold must be between 2 and the number of parts")
+	}
+
+	shares := make([][]byte, parts)
+	for i := range shares {
+		shares[i] = make([]byte, len(secret)+1)
+		shares[i][0] = byte(i + 1)
+	}
+
+	coefficients := make([]byte, threshold)
+	for i, b := range secret {
+		_, err := rand.Read(coefficients[1:])
+		if err != nil {
+			return nil, err
+		}
+		coefficients[0] = b
+
+		for _, share := range shares {
+			share[i+1] = evaluate(coefficients, share[0])
+		}
+	}
+	return shares, nil
+}
+
+// CombineSecret recovers the secret from parts created by SplitSecret.
+// Combining less parts than the threshold returns an unrelated secret.
+func CombineSecret(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, fmt.Errorf("at least 2 parts are required")
+	}
+
+	xs := make(map[byte]bool)
+	for _, share := range shares {
+		if len(share) < 2 || len(share) != len(shares[0]) {
+			return nil, fmt.Errorf("parts must have the same length")
+		}
+		if share[0] == 0 || xs[share[0]] {
+			return nil, fmt.Errorf("invalid or duplicate part %d", share[0])
+		}
+		xs[share[0]] = true
+	}
+
+	secret := make([]byte, len(shares[0])-1)
+	for i := range secret {
+		var b byte
+		for j, share := range shares {
+			// lagrange basis polynomial of the share evaluated at 0
+			basis := byte(1)
+			for k, other := range shares {
+				if j == k {
+					continue
+				}
+				basis = gfMul(basis, gfMul(other[0], gfInverse(other[0]^share[0])))
+			}
+			b ^= gfMul(share[i+1], basis)
+		}
+		secret[i] = b
+	}
+	return secret, nil
+}
+
+// evaluate evaluates the polynomial with the coefficients at x with Horner's method
+func evaluate(coefficients []byte, x byte) byte {
+	var result byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result = gfMul(result, x) ^ coefficients[i]
+	}
+	return result
+}
+
+// gfMul multiplies in GF(2^8) with the AES reducing polynomial
+func gfMul(a byte, b byte) byte {
+	var result byte
+	for b > 0 {
+		if b&1 == 1 {
+			result ^= a
+		}
+		carry := a & 0x80
+		a <<= 1
+		if carry != 0 {
+			a ^= 0x1b
+		}
+		b >>= 1
+	}
+	return result
+}
+
+// gfInverse returns the multiplicative inverse a^254 in GF(2^8)
+func gfInverse(a byte) byte {
+	result := byte(1)
+	for i := 0; i < 254; i++ {
+		result = gfMul(result, a)
+	}
+	return result
+}
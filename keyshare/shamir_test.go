@@ -0,0 +1,64 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+)
+
+type ShamirTestSuite struct {
+	suite.Suite
+	secret []byte
+}
+
+func TestRunShamirTestSuite(t *testing.T) {
+	suite.Run(t, new(ShamirTestSuite))
+}
+
+func (s *ShamirTestSuite) SetupTest() {
+	s.secret = []byte("01234567890123456789012345678901")
+}
+
+func (s *ShamirTestSuite) Test_SplitAndCombine() {
+	parts, err := keyshare.SplitSecret(s.secret, 5, 3)
+	s.Nil(err)
+	s.Equal(len(parts), 5)
+
+	secret, err := keyshare.CombineSecret([][]byte{parts[4], parts[0], parts[2]})
+	s.Nil(err)
+	s.Equal(secret, s.secret)
+
+	secret, err = keyshare.CombineSecret(parts)
+	s.Nil(err)
+	s.Equal(secret, s.secret)
+}
+
+func (s *ShamirTestSuite) Test_CombineBelowThreshold() {
+	parts, _ := keyshare.SplitSecret(s.secret, 5, 3)
+
+	secret, err := keyshare.CombineSecret([][]byte{parts[1], parts[3]})
+
+	s.Nil(err)
+	s.NotEqual(secret, s.secret)
+}
+
+func (s *ShamirTestSuite) Test_CombineDuplicatePart() {
+	parts, _ := keyshare.SplitSecret(s.secret, 3, 2)
+
+	_, err := keyshare.CombineSecret([][]byte{parts[1], parts[1]})
+
+	s.NotNil(err)
+}
+
+func (s *ShamirTestSuite) Test_SplitInvalidThreshold() {
+	_, err := keyshare.SplitSecret(s.secret, 3, 4)
+	s.NotNil(err)
+
+	_, err = keyshare.SplitSecret(s.secret, 3, 1)
+	s.NotNil(err)
+}
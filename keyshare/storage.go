@@ -0,0 +1,336 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	encryptedKeyshareVersion = 1
+
+	// HistorySize is the number of previous keyshare generations kept
+	// in the history directory next to the keyshare file
+	HistorySize     = 5
+	historySuffix   = ".history"
+	generationExt   = ".keyshare"
+	temporarySuffix = ".tmp"
+)
+
+// encryptedKeyshare is the on disk format of a keyshare encrypted with AES-GCM
+// using a random data key wrapped by the KeyWrapper
+type encryptedKeyshare struct {
+	Version    int    `json:"version"`
+	WrappedKey []byte `json:"wrappedKey"`
+	Keyshare   []byte `json:"keyshare"`
+}
+
+// keyshareFile reads and writes keyshare file encrypting it with the key wrapper.
+// Keyshares are stored unencrypted if the key wrapper is nil.
+//
+// Keyshare is written atomically and the replaced keyshare is kept in the history
+// directory as the generation with the next number.
+type keyshareFile struct {
+	lock    sync.Mutex
+	path    string
+	wrapper KeyWrapper
+}
+
+func newKeyshareFile(path string, wrapper KeyWrapper) *keyshareFile {
+	return &keyshareFile{
+		path:    path,
+		wrapper: wrapper,
+	}
+}
+
+func (f *keyshareFile) write(data []byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	data, err := f.encrypt(data)
+	if err != nil {
+		return err
+	}
+	return f.replace(data)
+}
+
+// read returns decrypted keyshare and migrates unencrypted keyshare
+// to encrypted storage if the key wrapper is configured
+func (f *keyshareFile) read() ([]byte, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("error on reading keyshare file: %w", err)
+	}
+	pt, encrypted, err := f.decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !encrypted && f.wrapper != nil {
+		err = f.migrate(pt)
+		if err != nil {
+			return nil, fmt.Errorf("error on migrating keyshare file to encrypted storage: %w", err)
+		}
+		log.Info().Msgf("Migrated keyshare %s to encrypted storage", f.path)
+	}
+	return pt, nil
+}
+
+// generations returns numbers of keyshare generations in history in ascending order
+func (f *keyshareFile) generations() ([]uint64, error) {
+	entries, err := os.ReadDir(f.historyDir())
+	if os.IsNotExist(err) {
+		return []uint64{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	generations := []uint64{}
+	for _, entry := range entries {
+		generation, err := strconv.ParseUint(strings.TrimSuffix(entry.Name(), generationExt), 10, 64)
+		if err != nil || !strings.HasSuffix(entry.Name(), generationExt) {
+			continue
+		}
+		generations = append(generations, generation)
+	}
+	sort.Slice(generations, func(i, j int) bool { return generations[i] < generations[j] })
+	return generations, nil
+}
+
+// readGeneration returns decrypted keyshare generation and the time it was stored
+func (f *keyshareFile) readGeneration(generation uint64) ([]byte, time.Time, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	path := f.generationPath(generation)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("keyshare generation %d not found: %w", generation, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	pt, _, err := f.decrypt(data)
+	return pt, info.ModTime(), err
+}
+
+// restore replaces keyshare with the generation from history
+// and keeps the replaced keyshare in history
+func (f *keyshareFile) restore(generation uint64) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	data, err := os.ReadFile(f.generationPath(generation))
+	if err != nil {
+		return fmt.Errorf("keyshare generation %d not found: %w", generation, err)
+	}
+	return f.replace(data)
+}
+
+// replace archives current keyshare into history and atomically writes the new keyshare
+func (f *keyshareFile) replace(data []byte) error {
+	_, err := os.Stat(f.path)
+	if err == nil {
+		err = f.archive()
+		if err != nil {
+			return fmt.Errorf("error on archiving keyshare: %w", err)
+		}
+	}
+
+	err = writeFileAtomic(f.path, data)
+	if err != nil {
+		return err
+	}
+	f.prune()
+	return nil
+}
+
+// archive links current keyshare file into history as the next generation
+func (f *keyshareFile) archive() error {
+	generations, err := f.generations()
+	if err != nil {
+		return err
+	}
+	next := uint64(1)
+	if len(generations) > 0 {
+		next = generations[len(generations)-1] + 1
+	}
+
+	err = os.MkdirAll(f.historyDir(), 0700)
+	if err != nil {
+		return err
+	}
+	return os.Link(f.path, f.generationPath(next))
+}
+
+// prune removes the oldest generations exceeding the history size
+func (f *keyshareFile) prune() {
+	generations, err := f.generations()
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed listing keyshare history of %s", f.path)
+		return
+	}
+	for len(generations) > HistorySize {
+		err = os.Remove(f.generationPath(generations[0]))
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed removing keyshare generation %d of %s", generations[0], f.path)
+		}
+		generations = generations[1:]
+	}
+}
+
+// migrate encrypts keyshare and its history in place without archiving
+// so that no unencrypted copy of the keyshare is left behind
+func (f *keyshareFile) migrate(pt []byte) error {
+	data, err := f.encrypt(pt)
+	if err != nil {
+		return err
+	}
+	err = writeFileAtomic(f.path, data)
+	if err != nil {
+		return err
+	}
+
+	generations, err := f.generations()
+	if err != nil {
+		return err
+	}
+	for _, generation := range generations {
+		path := f.generationPath(generation)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		pt, encrypted, err := f.decrypt(data)
+		if err != nil || encrypted {
+			continue
+		}
+		data, err = f.encrypt(pt)
+		if err != nil {
+			return err
+		}
+		err = writeFileAtomic(path, data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *keyshareFile) historyDir() string {
+	return f.path + historySuffix
+}
+
+func (f *keyshareFile) generationPath(generation uint64) string {
+	return filepath.Join(f.historyDir(), fmt.Sprintf("%d%s", generation, generationExt))
+}
+
+// decrypt returns keyshare from file data and whether it was encrypted
+func (f *keyshareFile) decrypt(data []byte) ([]byte, bool, error) {
+	ek := encryptedKeyshare{}
+	err := json.Unmarshal(data, &ek)
+	if err != nil {
+		return nil, false, fmt.Errorf("error on unmarshaling keyshare file: %s", err)
+	}
+	if ek.Version == 0 {
+		return data, false, nil
+	}
+
+	if ek.Version != encryptedKeyshareVersion {
+		return nil, true, fmt.Errorf("unsupported keyshare file version %d", ek.Version)
+	}
+	if f.wrapper == nil {
+		return nil, true, fmt.Errorf("keyshare is encrypted but keyshare encryption is not configured")
+	}
+	dataKey, err := f.wrapper.UnwrapKey(ek.WrappedKey)
+	if err != nil {
+		return nil, true, fmt.Errorf("error on unwrapping keyshare key: %w", err)
+	}
+	pt, err := open(dataKey, ek.Keyshare, nil)
+	if err != nil {
+		return nil, true, fmt.Errorf("error on decrypting keyshare: %w", err)
+	}
+	return pt, true, nil
+}
+
+// encrypt returns keyshare file data that is unencrypted if the key wrapper is not configured
+func (f *keyshareFile) encrypt(data []byte) ([]byte, error) {
+	if f.wrapper == nil {
+		return data, nil
+	}
+
+	dataKey := make([]byte, dataKeyLength)
+	_, err := rand.Read(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	ct, err := seal(dataKey, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	wrappedKey, err := f.wrapper.WrapKey(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("error on wrapping keyshare key: %w", err)
+	}
+
+	return json.Marshal(&encryptedKeyshare{
+		Version:    encryptedKeyshareVersion,
+		WrappedKey: wrappedKey,
+		Keyshare:   ct,
+	})
+}
+
+// writeFileAtomic writes data into a temporary file in the same directory,
+// syncs it to disk and renames it to the path so that the path always
+// contains either the old or the new data
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+temporarySuffix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Sync()
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return err
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
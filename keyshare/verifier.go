@@ -0,0 +1,283 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrStaleKeyshare is returned when the public key of the local keyshare
+// does not match the key the domain expects
+var ErrStaleKeyshare = errors.New("stale keyshare")
+
+// PublicKeyCheck compares the public key derived from the local keyshare
+// with the key expected by the domain
+type PublicKeyCheck interface {
+	DomainID() uint8
+	// Check returns error wrapping ErrStaleKeyshare if the keys do not match
+	Check() error
+}
+
+type KeyshareStatusMeter interface {
+	TrackKeyshareStatus(domainID uint8, stale bool)
+}
+
+// Verifier periodically runs public key checks and tracks domains
+// for which the local keyshare is stale
+type Verifier struct {
+	checks []PublicKeyCheck
+
+	lock  sync.RWMutex
+	stale map[uint8]error
+}
+
+func NewVerifier() *Verifier {
+	return &Verifier{
+		checks: make([]PublicKeyCheck, 0),
+		stale:  make(map[uint8]error),
+	}
+}
+
+// AddCheck registers public key check that is run on each verification
+func (v *Verifier) AddCheck(check PublicKeyCheck) {
+	v.checks = append(v.checks, check)
+}
+
+// Start verifies keyshares on every interval and tracks keyshare status
+// of each domain until the context is done
+func (v *Verifier) Start(ctx context.Context, interval time.Duration, metrics KeyshareStatusMeter) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, check := range v.checks {
+			metrics.TrackKeyshareStatus(check.DomainID(), v.Stale(check.DomainID()) != nil)
+		}
+
+		select {
+		case <-ticker.C:
+			v.Verify()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Verify runs all public key checks. Domain status is changed only if the check
+// succeeds or finds a mismatch so that failing RPC calls do not block signing.
+func (v *Verifier) Verify() {
+	for _, check := range v.checks {
+		err := check.Check()
+		if err != nil && !errors.Is(err, ErrStaleKeyshare) {
+			log.Warn().Err(err).Uint8("domainID", check.DomainID()).Msg("Failed verifying keyshare public key")
+			continue
+		}
+
+		v.lock.Lock()
+		if err != nil {
+			log.Error().Err(err).Uint8("domainID", check.DomainID()).Msg("Keyshare public key mismatch, signing is disabled for the domain")
+			v.stale[check.DomainID()] = err
+		} else {
+			delete(v.stale, check.DomainID())
+		}
+		v.lock.Unlock()
+	}
+}
+
+// Stale returns the mismatch error if the keyshare is stale for the domain
+func (v *Verifier) Stale(domainID uint8) error {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	return v.stale[domainID]
+}
+
+// Health returns error describing all domains with a stale keyshare
+func (v *Verifier) Health() error {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	if len(v.stale) == 0 {
+		return nil
+	}
+
+	domains := make([]int, 0, len(v.stale))
+	for domainID := range v.stale {
+		domains = append(domains, int(domainID))
+	}
+	sort.Ints(domains)
+	errs := make([]string, len(domains))
+	for i, domainID := range domains {
+		errs[i] = fmt.Sprintf("domain %d: %s", domainID, v.stale[uint8(domainID)])
+	}
+	return fmt.Errorf("%w: %s", ErrStaleKeyshare, strings.Join(errs, "; "))
+}
+
+// ECDSAFetcher returns the keyshare store that refuses to return
+// the keyshare for signing while the keyshare is stale for the domain
+func (v *Verifier) ECDSAFetcher(domainID uint8, store *ECDSAKeyshareStore) *VerifiedECDSAKeyshareStore {
+	return &VerifiedECDSAKeyshareStore{
+		ECDSAKeyshareStore: store,
+		domainID:           domainID,
+		verifier:           v,
+	}
+}
+
+// FrostFetcher returns the keyshare store that refuses to return
+// the keyshare for signing while the keyshare is stale for the domain
+func (v *Verifier) FrostFetcher(domainID uint8, store *FrostKeyshareStore) *VerifiedFrostKeyshareStore {
+	return &VerifiedFrostKeyshareStore{
+		FrostKeyshareStore: store,
+		domainID:           domainID,
+		verifier:           v,
+	}
+}
+
+type VerifiedECDSAKeyshareStore struct {
+	*ECDSAKeyshareStore
+	domainID uint8
+	verifier *Verifier
+}
+
+func (ks *VerifiedECDSAKeyshareStore) GetKeyshare() (ECDSAKeyshare, error) {
+	err := ks.verifier.Stale(ks.domainID)
+	if err != nil {
+		return ECDSAKeyshare{}, err
+	}
+	return ks.ECDSAKeyshareStore.GetKeyshare()
+}
+
+type VerifiedFrostKeyshareStore struct {
+	*FrostKeyshareStore
+	domainID uint8
+	verifier *Verifier
+}
+
+func (ks *VerifiedFrostKeyshareStore) GetKeyshare() (FrostKeyshare, error) {
+	err := ks.verifier.Stale(ks.domainID)
+	if err != nil {
+		return FrostKeyshare{}, err
+	}
+	return ks.FrostKeyshareStore.GetKeyshare()
+}
+
+type MPCAddressFetcher interface {
+	MPCAddress() (common.Address, error)
+}
+
+type ECDSAKeyshareFetcher interface {
+	GetKeyshare() (ECDSAKeyshare, error)
+}
+
+// ECDSAPublicKeyCheck compares the address of the ECDSA keyshare with the MPC address of the bridge
+type ECDSAPublicKeyCheck struct {
+	domainID uint8
+	bridge   MPCAddressFetcher
+	fetcher  ECDSAKeyshareFetcher
+}
+
+func NewECDSAPublicKeyCheck(domainID uint8, bridge MPCAddressFetcher, fetcher ECDSAKeyshareFetcher) *ECDSAPublicKeyCheck {
+	return &ECDSAPublicKeyCheck{
+		domainID: domainID,
+		bridge:   bridge,
+		fetcher:  fetcher,
+	}
+}
+
+func (c *ECDSAPublicKeyCheck) DomainID() uint8 {
+	return c.domainID
+}
+
+// Check is skipped if the relayer has no keyshare or the bridge MPC address is not set yet
+func (c *ECDSAPublicKeyCheck) Check() error {
+	key, err := c.fetcher.GetKeyshare()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	address, err := c.bridge.MPCAddress()
+	if err != nil {
+		return fmt.Errorf("failed reading bridge MPC address: %w", err)
+	}
+	if address == (common.Address{}) {
+		return nil
+	}
+
+	if !strings.EqualFold(key.PublicKey(), address.Hex()) {
+		return fmt.Errorf("%w: keyshare address %s does not match bridge MPC address %s", ErrStaleKeyshare, key.PublicKey(), address.Hex())
+	}
+	return nil
+}
+
+type FrostKeyshareFetcher interface {
+	GetKeyshare() (FrostKeyshare, error)
+}
+
+// TaprootResource is a resource address controlled by the FROST key tweaked with the tweak
+type TaprootResource struct {
+	Address btcutil.Address
+	Tweak   string
+}
+
+// FrostPublicKeyCheck compares taproot addresses derived from the FROST keyshare
+// with the configured resource addresses
+type FrostPublicKeyCheck struct {
+	domainID  uint8
+	network   *chaincfg.Params
+	resources []TaprootResource
+	fetcher   FrostKeyshareFetcher
+}
+
+func NewFrostPublicKeyCheck(domainID uint8, network *chaincfg.Params, resources []TaprootResource, fetcher FrostKeyshareFetcher) *FrostPublicKeyCheck {
+	return &FrostPublicKeyCheck{
+		domainID:  domainID,
+		network:   network,
+		resources: resources,
+		fetcher:   fetcher,
+	}
+}
+
+func (c *FrostPublicKeyCheck) DomainID() uint8 {
+	return c.domainID
+}
+
+// Check is skipped if the relayer has no keyshare
+func (c *FrostPublicKeyCheck) Check() error {
+	key, err := c.fetcher.GetKeyshare()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return VerifyTaprootResources(key, c.network, c.resources)
+}
+
+// VerifyTaprootResources returns error wrapping ErrStaleKeyshare if taproot addresses
+// derived from the FROST keyshare do not match the resource addresses
+func VerifyTaprootResources(key FrostKeyshare, network *chaincfg.Params, resources []TaprootResource) error {
+	for _, resource := range resources {
+		address, err := key.TaprootAddress(resource.Tweak, network)
+		if err != nil {
+			return fmt.Errorf("failed deriving taproot address with tweak %s: %w", resource.Tweak, err)
+		}
+		if address.EncodeAddress() != resource.Address.EncodeAddress() {
+			return fmt.Errorf("%w: keyshare taproot address %s does not match resource address %s", ErrStaleKeyshare, address.EncodeAddress(), resource.Address.EncodeAddress())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	mock_keyshare "github.com/ChainSafe/sygma-relayer/keyshare/mock"
+)
+
+const (
+	testTweak          = "c82aa6ae534bb28aaafeb3660c31d6a52e187d8f05d48bb6bdb9b733a9b42212"
+	testTaprootAddress = "bcrt1pfljwpdyxj4adg52xhc6r2vfx9ndhu5zay2s8eq0334ts8zkqqeuqu8yrp7"
+)
+
+var testMPCAddress = common.HexToAddress("0x1c5541A79AcC662ab2D2647F3B141a3B7Cdb2Ae4")
+
+type VerifierTestSuite struct {
+	suite.Suite
+	mockBridge         *mock_keyshare.MockMPCAddressFetcher
+	ecdsaKeyshareStore *keyshare.ECDSAKeyshareStore
+	frostKeyshareStore *keyshare.FrostKeyshareStore
+	verifier           *keyshare.Verifier
+}
+
+func TestRunVerifierTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifierTestSuite))
+}
+
+func (s *VerifierTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockBridge = mock_keyshare.NewMockMPCAddressFetcher(ctrl)
+	s.ecdsaKeyshareStore = keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/0.keyshare", nil)
+	s.frostKeyshareStore = keyshare.NewFrostKeyshareStore("../tss/test/keyshares/0-frost.keyshare", nil)
+	s.verifier = keyshare.NewVerifier()
+	s.verifier.AddCheck(keyshare.NewECDSAPublicKeyCheck(1, s.mockBridge, s.ecdsaKeyshareStore))
+}
+
+func (s *VerifierTestSuite) frostCheck(address string) {
+	btcAddress, err := btcutil.DecodeAddress(address, &chaincfg.RegressionNetParams)
+	s.Nil(err)
+	s.verifier.AddCheck(keyshare.NewFrostPublicKeyCheck(2, &chaincfg.RegressionNetParams, []keyshare.TaprootResource{
+		{
+			Address: btcAddress,
+			Tweak:   testTweak,
+		},
+	}, s.frostKeyshareStore))
+}
+
+func (s *VerifierTestSuite) Test_MatchingKeys() {
+	s.mockBridge.EXPECT().MPCAddress().Return(testMPCAddress, nil)
+	s.frostCheck(testTaprootAddress)
+
+	s.verifier.Verify()
+
+	s.Nil(s.verifier.Health())
+	s.Nil(s.verifier.Stale(1))
+	s.Nil(s.verifier.Stale(2))
+	_, err := s.verifier.ECDSAFetcher(1, s.ecdsaKeyshareStore).GetKeyshare()
+	s.Nil(err)
+	_, err = s.verifier.FrostFetcher(2, s.frostKeyshareStore).GetKeyshare()
+	s.Nil(err)
+}
+
+func (s *VerifierTestSuite) Test_ECDSAMismatch() {
+	s.mockBridge.EXPECT().MPCAddress().Return(common.HexToAddress("0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b"), nil)
+
+	s.verifier.Verify()
+
+	s.True(errors.Is(s.verifier.Stale(1), keyshare.ErrStaleKeyshare))
+	s.True(errors.Is(s.verifier.Health(), keyshare.ErrStaleKeyshare))
+	_, err := s.verifier.ECDSAFetcher(1, s.ecdsaKeyshareStore).GetKeyshare()
+	s.True(errors.Is(err, keyshare.ErrStaleKeyshare))
+	_, err = s.verifier.ECDSAFetcher(3, s.ecdsaKeyshareStore).GetKeyshare()
+	s.Nil(err)
+}
+
+func (s *VerifierTestSuite) Test_FrostMismatch() {
+	s.mockBridge.EXPECT().MPCAddress().Return(testMPCAddress, nil)
+	s.frostCheck("bcrt1pdf5c3q35ssem2l25n435fa69qr7dzwkc6gsqehuflr3euh905l2sjyr5ek")
+
+	s.verifier.Verify()
+
+	s.Nil(s.verifier.Stale(1))
+	s.True(errors.Is(s.verifier.Stale(2), keyshare.ErrStaleKeyshare))
+	_, err := s.verifier.FrostFetcher(2, s.frostKeyshareStore).GetKeyshare()
+	s.True(errors.Is(err, keyshare.ErrStaleKeyshare))
+}
+
+func (s *VerifierTestSuite) Test_MPCAddressNotSet() {
+	s.mockBridge.EXPECT().MPCAddress().Return(common.Address{}, nil)
+
+	s.verifier.Verify()
+
+	s.Nil(s.verifier.Health())
+}
+
+func (s *VerifierTestSuite) Test_MissingKeyshare() {
+	verifier := keyshare.NewVerifier()
+	verifier.AddCheck(keyshare.NewECDSAPublicKeyCheck(1, s.mockBridge, keyshare.NewECDSAKeyshareStore(fmt.Sprintf("%s/missing.keyshare", s.T().TempDir()), nil)))
+
+	verifier.Verify()
+
+	s.Nil(verifier.Health())
+}
+
+func (s *VerifierTestSuite) Test_FailedCheckKeepsStatus() {
+	s.mockBridge.EXPECT().MPCAddress().Return(common.HexToAddress("0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b"), nil)
+	s.verifier.Verify()
+	s.NotNil(s.verifier.Stale(1))
+
+	s.mockBridge.EXPECT().MPCAddress().Return(common.Address{}, fmt.Errorf("error"))
+	s.verifier.Verify()
+	s.NotNil(s.verifier.Stale(1))
+
+	s.mockBridge.EXPECT().MPCAddress().Return(testMPCAddress, nil)
+	s.verifier.Verify()
+	s.Nil(s.verifier.Stale(1))
+}
@@ -0,0 +1,211 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+)
+
+const (
+	PassphraseWrapper = "passphrase"
+	FileWrapper       = "file"
+	PKCS11Wrapper     = "pkcs11"
+
+	dataKeyLength = 32
+	saltLength    = 16
+	// kdfParamsLength is the length of encoded argon2id time, memory and threads
+	kdfParamsLength = 4 + 4 + 1
+	// maxTime and maxMemory limit the KDF cost allowed when unwrapping
+	maxTime   = 16
+	maxMemory = 1024 * 1024
+)
+
+// KeyWrapper encrypts and decrypts data keys that are used to encrypt keyshares at rest
+type KeyWrapper interface {
+	WrapKey(key []byte) ([]byte, error)
+	UnwrapKey(wrappedKey []byte) ([]byte, error)
+}
+
+// NewKeyWrapper creates key wrapper from the keyshare encryption configuration.
+// Returns nil wrapper if keyshare encryption is not configured.
+func NewKeyWrapper(config relayer.KeyshareEncryptionConfig) (KeyWrapper, error) {
+	switch config.Wrapper {
+	case "":
+		return nil, nil
+	case PassphraseWrapper:
+		return NewPassphraseKeyWrapper([]byte(config.Passphrase), DefaultKDFParams)
+	case FileWrapper:
+		return NewFileKeyWrapper(config.MasterKeyPath)
+	case PKCS11Wrapper:
+		return NewPKCS11KeyWrapper(config.Pkcs11Library, config.Pkcs11TokenLabel, config.Pkcs11Pin, config.Pkcs11KeyLabel)
+	default:
+		return nil, fmt.Errorf("unsupported keyshare key wrapper %s", config.Wrapper)
+	}
+}
+
+// KDFParams are argon2id parameters used to derive the wrapping key from the passphrase
+type KDFParams struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+var DefaultKDFParams = KDFParams{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+}
+
+// PassphraseKeyWrapper wraps data keys with AES-GCM using a key derived from the passphrase with argon2id.
+// Wrapped key is salt | time | memory | threads | nonce | ciphertext.
+// The last derived key is cached so that reading the keyshare before each signing
+// does not run the key derivation.
+type PassphraseKeyWrapper struct {
+	passphrase []byte
+	kdfParams  KDFParams
+
+	cacheLock   sync.Mutex
+	cachedInput []byte
+	cachedKey   []byte
+}
+
+func NewPassphraseKeyWrapper(passphrase []byte, kdfParams KDFParams) (*PassphraseKeyWrapper, error) {
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("empty keyshare passphrase")
+	}
+
+	return &PassphraseKeyWrapper{
+		passphrase: passphrase,
+		kdfParams:  kdfParams,
+	}, nil
+}
+
+func (w *PassphraseKeyWrapper) WrapKey(key []byte) ([]byte, error) {
+	salt := make([]byte, saltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	header := bytes.NewBuffer(salt)
+	_ = binary.Write(header, binary.BigEndian, w.kdfParams.Time)
+	_ = binary.Write(header, binary.BigEndian, w.kdfParams.Memory)
+	header.WriteByte(w.kdfParams.Threads)
+
+	wrappedKey, err := seal(w.wrappingKey(header.Bytes(), w.kdfParams), key, header.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return append(header.Bytes(), wrappedKey...), nil
+}
+
+func (w *PassphraseKeyWrapper) UnwrapKey(wrappedKey []byte) ([]byte, error) {
+	if len(wrappedKey) < saltLength+kdfParamsLength {
+		return nil, fmt.Errorf("wrapped key too short")
+	}
+	header := wrappedKey[:saltLength+kdfParamsLength]
+	params := KDFParams{
+		Time:    binary.BigEndian.Uint32(header[saltLength : saltLength+4]),
+		Memory:  binary.BigEndian.Uint32(header[saltLength+4 : saltLength+8]),
+		Threads: header[saltLength+8],
+	}
+	if params.Time == 0 || params.Time > maxTime || params.Threads == 0 || params.Memory > maxMemory {
+		return nil, fmt.Errorf("invalid key derivation parameters %+v", params)
+	}
+
+	return open(w.wrappingKey(header, params), wrappedKey[len(header):], header)
+}
+
+// wrappingKey derives the wrapping key for the salt and parameters encoded in the header
+func (w *PassphraseKeyWrapper) wrappingKey(header []byte, params KDFParams) []byte {
+	w.cacheLock.Lock()
+	defer w.cacheLock.Unlock()
+	if bytes.Equal(w.cachedInput, header) {
+		return w.cachedKey
+	}
+
+	w.cachedKey = argon2.IDKey(w.passphrase, header[:saltLength], params.Time, params.Memory, params.Threads, dataKeyLength)
+	w.cachedInput = append([]byte{}, header...)
+	return w.cachedKey
+}
+
+// FileKeyWrapper wraps data keys with AES-GCM using the master key read from a local file.
+// Wrapped key is nonce | ciphertext.
+type FileKeyWrapper struct {
+	masterKey []byte
+}
+
+// NewFileKeyWrapper reads hex encoded 32 byte master key from the file
+func NewFileKeyWrapper(path string) (*FileKeyWrapper, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error on reading master key file: %w", err)
+	}
+	masterKey, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("master key is not hex encoded: %w", err)
+	}
+	if len(masterKey) != dataKeyLength {
+		return nil, fmt.Errorf("master key must be %d bytes", dataKeyLength)
+	}
+
+	return &FileKeyWrapper{
+		masterKey: masterKey,
+	}, nil
+}
+
+func (w *FileKeyWrapper) WrapKey(key []byte) ([]byte, error) {
+	return seal(w.masterKey, key, nil)
+}
+
+func (w *FileKeyWrapper) UnwrapKey(wrappedKey []byte) ([]byte, error) {
+	return open(w.masterKey, wrappedKey, nil)
+}
+
+// seal encrypts data with AES-GCM and returns nonce | ciphertext
+func seal(key []byte, data []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, data, additionalData), nil
+}
+
+// open decrypts nonce | ciphertext encrypted with AES-GCM
+func open(key []byte, ct []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ct) < aead.NonceSize()+aead.Overhead() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	return aead.Open(nil, ct[:aead.NonceSize()], ct[aead.NonceSize():], additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,180 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+)
+
+var testKDFParams = keyshare.KDFParams{
+	Time:    1,
+	Memory:  1024,
+	Threads: 1,
+}
+
+type KeyWrapperTestSuite struct {
+	suite.Suite
+	dataKey []byte
+}
+
+func TestRunKeyWrapperTestSuite(t *testing.T) {
+	suite.Run(t, new(KeyWrapperTestSuite))
+}
+
+func (s *KeyWrapperTestSuite) SetupTest() {
+	s.dataKey = []byte("01234567890123456789012345678901")
+}
+
+func (s *KeyWrapperTestSuite) Test_PassphraseKeyWrapper() {
+	wrapper, _ := keyshare.NewPassphraseKeyWrapper([]byte("passphrase"), testKDFParams)
+
+	wrappedKey, err := wrapper.WrapKey(s.dataKey)
+	s.Nil(err)
+	s.NotContains(string(wrappedKey), string(s.dataKey))
+
+	otherWrapper, _ := keyshare.NewPassphraseKeyWrapper([]byte("passphrase"), testKDFParams)
+	key, err := otherWrapper.UnwrapKey(wrappedKey)
+	s.Nil(err)
+	s.Equal(key, s.dataKey)
+}
+
+func (s *KeyWrapperTestSuite) Test_PassphraseKeyWrapper_InvalidPassphrase() {
+	wrapper, _ := keyshare.NewPassphraseKeyWrapper([]byte("passphrase"), testKDFParams)
+	wrappedKey, _ := wrapper.WrapKey(s.dataKey)
+
+	otherWrapper, _ := keyshare.NewPassphraseKeyWrapper([]byte("invalid"), testKDFParams)
+	_, err := otherWrapper.UnwrapKey(wrappedKey)
+
+	s.NotNil(err)
+}
+
+func (s *KeyWrapperTestSuite) Test_PassphraseKeyWrapper_EmptyPassphrase() {
+	_, err := keyshare.NewPassphraseKeyWrapper([]byte{}, testKDFParams)
+
+	s.NotNil(err)
+}
+
+func (s *KeyWrapperTestSuite) Test_FileKeyWrapper() {
+	path := fmt.Sprintf("%s/master.key", s.T().TempDir())
+	_ = os.WriteFile(path, []byte("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f\n"), 0600)
+	wrapper, err := keyshare.NewFileKeyWrapper(path)
+	s.Nil(err)
+
+	wrappedKey, err := wrapper.WrapKey(s.dataKey)
+	s.Nil(err)
+	key, err := wrapper.UnwrapKey(wrappedKey)
+	s.Nil(err)
+	s.Equal(key, s.dataKey)
+
+	wrappedKey[len(wrappedKey)-1] ^= 1
+	_, err = wrapper.UnwrapKey(wrappedKey)
+	s.NotNil(err)
+}
+
+func (s *KeyWrapperTestSuite) Test_FileKeyWrapper_InvalidMasterKey() {
+	path := fmt.Sprintf("%s/master.key", s.T().TempDir())
+	_ = os.WriteFile(path, []byte("0001"), 0600)
+
+	_, err := keyshare.NewFileKeyWrapper(path)
+
+	s.NotNil(err)
+}
+
+func (s *KeyWrapperTestSuite) Test_NewKeyWrapper_NotConfigured() {
+	wrapper, err := keyshare.NewKeyWrapper(relayer.KeyshareEncryptionConfig{})
+
+	s.Nil(err)
+	s.Nil(wrapper)
+}
+
+func (s *KeyWrapperTestSuite) Test_NewKeyWrapper_UnsupportedWrapper() {
+	_, err := keyshare.NewKeyWrapper(relayer.KeyshareEncryptionConfig{Wrapper: "invalid"})
+
+	s.NotNil(err)
+}
+
+// PKCS11KeyWrapperTestSuite runs against SoftHSM module set in SOFTHSM_LIB,
+// e.g. /usr/lib/softhsm/libsofthsm2.so, and is skipped if it is not set
+type PKCS11KeyWrapperTestSuite struct {
+	suite.Suite
+	library string
+	pin     string
+}
+
+func TestRunPKCS11KeyWrapperTestSuite(t *testing.T) {
+	suite.Run(t, new(PKCS11KeyWrapperTestSuite))
+}
+
+func (s *PKCS11KeyWrapperTestSuite) SetupSuite() {
+	s.library = os.Getenv("SOFTHSM_LIB")
+	if s.library == "" {
+		s.T().Skip("SOFTHSM_LIB not set")
+	}
+	s.pin = "1234"
+
+	dir := s.T().TempDir()
+	err := os.MkdirAll(fmt.Sprintf("%s/tokens", dir), 0700)
+	s.Require().Nil(err)
+	conf := fmt.Sprintf("%s/softhsm2.conf", dir)
+	err = os.WriteFile(conf, []byte(fmt.Sprintf("directories.tokendir = %s/tokens\n", dir)), 0600)
+	s.Require().Nil(err)
+	s.T().Setenv("SOFTHSM2_CONF", conf)
+
+	ctx := pkcs11.New(s.library)
+	s.Require().NotNil(ctx)
+	defer ctx.Destroy()
+	s.Require().Nil(ctx.Initialize())
+	defer func() { _ = ctx.Finalize() }()
+
+	slots, err := ctx.GetSlotList(false)
+	s.Require().Nil(err)
+	s.Require().Nil(ctx.InitToken(slots[0], "so-pin", "sygma"))
+	slots, err = ctx.GetSlotList(true)
+	s.Require().Nil(err)
+	session, err := ctx.OpenSession(slots[0], pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	s.Require().Nil(err)
+	s.Require().Nil(ctx.Login(session, pkcs11.CKU_SO, "so-pin"))
+	s.Require().Nil(ctx.InitPIN(session, s.pin))
+	s.Require().Nil(ctx.Logout(session))
+	s.Require().Nil(ctx.Login(session, pkcs11.CKU_USER, s.pin))
+	_, err = ctx.GenerateKey(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_KEY_GEN, nil)}, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_ENCRYPT, true),
+		pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, 32),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, "keyshare"),
+	})
+	s.Require().Nil(err)
+	_ = ctx.Logout(session)
+	_ = ctx.CloseSession(session)
+}
+
+func (s *PKCS11KeyWrapperTestSuite) Test_WrapAndUnwrapKey() {
+	wrapper, err := keyshare.NewPKCS11KeyWrapper(s.library, "sygma", s.pin, "keyshare")
+	s.Require().Nil(err)
+	defer wrapper.Close()
+	dataKey := []byte("01234567890123456789012345678901")
+
+	wrappedKey, err := wrapper.WrapKey(dataKey)
+	s.Nil(err)
+	key, err := wrapper.UnwrapKey(wrappedKey)
+	s.Nil(err)
+	s.Equal(key, dataKey)
+}
+
+func (s *PKCS11KeyWrapperTestSuite) Test_MissingKey() {
+	_, err := keyshare.NewPKCS11KeyWrapper(s.library, "sygma", s.pin, "missing")
+
+	s.NotNil(err)
+}
@@ -0,0 +1,51 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	api "go.opentelemetry.io/otel/metric"
+)
+
+type EndpointMetrics struct {
+	endpointLatencyHistogram api.Int64Histogram
+	endpointErrorCounter     api.Int64Counter
+	opts                     api.MeasurementOption
+}
+
+// NewEndpointMetrics initializes metrics related to RPC endpoint health
+func NewEndpointMetrics(ctx context.Context, meter metric.Meter, opts metric.MeasurementOption) (*EndpointMetrics, error) {
+	endpointLatencyHistogram, err := meter.Int64Histogram(
+		"relayer.EndpointLatency",
+		api.WithDescription("Latency of RPC endpoint calls in milliseconds"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	endpointErrorCounter, err := meter.Int64Counter(
+		"relayer.EndpointErrorCount",
+		api.WithDescription("Number of failed RPC endpoint calls"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EndpointMetrics{
+		endpointLatencyHistogram: endpointLatencyHistogram,
+		endpointErrorCounter:     endpointErrorCounter,
+		opts:                     opts,
+	}, nil
+}
+
+func (m *EndpointMetrics) TrackEndpointCall(domainID uint8, endpoint string, latency time.Duration, failed bool) {
+	attributes := api.WithAttributes(attribute.Int64("domainID", int64(domainID)), attribute.String("endpoint", endpoint))
+	m.endpointLatencyHistogram.Record(context.Background(), latency.Milliseconds(), m.opts, attributes)
+	if failed {
+		m.endpointErrorCounter.Add(context.Background(), 1, m.opts, attributes)
+	}
+}
@@ -0,0 +1,85 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	api "go.opentelemetry.io/otel/metric"
+)
+
+type KeyshareMetrics struct {
+	staleKeyshareGauge    api.Int64ObservableGauge
+	failedKeyRefreshGauge api.Int64ObservableGauge
+	lock                  *sync.Mutex
+	staleKeyshares        map[uint8]int64
+	failedKeyRefreshes    map[string]int64
+}
+
+// NewKeyshareMetrics initializes metrics related to keyshare public key verification
+func NewKeyshareMetrics(ctx context.Context, meter metric.Meter, opts metric.MeasurementOption) (*KeyshareMetrics, error) {
+	lock := &sync.Mutex{}
+	staleKeyshares := make(map[uint8]int64)
+	staleKeyshareGauge, err := meter.Int64ObservableGauge(
+		"relayer.StaleKeyshare",
+		api.WithInt64Callback(func(context context.Context, result api.Int64Observer) error {
+			lock.Lock()
+			defer lock.Unlock()
+			for domainID, stale := range staleKeyshares {
+				result.Observe(stale, opts, api.WithAttributes(attribute.Int64("domainID", int64(domainID))))
+			}
+			return nil
+		}),
+		api.WithDescription("Whether the keyshare public key does not match the key expected by the domain"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	failedKeyRefreshes := make(map[string]int64)
+	failedKeyRefreshGauge, err := meter.Int64ObservableGauge(
+		"relayer.FailedKeyRefresh",
+		api.WithInt64Callback(func(context context.Context, result api.Int64Observer) error {
+			lock.Lock()
+			defer lock.Unlock()
+			for keyType, failed := range failedKeyRefreshes {
+				result.Observe(failed, opts, api.WithAttributes(attribute.String("keyType", keyType)))
+			}
+			return nil
+		}),
+		api.WithDescription("Whether the proactive key refresh changed the keyshare public key"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyshareMetrics{
+		staleKeyshareGauge:    staleKeyshareGauge,
+		failedKeyRefreshGauge: failedKeyRefreshGauge,
+		lock:                  lock,
+		staleKeyshares:        staleKeyshares,
+		failedKeyRefreshes:    failedKeyRefreshes,
+	}, nil
+}
+
+func (m *KeyshareMetrics) TrackKeyshareStatus(domainID uint8, stale bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.staleKeyshares[domainID] = 0
+	if stale {
+		m.staleKeyshares[domainID] = 1
+	}
+}
+
+func (m *KeyshareMetrics) TrackKeyRefreshStatus(keyType string, failed bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.failedKeyRefreshes[keyType] = 0
+	if failed {
+		m.failedKeyRefreshes[keyType] = 1
+	}
+}
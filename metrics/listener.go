@@ -0,0 +1,52 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	api "go.opentelemetry.io/otel/metric"
+)
+
+type ListenerMetrics struct {
+	fetchedBlocksCounter    api.Int64Counter
+	blockFetchRateHistogram api.Int64Histogram
+	opts                    api.MeasurementOption
+}
+
+// NewListenerMetrics initializes metrics related to listener event fetching
+func NewListenerMetrics(ctx context.Context, meter metric.Meter, opts metric.MeasurementOption) (*ListenerMetrics, error) {
+	fetchedBlocksCounter, err := meter.Int64Counter(
+		"relayer.FetchedBlockCount",
+		api.WithDescription("Number of blocks the listener fetched events for"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	blockFetchRateHistogram, err := meter.Int64Histogram(
+		"relayer.BlockFetchRate",
+		api.WithDescription("Number of blocks per second the listener fetched events for"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListenerMetrics{
+		fetchedBlocksCounter:    fetchedBlocksCounter,
+		blockFetchRateHistogram: blockFetchRateHistogram,
+		opts:                    opts,
+	}, nil
+}
+
+// TrackBlockFetch tracks number of blocks fetched and the fetch throughput
+func (m *ListenerMetrics) TrackBlockFetch(domainID uint8, blocks int64, duration time.Duration) {
+	attributes := api.WithAttributes(attribute.Int64("domainID", int64(domainID)))
+	m.fetchedBlocksCounter.Add(context.Background(), blocks, m.opts, attributes)
+	if duration > 0 {
+		m.blockFetchRateHistogram.Record(context.Background(), blocks*int64(time.Second)/int64(duration), m.opts, attributes)
+	}
+}
@@ -15,6 +15,10 @@ type SygmaMetrics struct {
 	*observability.RelayerMetrics
 	*MpcMetrics
 	*HostMetrics
+	*EndpointMetrics
+	*ListenerMetrics
+	*KeyshareMetrics
+	*RuntimeMetrics
 }
 
 // NewSygmaMetrics creates an instance of metrics
@@ -36,9 +40,33 @@ func NewSygmaMetrics(ctx context.Context, meter api.Meter, env, relayerID, versi
 		return nil, err
 	}
 
+	endpointMetrics, err := NewEndpointMetrics(ctx, meter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	listenerMetrics, err := NewListenerMetrics(ctx, meter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	keyshareMetrics, err := NewKeyshareMetrics(ctx, meter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	runtimeMetrics, err := NewRuntimeMetrics(ctx, meter, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	return &SygmaMetrics{
-		RelayerMetrics: relayerMetrics,
-		MpcMetrics:     mpcMetrics,
-		HostMetrics:    hostMetrics,
+		RelayerMetrics:  relayerMetrics,
+		MpcMetrics:      mpcMetrics,
+		HostMetrics:     hostMetrics,
+		EndpointMetrics: endpointMetrics,
+		ListenerMetrics: listenerMetrics,
+		KeyshareMetrics: keyshareMetrics,
+		RuntimeMetrics:  runtimeMetrics,
 	}, nil
 }
@@ -0,0 +1,55 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	api "go.opentelemetry.io/otel/metric"
+)
+
+type RuntimeMetrics struct {
+	haltedRuntimeGauge api.Int64ObservableGauge
+	lock               *sync.Mutex
+	haltedRuntimes     map[uint8]int64
+}
+
+// NewRuntimeMetrics initializes metrics related to substrate runtime upgrades
+func NewRuntimeMetrics(ctx context.Context, meter metric.Meter, opts metric.MeasurementOption) (*RuntimeMetrics, error) {
+	lock := &sync.Mutex{}
+	haltedRuntimes := make(map[uint8]int64)
+	haltedRuntimeGauge, err := meter.Int64ObservableGauge(
+		"relayer.RuntimeHalted",
+		api.WithInt64Callback(func(context context.Context, result api.Int64Observer) error {
+			lock.Lock()
+			defer lock.Unlock()
+			for domainID, halted := range haltedRuntimes {
+				result.Observe(halted, opts, api.WithAttributes(attribute.Int64("domainID", int64(domainID))))
+			}
+			return nil
+		}),
+		api.WithDescription("Whether proposal execution is halted because the bridge pallet changed in a runtime upgrade"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RuntimeMetrics{
+		haltedRuntimeGauge: haltedRuntimeGauge,
+		lock:               lock,
+		haltedRuntimes:     haltedRuntimes,
+	}, nil
+}
+
+func (m *RuntimeMetrics) TrackRuntimeStatus(domainID uint8, halted bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.haltedRuntimes[domainID] = 0
+	if halted {
+		m.haltedRuntimes[domainID] = 1
+	}
+}
@@ -5,6 +5,7 @@ package retry
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/store"
@@ -14,26 +15,35 @@ import (
 
 const (
 	RetryMessageType message.MessageType = "RetryMessage"
+
+	stuckProposalsPageSize = 100
 )
 
+// StuckPeriod is the time after which a pending proposal is considered stuck
+var StuckPeriod = time.Hour
+
 type RetryMessageData struct {
 	SourceDomainID      uint8
 	DestinationDomainID uint8
 	BlockHeight         *big.Int
 	ResourceID          [32]byte
+	// AllResources retries deposits of all resources instead of ResourceID
+	AllResources bool
 }
 
 type PropStorer interface {
-	StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error
 	PropStatus(source, destination uint8, depositNonce uint64) (store.PropStatus, error)
+	UpdateProposal(update store.ProposalUpdate) error
 }
 
 // FilterDeposits filters deposits per domain and resource
-// that are to be retried.
+// that are to be retried. Deposits of all resources are retried
+// if allResources is set.
 func FilterDeposits(
 	propStorer PropStorer,
 	domainDeposits map[uint8][]*message.Message,
 	resourceID [32]byte,
+	allResources bool,
 	destination uint8) ([]*message.Message, error) {
 	filteredDeposits := make([]*message.Message, 0)
 	for domain, deposits := range domainDeposits {
@@ -43,7 +53,7 @@ func FilterDeposits(
 
 		for _, deposit := range deposits {
 			data := deposit.Data.(transfer.TransferMessageData)
-			if data.ResourceId != resourceID {
+			if !allResources && data.ResourceId != resourceID {
 				continue
 			}
 
@@ -63,6 +73,34 @@ func FilterDeposits(
 	return filteredDeposits, nil
 }
 
+type ProposalFetcher interface {
+	Proposals(query store.ProposalQuery, cursor string, limit int) ([]*store.ProposalRecord, string, error)
+}
+
+// StuckProposals pages through proposals to the destination that are pending for longer
+// than StuckPeriod. Stuck proposals are executed by retrying their deposits.
+func StuckProposals(fetcher ProposalFetcher, destination uint8) ([]*store.ProposalRecord, error) {
+	query := store.ProposalQuery{
+		Status:        store.PendingProp,
+		Destination:   destination,
+		ChangedBefore: time.Now().Add(-StuckPeriod),
+	}
+
+	stuckProposals := make([]*store.ProposalRecord, 0)
+	cursor := ""
+	for {
+		records, next, err := fetcher.Proposals(query, cursor, stuckProposalsPageSize)
+		if err != nil {
+			return stuckProposals, err
+		}
+		stuckProposals = append(stuckProposals, records...)
+		if next == "" {
+			return stuckProposals, nil
+		}
+		cursor = next
+	}
+}
+
 func isExecuted(msg *message.Message, propStorer PropStorer) (bool, error) {
 	var err error
 	propStatus, err := propStorer.PropStatus(
@@ -79,11 +117,13 @@ func isExecuted(msg *message.Message, propStorer PropStorer) (bool, error) {
 
 	// change the status to failed if proposal is stuck to be able to retry it
 	if propStatus == store.PendingProp {
-		err = propStorer.StorePropStatus(
-			msg.Source,
-			msg.Destination,
-			msg.Data.(transfer.TransferMessageData).DepositNonce,
-			store.FailedProp)
+		err = propStorer.UpdateProposal(store.ProposalUpdate{
+			Source:       msg.Source,
+			Destination:  msg.Destination,
+			DepositNonce: msg.Data.(transfer.TransferMessageData).DepositNonce,
+			Status:       store.FailedProp,
+			Reason:       "retried while pending",
+		})
 	}
 	return false, err
 }
@@ -3,6 +3,7 @@ package retry_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	mock_executor "github.com/ChainSafe/sygma-relayer/chains/btc/executor/mock"
 	"github.com/ChainSafe/sygma-relayer/e2e/evm"
@@ -48,7 +49,7 @@ func (s *FilterDepositsTestSuite) Test_NoValidDeposits() {
 		},
 	}
 
-	d, err := retry.FilterDeposits(s.mockPropStorer, deposits, validResource, validDomain)
+	d, err := retry.FilterDeposits(s.mockPropStorer, deposits, validResource, false, validDomain)
 
 	expectedDeposits := make([]*message.Message, 0)
 	s.Nil(err)
@@ -122,9 +123,15 @@ func (s *FilterDepositsTestSuite) Test_FilterDeposits() {
 	s.mockPropStorer.EXPECT().PropStatus(invalidDomain, validDomain, failedNonce).Return(store.FailedProp, nil)
 	s.mockPropStorer.EXPECT().PropStatus(invalidDomain, validDomain, pendingNonce).Return(store.PendingProp, nil)
 	s.mockPropStorer.EXPECT().PropStatus(invalidDomain, validDomain, failedExecutionCheckNonce).Return(store.PendingProp, fmt.Errorf("error"))
-	s.mockPropStorer.EXPECT().StorePropStatus(invalidDomain, validDomain, pendingNonce, store.FailedProp).Return(nil)
+	s.mockPropStorer.EXPECT().UpdateProposal(store.ProposalUpdate{
+		Source:       invalidDomain,
+		Destination:  validDomain,
+		DepositNonce: pendingNonce,
+		Status:       store.FailedProp,
+		Reason:       "retried while pending",
+	}).Return(nil)
 
-	d, err := retry.FilterDeposits(s.mockPropStorer, deposits, validResource, validDomain)
+	d, err := retry.FilterDeposits(s.mockPropStorer, deposits, validResource, false, validDomain)
 
 	expectedDeposits := []*message.Message{
 		{
@@ -147,3 +154,106 @@ func (s *FilterDepositsTestSuite) Test_FilterDeposits() {
 	s.Nil(err)
 	s.Equal(d, expectedDeposits)
 }
+
+func (s *FilterDepositsTestSuite) Test_FilterDeposits_AllResources() {
+	resource1 := evm.SliceTo32Bytes(common.LeftPadBytes([]byte{3}, 31))
+	resource2 := evm.SliceTo32Bytes(common.LeftPadBytes([]byte{4}, 31))
+	sourceDomain := uint8(3)
+	destinationDomain := uint8(4)
+
+	deposits := make(map[uint8][]*message.Message)
+	deposits[destinationDomain] = []*message.Message{
+		{
+			Source:      sourceDomain,
+			Destination: destinationDomain,
+			Data: transfer.TransferMessageData{
+				DepositNonce: 1,
+				ResourceId:   resource1,
+			},
+		},
+		{
+			Source:      sourceDomain,
+			Destination: destinationDomain,
+			Data: transfer.TransferMessageData{
+				DepositNonce: 2,
+				ResourceId:   resource2,
+			},
+		},
+	}
+	s.mockPropStorer.EXPECT().PropStatus(sourceDomain, destinationDomain, uint64(1)).Return(store.FailedProp, nil)
+	s.mockPropStorer.EXPECT().PropStatus(sourceDomain, destinationDomain, uint64(2)).Return(store.ExecutedProp, nil)
+
+	d, err := retry.FilterDeposits(s.mockPropStorer, deposits, [32]byte{}, true, destinationDomain)
+
+	s.Nil(err)
+	s.Equal(d, deposits[destinationDomain][:1])
+}
+
+type StuckProposalsTestSuite struct {
+	suite.Suite
+
+	db        *store.LvlDB
+	propStore *store.PropStore
+}
+
+func TestRunStuckProposalsTestSuite(t *testing.T) {
+	suite.Run(t, new(StuckProposalsTestSuite))
+}
+
+func (s *StuckProposalsTestSuite) SetupTest() {
+	db, err := store.NewLvlDB(s.T().TempDir())
+	s.Require().Nil(err)
+	s.db = db
+	s.propStore = store.NewPropStore(db)
+}
+
+func (s *StuckProposalsTestSuite) TearDownTest() {
+	s.db.Close()
+	retry.StuckPeriod = time.Hour
+}
+
+func (s *StuckProposalsTestSuite) Test_NotStuckYet() {
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 1, Status: store.PendingProp})
+
+	proposals, err := retry.StuckProposals(s.propStore, 2)
+
+	s.Nil(err)
+	s.Equal(len(proposals), 0)
+}
+
+func (s *StuckProposalsTestSuite) Test_PagesThroughStuckProposals() {
+	for nonce := uint64(1); nonce <= 150; nonce++ {
+		_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: nonce, Status: store.PendingProp})
+	}
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 3, DepositNonce: 151, Status: store.PendingProp})
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 152, Status: store.FailedProp})
+	retry.StuckPeriod = 0
+
+	proposals, err := retry.StuckProposals(s.propStore, 2)
+
+	s.Nil(err)
+	s.Equal(len(proposals), 150)
+}
+
+func (s *FilterDepositsTestSuite) Test_FilterDeposits_ZeroResource() {
+	resource := evm.SliceTo32Bytes(common.LeftPadBytes([]byte{3}, 31))
+	sourceDomain := uint8(3)
+	destinationDomain := uint8(4)
+
+	deposits := make(map[uint8][]*message.Message)
+	deposits[destinationDomain] = []*message.Message{
+		{
+			Source:      sourceDomain,
+			Destination: destinationDomain,
+			Data: transfer.TransferMessageData{
+				DepositNonce: 1,
+				ResourceId:   resource,
+			},
+		},
+	}
+
+	d, err := retry.FilterDeposits(s.mockPropStorer, deposits, [32]byte{}, false, destinationDomain)
+
+	s.Nil(err)
+	s.Equal(d, make([]*message.Message, 0))
+}
@@ -0,0 +1,69 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"fmt"
+
+	"github.com/sygmaprotocol/sygma-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// KeyValueStore is a key value store with atomic batch writes and ordered
+// iteration over keys used to maintain and query secondary indexes
+type KeyValueStore interface {
+	store.KeyValueReaderWriter
+	Write(batch *leveldb.Batch) error
+	// Iterate calls fn for each key with the prefix in key order, starting from the
+	// first key not lower than start, until fn returns false. Key and value are only
+	// valid until fn returns.
+	Iterate(prefix []byte, start []byte, fn func(key []byte, value []byte) bool) error
+}
+
+type LvlDB struct {
+	db *leveldb.DB
+}
+
+func NewLvlDB(path string) (*LvlDB, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error on opening leveldb: %w", err)
+	}
+	return &LvlDB{db: db}, nil
+}
+
+func (db *LvlDB) GetByKey(key []byte) ([]byte, error) {
+	return db.db.Get(key, nil)
+}
+
+func (db *LvlDB) SetByKey(key []byte, value []byte) error {
+	return db.db.Put(key, value, nil)
+}
+
+func (db *LvlDB) Write(batch *leveldb.Batch) error {
+	return db.db.Write(batch, nil)
+}
+
+func (db *LvlDB) Iterate(prefix []byte, start []byte, fn func(key []byte, value []byte) bool) error {
+	it := db.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer it.Release()
+
+	var ok bool
+	if start != nil {
+		ok = it.Seek(start)
+	} else {
+		ok = it.First()
+	}
+	for ; ok; ok = it.Next() {
+		if !fn(it.Key(), it.Value()) {
+			break
+		}
+	}
+	return it.Error()
+}
+
+func (db *LvlDB) Close() error {
+	return db.db.Close()
+}
@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./store/lvldb.go
+
+// Package mock_store is a generated GoMock package.
+package mock_store
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	leveldb "github.com/syndtr/goleveldb/leveldb"
+)
+
+// MockKeyValueStore is a mock of KeyValueStore interface.
+type MockKeyValueStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyValueStoreMockRecorder
+}
+
+// MockKeyValueStoreMockRecorder is the mock recorder for MockKeyValueStore.
+type MockKeyValueStoreMockRecorder struct {
+	mock *MockKeyValueStore
+}
+
+// NewMockKeyValueStore creates a new mock instance.
+func NewMockKeyValueStore(ctrl *gomock.Controller) *MockKeyValueStore {
+	mock := &MockKeyValueStore{ctrl: ctrl}
+	mock.recorder = &MockKeyValueStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyValueStore) EXPECT() *MockKeyValueStoreMockRecorder {
+	return m.recorder
+}
+
+// GetByKey mocks base method.
+func (m *MockKeyValueStore) GetByKey(key []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKey", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByKey indicates an expected call of GetByKey.
+func (mr *MockKeyValueStoreMockRecorder) GetByKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKey", reflect.TypeOf((*MockKeyValueStore)(nil).GetByKey), key)
+}
+
+// Iterate mocks base method.
+func (m *MockKeyValueStore) Iterate(prefix, start []byte, fn func([]byte, []byte) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Iterate", prefix, start, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Iterate indicates an expected call of Iterate.
+func (mr *MockKeyValueStoreMockRecorder) Iterate(prefix, start, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iterate", reflect.TypeOf((*MockKeyValueStore)(nil).Iterate), prefix, start, fn)
+}
+
+// SetByKey mocks base method.
+func (m *MockKeyValueStore) SetByKey(key, value []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetByKey", key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetByKey indicates an expected call of SetByKey.
+func (mr *MockKeyValueStoreMockRecorder) SetByKey(key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetByKey", reflect.TypeOf((*MockKeyValueStore)(nil).SetByKey), key, value)
+}
+
+// Write mocks base method.
+func (m *MockKeyValueStore) Write(batch *leveldb.Batch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockKeyValueStoreMockRecorder) Write(batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockKeyValueStore)(nil).Write), batch)
+}
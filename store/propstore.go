@@ -5,28 +5,127 @@ package store
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/sygmaprotocol/sygma-core/store"
 	"github.com/syndtr/goleveldb/leveldb"
 )
 
 type PropStatus string
 
 var (
-	KEY                     = "source:%d:destination:%d:depositNonce:%d"
+	KEY                 = "source:%d:destination:%d:depositNonce:%d"
+	PROPOSAL_RECORD_KEY = "proposal:source:%d:destination:%d:depositNonce:%d"
+	// Index keys point to proposal record keys. Numbers are zero padded so that status index keys are ordered
+	// by status, destination and time bucket of the last status change, destination index keys by destination
+	// and time index keys by time bucket of the last status change.
+	PROPOSAL_STATUS_INDEX_KEY      = "proposal:index:status:%s:%03d:%020d:%03d:%020d"
+	PROPOSAL_DESTINATION_INDEX_KEY = "proposal:index:destination:%03d:%03d:%020d"
+	PROPOSAL_TIME_INDEX_KEY        = "proposal:index:time:%020d:%03d:%03d:%020d"
+	// PROPOSAL_INDEX_VERSION_KEY stores the version of the index layout
+	PROPOSAL_INDEX_VERSION_KEY = "proposal:indexVersion"
+	// ProposalTimeBucket is the time range of the proposal time index buckets
+	ProposalTimeBucket = time.Hour
+
+	proposalIndexVersion = "1"
+
 	MissingProp  PropStatus = "missing"
 	PendingProp  PropStatus = "pending"
 	FailedProp   PropStatus = "failed"
 	ExecutedProp PropStatus = "executed"
+	// PermanentlyFailedProp marks proposals that were executed on chain but failed in the handler
+	// and are not retried unless an explicit retry is requested
+	PermanentlyFailedProp PropStatus = "permanentlyFailed"
 )
 
+// ProposalTransition is a change of the proposal status
+type ProposalTransition struct {
+	Status    PropStatus
+	Timestamp time.Time
+}
+
+// ProposalRecord holds the execution history of a proposal
+type ProposalRecord struct {
+	Source       uint8
+	Destination  uint8
+	DepositNonce uint64
+	MessageID    string
+	// Status is the current proposal status
+	Status      PropStatus
+	FirstSeen   time.Time
+	Transitions []ProposalTransition
+	// Attempts is the number of signing sessions started for the proposal
+	Attempts       int
+	SessionIDs     []string
+	TxHash         string
+	FailureReasons []string
+	// Recipient is the decoded recipient of the transfer
+	Recipient string
+}
+
+// ProposalUpdate describes a change of the proposal recorded by executors.
+// Empty fields are not recorded, so an update without status only records the
+// signing session, transaction hash, failure reason or recipient.
+type ProposalUpdate struct {
+	Source       uint8
+	Destination  uint8
+	DepositNonce uint64
+	MessageID    string
+	Status       PropStatus
+	// SessionID is set when a signing session for the proposal is started
+	SessionID string
+	// TxHash is the hash of the transaction that executed the proposal
+	TxHash string
+	// Reason is the reason of the proposal failure
+	Reason string
+	// Recipient is the decoded recipient of the transfer
+	Recipient string
+}
+
+// StatusChangedAt returns the time of the last status transition
+func (r *ProposalRecord) StatusChangedAt() time.Time {
+	if len(r.Transitions) == 0 {
+		return time.Time{}
+	}
+	return r.Transitions[len(r.Transitions)-1].Timestamp
+}
+
+// ProposalQuery selects proposal records, zero value fields match all proposals
+type ProposalQuery struct {
+	Status PropStatus
+	// Destination is the destination domain ID, 0 matches all destinations
+	Destination uint8
+	// ChangedAfter and ChangedBefore select proposals by the time of the last status transition
+	ChangedAfter  time.Time
+	ChangedBefore time.Time
+}
+
+func (q ProposalQuery) matches(record *ProposalRecord) bool {
+	if q.Status != "" && record.Status != q.Status {
+		return false
+	}
+	if q.Destination != 0 && record.Destination != q.Destination {
+		return false
+	}
+	if !q.ChangedAfter.IsZero() && record.StatusChangedAt().Before(q.ChangedAfter) {
+		return false
+	}
+	if !q.ChangedBefore.IsZero() && !record.StatusChangedAt().Before(q.ChangedBefore) {
+		return false
+	}
+	return true
+}
+
 type PropStore struct {
-	db store.KeyValueReaderWriter
+	db   KeyValueStore
+	lock sync.Mutex
 }
 
-func NewPropStore(db store.KeyValueReaderWriter) *PropStore {
+func NewPropStore(db KeyValueStore) *PropStore {
 	return &PropStore{
 		db: db,
 	}
@@ -63,3 +162,305 @@ func (ns *PropStore) PropStatus(source, destination uint8, depositNonce uint64)
 	status := PropStatus(string(v))
 	return status, nil
 }
+
+// UpdateProposal applies the update to the proposal record and stores the
+// proposal status if the update changes it. Record, status and indexes are
+// written atomically.
+func (ns *PropStore) UpdateProposal(update ProposalUpdate) error {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+
+	key := fmt.Sprintf(PROPOSAL_RECORD_KEY, update.Source, update.Destination, update.DepositNonce)
+	record, err := ns.record(key)
+	if err != nil {
+		return err
+	}
+	batch := new(leveldb.Batch)
+	now := time.Now().UTC()
+	if record == nil {
+		record = &ProposalRecord{
+			Source:       update.Source,
+			Destination:  update.Destination,
+			DepositNonce: update.DepositNonce,
+			FirstSeen:    now,
+		}
+		batch.Put(destinationIndexKey(record), []byte(key))
+	}
+	indexedStatus := record.Status
+	indexedTime := record.StatusChangedAt()
+	record.Status, err = ns.PropStatus(update.Source, update.Destination, update.DepositNonce)
+	if err != nil {
+		return err
+	}
+
+	if update.MessageID != "" {
+		record.MessageID = update.MessageID
+	}
+	if update.SessionID != "" {
+		record.Attempts++
+		record.SessionIDs = append(record.SessionIDs, update.SessionID)
+	}
+	if update.TxHash != "" {
+		record.TxHash = update.TxHash
+	}
+	if update.Reason != "" {
+		record.FailureReasons = append(record.FailureReasons, update.Reason)
+	}
+	if update.Recipient != "" {
+		record.Recipient = update.Recipient
+	}
+	if update.Status != "" && update.Status != record.Status {
+		if indexedStatus != "" {
+			batch.Delete(statusIndexKey(indexedStatus, indexedTime, record))
+			batch.Delete(timeIndexKey(indexedTime, record))
+		}
+		record.Status = update.Status
+		record.Transitions = append(record.Transitions, ProposalTransition{
+			Status:    update.Status,
+			Timestamp: now,
+		})
+		batch.Put(statusIndexKey(record.Status, now, record), []byte(key))
+		batch.Put(timeIndexKey(now, record), []byte(key))
+		batch.Put([]byte(fmt.Sprintf(KEY, update.Source, update.Destination, update.DepositNonce)), []byte(record.Status))
+	}
+
+	v, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	batch.Put([]byte(key), v)
+	return ns.db.Write(batch)
+}
+
+// ProposalRecord returns the execution history of the proposal or nil if
+// no executor recorded the proposal
+func (ns *PropStore) ProposalRecord(source, destination uint8, depositNonce uint64) (*ProposalRecord, error) {
+	record, err := ns.record(fmt.Sprintf(PROPOSAL_RECORD_KEY, source, destination, depositNonce))
+	if err != nil || record == nil {
+		return record, err
+	}
+
+	record.Status, err = ns.PropStatus(source, destination, depositNonce)
+	return record, err
+}
+
+// Proposals returns up to limit proposal records that match the query, starting after the cursor.
+// Records are read through the status index if the query has a status and a destination, through
+// the time index if the query has a time range, through the status or destination index if the query
+// has only one of them and through the time index otherwise. The returned cursor continues paging
+// and is empty once there are no more index keys to read.
+func (ns *PropStore) Proposals(query ProposalQuery, cursor string, limit int) ([]*ProposalRecord, string, error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("invalid limit %d", limit)
+	}
+	prefix, start, end := indexRange(query)
+	if cursor != "" {
+		if !strings.HasPrefix(cursor, prefix) {
+			return nil, "", fmt.Errorf("cursor %s does not belong to the query", cursor)
+		}
+		start = cursor + "\x00"
+	}
+
+	records := make([]*ProposalRecord, 0, limit)
+	next := ""
+	more := false
+	var recordErr error
+	err := ns.db.Iterate([]byte(prefix), []byte(start), func(key []byte, value []byte) bool {
+		if end != "" && string(key) > end {
+			return false
+		}
+		if len(records) == limit {
+			more = true
+			return false
+		}
+		next = string(key)
+
+		record, err := ns.record(string(value))
+		if err != nil {
+			recordErr = err
+			return false
+		}
+		if record == nil || !query.matches(record) {
+			return true
+		}
+		records = append(records, record)
+		return true
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if recordErr != nil {
+		return nil, "", recordErr
+	}
+	if !more {
+		next = ""
+	}
+	return records, next, nil
+}
+
+// indexRange returns the index prefix and the key range of the index that is read for the query
+func indexRange(query ProposalQuery) (prefix string, start string, end string) {
+	timeRange := !query.ChangedAfter.IsZero() || !query.ChangedBefore.IsZero()
+	switch {
+	case query.Status != "" && query.Destination != 0:
+		prefix = fmt.Sprintf("proposal:index:status:%s:%03d:", query.Status, query.Destination)
+		start, end = bucketRange(prefix, query)
+	case timeRange:
+		prefix = "proposal:index:time:"
+		start, end = bucketRange(prefix, query)
+	case query.Status != "":
+		prefix = fmt.Sprintf("proposal:index:status:%s:", query.Status)
+	case query.Destination != 0:
+		prefix = fmt.Sprintf("proposal:index:destination:%03d:", query.Destination)
+	default:
+		prefix = "proposal:index:time:"
+	}
+	return prefix, start, end
+}
+
+// bucketRange returns the key range of time buckets of the query for index keys
+// that continue with the time bucket after the prefix
+func bucketRange(prefix string, query ProposalQuery) (start string, end string) {
+	if !query.ChangedAfter.IsZero() {
+		start = fmt.Sprintf("%s%020d:", prefix, timeBucket(query.ChangedAfter))
+	}
+	if !query.ChangedBefore.IsZero() {
+		end = fmt.Sprintf("%s%020d:\xff", prefix, timeBucket(query.ChangedBefore))
+	}
+	return start, end
+}
+
+// MigrateIndexes rebuilds proposal indexes written with a previous index layout and creates
+// records of proposal statuses stored before proposal records were introduced, so that existing
+// proposals can be queried. Statuses without a record are indexed with an unknown time of change.
+// Migration is skipped once indexes have the current layout.
+func (ns *PropStore) MigrateIndexes() error {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+
+	version, err := ns.db.GetByKey([]byte(PROPOSAL_INDEX_VERSION_KEY))
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		return err
+	}
+	if string(version) == proposalIndexVersion {
+		return nil
+	}
+
+	batch := new(leveldb.Batch)
+	err = ns.db.Iterate([]byte("proposal:index:"), nil, func(key []byte, value []byte) bool {
+		batch.Delete(append([]byte{}, key...))
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	var migrationErr error
+	err = ns.db.Iterate([]byte("proposal:source:"), nil, func(key []byte, value []byte) bool {
+		var record ProposalRecord
+		err := json.Unmarshal(value, &record)
+		if err != nil {
+			migrationErr = err
+			return false
+		}
+		putIndexes(batch, string(key), &record)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if migrationErr != nil {
+		return migrationErr
+	}
+
+	err = ns.db.Iterate([]byte("source:"), nil, func(key []byte, value []byte) bool {
+		var source, destination uint8
+		var depositNonce uint64
+		_, err := fmt.Sscanf(string(key), KEY, &source, &destination, &depositNonce)
+		if err != nil {
+			return true
+		}
+
+		recordKey := fmt.Sprintf(PROPOSAL_RECORD_KEY, source, destination, depositNonce)
+		_, err = ns.db.GetByKey([]byte(recordKey))
+		if err == nil {
+			return true
+		}
+		if !errors.Is(err, leveldb.ErrNotFound) {
+			migrationErr = err
+			return false
+		}
+
+		record := &ProposalRecord{
+			Source:       source,
+			Destination:  destination,
+			DepositNonce: depositNonce,
+			Status:       PropStatus(string(value)),
+		}
+		v, err := json.Marshal(record)
+		if err != nil {
+			migrationErr = err
+			return false
+		}
+		batch.Put([]byte(recordKey), v)
+		putIndexes(batch, recordKey, record)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if migrationErr != nil {
+		return migrationErr
+	}
+
+	batch.Put([]byte(PROPOSAL_INDEX_VERSION_KEY), []byte(proposalIndexVersion))
+	return ns.db.Write(batch)
+}
+
+func (ns *PropStore) record(key string) (*ProposalRecord, error) {
+	v, err := ns.db.GetByKey([]byte(key))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record ProposalRecord
+	err = json.Unmarshal(v, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// putIndexes writes destination index of the record and status and time index of the
+// record status if the status was set
+func putIndexes(batch *leveldb.Batch, key string, record *ProposalRecord) {
+	batch.Put(destinationIndexKey(record), []byte(key))
+	if record.Status == "" || record.Status == MissingProp {
+		return
+	}
+	batch.Put(statusIndexKey(record.Status, record.StatusChangedAt(), record), []byte(key))
+	batch.Put(timeIndexKey(record.StatusChangedAt(), record), []byte(key))
+}
+
+func statusIndexKey(status PropStatus, t time.Time, record *ProposalRecord) []byte {
+	return []byte(fmt.Sprintf(PROPOSAL_STATUS_INDEX_KEY, status, record.Destination, timeBucket(t), record.Source, record.DepositNonce))
+}
+
+func destinationIndexKey(record *ProposalRecord) []byte {
+	return []byte(fmt.Sprintf(PROPOSAL_DESTINATION_INDEX_KEY, record.Destination, record.Source, record.DepositNonce))
+}
+
+func timeIndexKey(t time.Time, record *ProposalRecord) []byte {
+	return []byte(fmt.Sprintf(PROPOSAL_TIME_INDEX_KEY, timeBucket(t), record.Destination, record.Source, record.DepositNonce))
+}
+
+// timeBucket returns the start of the time bucket, unknown time is in the first bucket
+func timeBucket(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Truncate(ProposalTimeBucket).Unix()
+}
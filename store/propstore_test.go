@@ -3,18 +3,19 @@ package store_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/sygma-relayer/store"
+	mock_store "github.com/ChainSafe/sygma-relayer/store/mock"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
-	mock_store "github.com/sygmaprotocol/sygma-core/mock"
 	"github.com/syndtr/goleveldb/leveldb"
-	"go.uber.org/mock/gomock"
 )
 
 type PropStoreTestSuite struct {
 	suite.Suite
 	nonceStore           *store.PropStore
-	keyValueReaderWriter *mock_store.MockKeyValueReaderWriter
+	keyValueReaderWriter *mock_store.MockKeyValueStore
 }
 
 func TestRunPropStoreTestSuite(t *testing.T) {
@@ -23,7 +24,7 @@ func TestRunPropStoreTestSuite(t *testing.T) {
 
 func (s *PropStoreTestSuite) SetupTest() {
 	gomockController := gomock.NewController(s.T())
-	s.keyValueReaderWriter = mock_store.NewMockKeyValueReaderWriter(gomockController)
+	s.keyValueReaderWriter = mock_store.NewMockKeyValueStore(gomockController)
 	s.nonceStore = store.NewPropStore(s.keyValueReaderWriter)
 }
 
@@ -73,3 +74,243 @@ func (s *PropStoreTestSuite) TestGetNonce_SuccessfulFetch() {
 	s.Nil(err)
 	s.Equal(status, store.ExecutedProp)
 }
+
+type ProposalRecordTestSuite struct {
+	suite.Suite
+	db        *store.LvlDB
+	propStore *store.PropStore
+}
+
+func TestRunProposalRecordTestSuite(t *testing.T) {
+	suite.Run(t, new(ProposalRecordTestSuite))
+}
+
+func (s *ProposalRecordTestSuite) SetupTest() {
+	db, err := store.NewLvlDB(s.T().TempDir())
+	s.Require().Nil(err)
+	s.db = db
+	s.propStore = store.NewPropStore(db)
+}
+
+func (s *ProposalRecordTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *ProposalRecordTestSuite) Test_UpdateProposal_NewRecord() {
+	err := s.propStore.UpdateProposal(store.ProposalUpdate{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: 3,
+		MessageID:    "messageID",
+		Status:       store.PendingProp,
+		SessionID:    "session",
+	})
+	s.Nil(err)
+
+	record, err := s.propStore.ProposalRecord(1, 2, 3)
+	s.Nil(err)
+	s.Equal(record.MessageID, "messageID")
+	s.Equal(record.Status, store.PendingProp)
+	s.Equal(record.Attempts, 1)
+	s.Equal(record.SessionIDs, []string{"session"})
+	s.Equal(len(record.Transitions), 1)
+	s.Equal(record.Transitions[0].Status, store.PendingProp)
+	s.False(record.FirstSeen.IsZero())
+	status, _ := s.propStore.PropStatus(1, 2, 3)
+	s.Equal(status, store.PendingProp)
+}
+
+func (s *ProposalRecordTestSuite) Test_UpdateProposal_StatusNotChanged() {
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 3, Status: store.PendingProp, SessionID: "session"})
+
+	err := s.propStore.UpdateProposal(store.ProposalUpdate{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: 3,
+		Status:       store.PendingProp,
+		TxHash:       "0xhash",
+		Reason:       "reason",
+	})
+	s.Nil(err)
+
+	record, _ := s.propStore.ProposalRecord(1, 2, 3)
+	s.Equal(record.TxHash, "0xhash")
+	s.Equal(record.FailureReasons, []string{"reason"})
+	s.Equal(record.Attempts, 1)
+	s.Equal(len(record.Transitions), 1)
+}
+
+func (s *ProposalRecordTestSuite) Test_UpdateProposal_StatusChanged() {
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 3, Status: store.PendingProp})
+
+	err := s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 3, Status: store.ExecutedProp})
+	s.Nil(err)
+
+	record, _ := s.propStore.ProposalRecord(1, 2, 3)
+	s.Equal(len(record.Transitions), 2)
+	s.Equal(record.Status, store.ExecutedProp)
+	pending, _, _ := s.propStore.Proposals(store.ProposalQuery{Status: store.PendingProp}, "", 10)
+	s.Equal(len(pending), 0)
+	executed, _, _ := s.propStore.Proposals(store.ProposalQuery{Status: store.ExecutedProp}, "", 10)
+	s.Equal(len(executed), 1)
+}
+
+func (s *ProposalRecordTestSuite) Test_UpdateProposal_RecipientBeforeStatus() {
+	err := s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 3, Recipient: "recipient"})
+	s.Nil(err)
+
+	err = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 3, Status: store.PendingProp})
+	s.Nil(err)
+
+	record, _ := s.propStore.ProposalRecord(1, 2, 3)
+	s.Equal(record.Recipient, "recipient")
+	s.Equal(record.Status, store.PendingProp)
+	pending, _, _ := s.propStore.Proposals(store.ProposalQuery{Status: store.PendingProp}, "", 10)
+	s.Equal(len(pending), 1)
+}
+
+func (s *ProposalRecordTestSuite) Test_ProposalRecord_NotFound() {
+	record, err := s.propStore.ProposalRecord(1, 2, 3)
+
+	s.Nil(err)
+	s.Nil(record)
+}
+
+func (s *ProposalRecordTestSuite) Test_Proposals_ByStatusAndDestination() {
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 3, DepositNonce: 1, Status: store.FailedProp})
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 2, Status: store.FailedProp})
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 2, Destination: 3, DepositNonce: 3, Status: store.FailedProp})
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 3, DepositNonce: 4, Status: store.ExecutedProp})
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 3, DepositNonce: 5, Status: store.PermanentlyFailedProp})
+
+	records, cursor, err := s.propStore.Proposals(store.ProposalQuery{Status: store.FailedProp, Destination: 3}, "", 10)
+
+	s.Nil(err)
+	s.Equal(cursor, "")
+	s.Equal(len(records), 2)
+	s.Equal(records[0].DepositNonce, uint64(1))
+	s.Equal(records[1].DepositNonce, uint64(3))
+}
+
+func (s *ProposalRecordTestSuite) Test_Proposals_ByDestination() {
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 3, DepositNonce: 1, Status: store.FailedProp})
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 2, Status: store.FailedProp})
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 3, DepositNonce: 4, Status: store.ExecutedProp})
+
+	records, _, err := s.propStore.Proposals(store.ProposalQuery{Destination: 3}, "", 10)
+
+	s.Nil(err)
+	s.Equal(len(records), 2)
+}
+
+func (s *ProposalRecordTestSuite) Test_Proposals_ByTime() {
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 3, DepositNonce: 1, Status: store.PendingProp})
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 3, DepositNonce: 2, Status: store.PendingProp})
+
+	records, _, err := s.propStore.Proposals(store.ProposalQuery{ChangedBefore: time.Now().Add(-time.Hour)}, "", 10)
+	s.Nil(err)
+	s.Equal(len(records), 0)
+
+	records, _, err = s.propStore.Proposals(store.ProposalQuery{
+		ChangedAfter:  time.Now().Add(-time.Hour),
+		ChangedBefore: time.Now().Add(time.Minute),
+	}, "", 10)
+	s.Nil(err)
+	s.Equal(len(records), 2)
+
+	records, _, err = s.propStore.Proposals(store.ProposalQuery{Status: store.PendingProp, ChangedBefore: time.Now().Add(-time.Hour)}, "", 10)
+	s.Nil(err)
+	s.Equal(len(records), 0)
+}
+
+func (s *ProposalRecordTestSuite) Test_Proposals_Paging() {
+	for nonce := uint64(1); nonce <= 5; nonce++ {
+		_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: nonce, Status: store.PendingProp})
+	}
+
+	nonces := []uint64{}
+	cursor := ""
+	for {
+		records, next, err := s.propStore.Proposals(store.ProposalQuery{Status: store.PendingProp}, cursor, 2)
+		s.Nil(err)
+		for _, r := range records {
+			nonces = append(nonces, r.DepositNonce)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	s.Equal(nonces, []uint64{1, 2, 3, 4, 5})
+}
+
+func (s *ProposalRecordTestSuite) Test_Proposals_InvalidCursor() {
+	_, _, err := s.propStore.Proposals(store.ProposalQuery{Status: store.PendingProp}, "proposal:index:status:failed:", 2)
+
+	s.NotNil(err)
+}
+
+func (s *ProposalRecordTestSuite) Test_Proposals_LastFullPage() {
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 1, Status: store.PendingProp})
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 2, Status: store.PendingProp})
+
+	records, cursor, err := s.propStore.Proposals(store.ProposalQuery{Status: store.PendingProp}, "", 2)
+
+	s.Nil(err)
+	s.Equal(len(records), 2)
+	s.Equal(cursor, "")
+}
+
+func (s *ProposalRecordTestSuite) Test_Proposals_ByStatusDestinationAndTime() {
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 1, Status: store.PendingProp})
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 3, DepositNonce: 2, Status: store.PendingProp})
+
+	records, _, err := s.propStore.Proposals(store.ProposalQuery{Status: store.PendingProp, Destination: 2, ChangedBefore: time.Now().Add(-time.Hour)}, "", 10)
+	s.Nil(err)
+	s.Equal(len(records), 0)
+
+	records, _, err = s.propStore.Proposals(store.ProposalQuery{Status: store.PendingProp, Destination: 2, ChangedBefore: time.Now().Add(time.Minute)}, "", 10)
+	s.Nil(err)
+	s.Equal(len(records), 1)
+	s.Equal(records[0].DepositNonce, uint64(1))
+}
+
+func (s *ProposalRecordTestSuite) Test_MigrateIndexes() {
+	_ = s.propStore.StorePropStatus(1, 2, 3, store.PendingProp)
+	_ = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 4, Status: store.FailedProp})
+	recordKey := "proposal:source:1:destination:2:depositNonce:4"
+	_ = s.db.SetByKey([]byte("proposal:index:status:failed:002:001:00000000000000000004"), []byte(recordKey))
+
+	err := s.propStore.MigrateIndexes()
+	s.Nil(err)
+
+	stuck, _, err := s.propStore.Proposals(store.ProposalQuery{Status: store.PendingProp, Destination: 2, ChangedBefore: time.Now().Add(-time.Hour)}, "", 10)
+	s.Nil(err)
+	s.Equal(len(stuck), 1)
+	s.Equal(stuck[0].DepositNonce, uint64(3))
+	failed, _, err := s.propStore.Proposals(store.ProposalQuery{Status: store.FailedProp}, "", 10)
+	s.Nil(err)
+	s.Equal(len(failed), 1)
+	s.Equal(failed[0].DepositNonce, uint64(4))
+	all, _, err := s.propStore.Proposals(store.ProposalQuery{Destination: 2}, "", 10)
+	s.Nil(err)
+	s.Equal(len(all), 2)
+
+	err = s.propStore.UpdateProposal(store.ProposalUpdate{Source: 1, Destination: 2, DepositNonce: 3, Status: store.ExecutedProp})
+	s.Nil(err)
+	stuck, _, _ = s.propStore.Proposals(store.ProposalQuery{Status: store.PendingProp}, "", 10)
+	s.Equal(len(stuck), 0)
+}
+
+func (s *ProposalRecordTestSuite) Test_MigrateIndexes_AlreadyMigrated() {
+	err := s.propStore.MigrateIndexes()
+	s.Nil(err)
+	_ = s.propStore.StorePropStatus(1, 2, 3, store.PendingProp)
+
+	err = s.propStore.MigrateIndexes()
+	s.Nil(err)
+
+	records, _, _ := s.propStore.Proposals(store.ProposalQuery{Status: store.PendingProp}, "", 10)
+	s.Equal(len(records), 0)
+}
@@ -0,0 +1,145 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type QuarantineStatus string
+
+var (
+	QUARANTINE_KEY                     = "quarantine:source:%d:destination:%d:depositNonce:%d"
+	QUARANTINE_PREFIX                  = "quarantine:source:"
+	QuarantinedProp   QuarantineStatus = "quarantined"
+	ApprovedProp      QuarantineStatus = "approved"
+	// RejectedProp marks proposals that can never be executed and can not be approved
+	RejectedProp QuarantineStatus = "rejected"
+)
+
+// QuarantineRecord holds a proposal that was held back from execution for manual review
+type QuarantineRecord struct {
+	Source       uint8
+	Destination  uint8
+	DepositNonce uint64
+	ResourceID   string
+	MessageID    string
+	Reason       string
+	Status       QuarantineStatus
+	Timestamp    time.Time
+}
+
+type QuarantineStore struct {
+	db   KeyValueStore
+	lock sync.Mutex
+}
+
+func NewQuarantineStore(db KeyValueStore) *QuarantineStore {
+	return &QuarantineStore{
+		db: db,
+	}
+}
+
+// Quarantine stores the quarantine record of the proposal unless the proposal
+// already has one, so that reviewed records are never overwritten
+func (s *QuarantineStore) Quarantine(record QuarantineRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := fmt.Sprintf(QUARANTINE_KEY, record.Source, record.Destination, record.DepositNonce)
+	existing, err := s.record(key)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+	return s.storeRecord(key, record)
+}
+
+// QuarantineRecord returns the quarantine record of the proposal or nil if
+// the proposal was never quarantined
+func (s *QuarantineStore) QuarantineRecord(source, destination uint8, depositNonce uint64) (*QuarantineRecord, error) {
+	return s.record(fmt.Sprintf(QUARANTINE_KEY, source, destination, depositNonce))
+}
+
+// QuarantinedProposals returns all quarantine records ordered by time of quarantine
+func (s *QuarantineStore) QuarantinedProposals() ([]*QuarantineRecord, error) {
+	records := make([]*QuarantineRecord, 0)
+	var decodeErr error
+	err := s.db.Iterate([]byte(QUARANTINE_PREFIX), nil, func(key []byte, value []byte) bool {
+		var record QuarantineRecord
+		decodeErr = json.Unmarshal(value, &record)
+		if decodeErr != nil {
+			decodeErr = fmt.Errorf("failed decoding quarantine record %s: %w", key, decodeErr)
+			return false
+		}
+		records = append(records, &record)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	return records, nil
+}
+
+// Approve marks quarantined proposal as reviewed so it is executed on the next retry
+func (s *QuarantineStore) Approve(source, destination uint8, depositNonce uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := fmt.Sprintf(QUARANTINE_KEY, source, destination, depositNonce)
+	record, err := s.record(key)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return fmt.Errorf("proposal %d-%d-%d not quarantined", source, destination, depositNonce)
+	}
+	if record.Status == RejectedProp {
+		return fmt.Errorf("proposal %d-%d-%d rejected: %s", source, destination, depositNonce, record.Reason)
+	}
+
+	record.Status = ApprovedProp
+	return s.storeRecord(key, *record)
+}
+
+func (s *QuarantineStore) record(key string) (*QuarantineRecord, error) {
+	v, err := s.db.GetByKey([]byte(key))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record QuarantineRecord
+	err = json.Unmarshal(v, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (s *QuarantineStore) storeRecord(key string, record QuarantineRecord) error {
+	v, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.db.SetByKey([]byte(key), v)
+}
@@ -0,0 +1,128 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+	mock_store "github.com/ChainSafe/sygma-relayer/store/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type QuarantineStoreTestSuite struct {
+	suite.Suite
+	quarantineStore      *store.QuarantineStore
+	keyValueReaderWriter *mock_store.MockKeyValueStore
+}
+
+func TestRunQuarantineStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(QuarantineStoreTestSuite))
+}
+
+func (s *QuarantineStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueReaderWriter = mock_store.NewMockKeyValueStore(gomockController)
+	s.quarantineStore = store.NewQuarantineStore(s.keyValueReaderWriter)
+}
+
+func (s *QuarantineStoreTestSuite) Test_Quarantine_StoresRecord() {
+	key := "quarantine:source:1:destination:2:depositNonce:3"
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte(key), gomock.Any()).Return(nil)
+
+	err := s.quarantineStore.Quarantine(store.QuarantineRecord{Source: 1, Destination: 2, DepositNonce: 3})
+
+	s.Nil(err)
+}
+
+func (s *QuarantineStoreTestSuite) Test_Quarantine_KeepsExistingRecord() {
+	key := "quarantine:source:1:destination:2:depositNonce:3"
+	existing, _ := json.Marshal(store.QuarantineRecord{Source: 1, Destination: 2, DepositNonce: 3, Status: store.ApprovedProp})
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return(existing, nil)
+
+	err := s.quarantineStore.Quarantine(store.QuarantineRecord{Source: 1, Destination: 2, DepositNonce: 3, Status: store.QuarantinedProp})
+
+	s.Nil(err)
+}
+
+func (s *QuarantineStoreTestSuite) Test_QuarantineRecord_NotFound() {
+	key := "quarantine:source:1:destination:2:depositNonce:3"
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+
+	record, err := s.quarantineStore.QuarantineRecord(1, 2, 3)
+
+	s.Nil(err)
+	s.Nil(record)
+}
+
+func (s *QuarantineStoreTestSuite) Test_Approve_NotQuarantined() {
+	key := "quarantine:source:1:destination:2:depositNonce:3"
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+
+	err := s.quarantineStore.Approve(1, 2, 3)
+
+	s.NotNil(err)
+}
+
+func (s *QuarantineStoreTestSuite) Test_Approve_Rejected() {
+	key := "quarantine:source:1:destination:2:depositNonce:3"
+	record, _ := json.Marshal(store.QuarantineRecord{Source: 1, Destination: 2, DepositNonce: 3, Status: store.RejectedProp})
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return(record, nil)
+
+	err := s.quarantineStore.Approve(1, 2, 3)
+
+	s.NotNil(err)
+}
+
+func (s *QuarantineStoreTestSuite) Test_Approve_UpdatesStatus() {
+	key := "quarantine:source:1:destination:2:depositNonce:3"
+	record, _ := json.Marshal(store.QuarantineRecord{Source: 1, Destination: 2, DepositNonce: 3, Status: store.QuarantinedProp})
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return(record, nil)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte(key), gomock.Any()).DoAndReturn(func(key []byte, value []byte) error {
+		var r store.QuarantineRecord
+		_ = json.Unmarshal(value, &r)
+		s.Equal(r.Status, store.ApprovedProp)
+		return nil
+	})
+
+	err := s.quarantineStore.Approve(1, 2, 3)
+
+	s.Nil(err)
+}
+
+func (s *QuarantineStoreTestSuite) Test_QuarantinedProposals() {
+	first, _ := json.Marshal(store.QuarantineRecord{Source: 1, Destination: 2, DepositNonce: 10, Reason: "first", Timestamp: time.Unix(1, 0)})
+	second, _ := json.Marshal(store.QuarantineRecord{Source: 1, Destination: 2, DepositNonce: 9, Reason: "second", Timestamp: time.Unix(2, 0)})
+	s.keyValueReaderWriter.EXPECT().Iterate([]byte(store.QUARANTINE_PREFIX), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(prefix []byte, start []byte, fn func(key []byte, value []byte) bool) error {
+			if fn([]byte("quarantine:source:1:destination:2:depositNonce:10"), first) {
+				fn([]byte("quarantine:source:1:destination:2:depositNonce:9"), second)
+			}
+			return nil
+		})
+
+	records, err := s.quarantineStore.QuarantinedProposals()
+
+	s.Nil(err)
+	s.Equal(len(records), 2)
+	s.Equal(records[0].Reason, "first")
+	s.Equal(records[1].Reason, "second")
+}
+
+func (s *QuarantineStoreTestSuite) Test_QuarantinedProposals_InvalidRecord() {
+	s.keyValueReaderWriter.EXPECT().Iterate([]byte(store.QUARANTINE_PREFIX), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(prefix []byte, start []byte, fn func(key []byte, value []byte) bool) error {
+			fn([]byte("quarantine:source:1:destination:2:depositNonce:3"), []byte("invalid"))
+			return nil
+		})
+
+	_, err := s.quarantineStore.QuarantinedProposals()
+
+	s.NotNil(err)
+}
@@ -0,0 +1,118 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package topology
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// PeerDiff contains address changes of a peer present in both topologies
+type PeerDiff struct {
+	ID           peer.ID
+	AddedAddrs   []ma.Multiaddr
+	RemovedAddrs []ma.Multiaddr
+}
+
+// TopologyDiff contains changes between two network topologies
+type TopologyDiff struct {
+	AddedPeers   []*peer.AddrInfo
+	RemovedPeers []*peer.AddrInfo
+	ChangedPeers []PeerDiff
+	OldThreshold int
+	NewThreshold int
+}
+
+// Diff compares the old and new topology
+func Diff(old *NetworkTopology, new *NetworkTopology) *TopologyDiff {
+	diff := &TopologyDiff{
+		OldThreshold: old.Threshold,
+		NewThreshold: new.Threshold,
+	}
+
+	oldPeers := peersByID(old.Peers)
+	newPeers := peersByID(new.Peers)
+	for _, p := range new.Peers {
+		oldPeer, ok := oldPeers[p.ID]
+		if !ok {
+			diff.AddedPeers = append(diff.AddedPeers, p)
+			continue
+		}
+
+		peerDiff := PeerDiff{
+			ID:           p.ID,
+			AddedAddrs:   missingAddrs(p.Addrs, oldPeer.Addrs),
+			RemovedAddrs: missingAddrs(oldPeer.Addrs, p.Addrs),
+		}
+		if len(peerDiff.AddedAddrs) != 0 || len(peerDiff.RemovedAddrs) != 0 {
+			diff.ChangedPeers = append(diff.ChangedPeers, peerDiff)
+		}
+	}
+	for _, p := range old.Peers {
+		if _, ok := newPeers[p.ID]; !ok {
+			diff.RemovedPeers = append(diff.RemovedPeers, p)
+		}
+	}
+	return diff
+}
+
+// IsEmpty returns true if topologies have the same peers, addresses and threshold
+func (d *TopologyDiff) IsEmpty() bool {
+	return d.IsAddressOnly() && len(d.ChangedPeers) == 0
+}
+
+// IsAddressOnly returns true if topologies have the same peers and threshold,
+// meaning that the change does not require resharing
+func (d *TopologyDiff) IsAddressOnly() bool {
+	return len(d.AddedPeers) == 0 && len(d.RemovedPeers) == 0 && d.OldThreshold == d.NewThreshold
+}
+
+func (d *TopologyDiff) String() string {
+	if d.IsEmpty() {
+		return "topologies are equal\n"
+	}
+
+	b := &strings.Builder{}
+	if d.OldThreshold != d.NewThreshold {
+		fmt.Fprintf(b, "threshold: %d -> %d\n", d.OldThreshold, d.NewThreshold)
+	}
+	for _, p := range d.AddedPeers {
+		fmt.Fprintf(b, "+ peer %s %s\n", p.ID, p.Addrs)
+	}
+	for _, p := range d.RemovedPeers {
+		fmt.Fprintf(b, "- peer %s %s\n", p.ID, p.Addrs)
+	}
+	for _, p := range d.ChangedPeers {
+		fmt.Fprintf(b, "~ peer %s\n", p.ID)
+		for _, addr := range p.AddedAddrs {
+			fmt.Fprintf(b, "    + %s\n", addr)
+		}
+		for _, addr := range p.RemovedAddrs {
+			fmt.Fprintf(b, "    - %s\n", addr)
+		}
+	}
+	return b.String()
+}
+
+func peersByID(peers []*peer.AddrInfo) map[peer.ID]*peer.AddrInfo {
+	byID := make(map[peer.ID]*peer.AddrInfo, len(peers))
+	for _, p := range peers {
+		byID[p.ID] = p
+	}
+	return byID
+}
+
+// missingAddrs returns addresses from addrs that are not contained in other
+func missingAddrs(addrs []ma.Multiaddr, other []ma.Multiaddr) []ma.Multiaddr {
+	var missing []ma.Multiaddr
+	for _, addr := range addrs {
+		if !ma.Contains(other, addr) {
+			missing = append(missing, addr)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,89 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package topology_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/topology"
+)
+
+type DiffTestSuite struct {
+	suite.Suite
+
+	old *topology.NetworkTopology
+}
+
+func TestRunDiffTestSuite(t *testing.T) {
+	suite.Run(t, new(DiffTestSuite))
+}
+
+func (s *DiffTestSuite) SetupTest() {
+	s.old = s.topology(&topology.RawTopology{
+		Peers: []topology.RawPeer{
+			{PeerAddress: "/dns4/relayer1/tcp/9000/p2p/QmcvEg7jGvuxdsUFRUiE4VdrL2P1Yeju5L83BsJvvXz7zX"},
+			{PeerAddress: "/dns4/relayer2/tcp/9001/p2p/QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT"},
+		},
+		Threshold: "1",
+	})
+}
+
+func (s *DiffTestSuite) topology(rawTopology *topology.RawTopology) *topology.NetworkTopology {
+	tp, err := topology.ProcessRawTopology(rawTopology)
+	s.Nil(err)
+	return tp
+}
+
+func (s *DiffTestSuite) Test_EqualTopologies() {
+	diff := topology.Diff(s.old, s.old)
+
+	s.True(diff.IsEmpty())
+	s.True(diff.IsAddressOnly())
+	s.Equal(diff.String(), "topologies are equal\n")
+}
+
+func (s *DiffTestSuite) Test_AddressChange() {
+	new := s.topology(&topology.RawTopology{
+		Peers: []topology.RawPeer{
+			{PeerAddress: "/dns4/relayer1/tcp/9000/p2p/QmcvEg7jGvuxdsUFRUiE4VdrL2P1Yeju5L83BsJvvXz7zX"},
+			{
+				PeerAddress: "/dns4/relayer2-new/tcp/9001/p2p/QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT",
+				DNSNames:    []string{"relayer2.sygma.io:9001"},
+			},
+		},
+		Threshold: "1",
+	})
+
+	diff := topology.Diff(s.old, new)
+
+	s.False(diff.IsEmpty())
+	s.True(diff.IsAddressOnly())
+	s.Equal(diff.String(), "~ peer QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT\n"+
+		"    + /dns4/relayer2-new/tcp/9001\n"+
+		"    + /dns/relayer2.sygma.io/tcp/9001\n"+
+		"    - /dns4/relayer2/tcp/9001\n")
+}
+
+func (s *DiffTestSuite) Test_PeerAndThresholdChange() {
+	new := s.topology(&topology.RawTopology{
+		Peers: []topology.RawPeer{
+			{PeerAddress: "/dns4/relayer1/tcp/9000/p2p/QmcvEg7jGvuxdsUFRUiE4VdrL2P1Yeju5L83BsJvvXz7zX"},
+			{PeerAddress: "/dns4/relayer3/tcp/9002/p2p/QmYAYuLUPNwYEBYJaKHcE7NKjUhiUV8txx2xDXHvcYa1xK"},
+		},
+		Threshold: "2",
+	})
+
+	diff := topology.Diff(s.old, new)
+
+	s.False(diff.IsAddressOnly())
+	s.Equal(len(diff.AddedPeers), 1)
+	s.Equal(diff.AddedPeers[0].ID.Pretty(), "QmYAYuLUPNwYEBYJaKHcE7NKjUhiUV8txx2xDXHvcYa1xK")
+	s.Equal(len(diff.RemovedPeers), 1)
+	s.Equal(diff.RemovedPeers[0].ID.Pretty(), "QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT")
+	s.Equal(diff.String(), "threshold: 1 -> 2\n"+
+		"+ peer QmYAYuLUPNwYEBYJaKHcE7NKjUhiUV8txx2xDXHvcYa1xK [/dns4/relayer3/tcp/9002]\n"+
+		"- peer QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT [/dns4/relayer2/tcp/9001]\n")
+}
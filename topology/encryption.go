@@ -8,8 +8,176 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+type Algorithm byte
+
+const (
+	AESGCM Algorithm = iota + 1
+	XChaCha20Poly1305
 )
 
+const (
+	formatVersion byte = 1
+	argon2idKDF   byte = 1
+
+	saltLength = 16
+	keyLength  = 32
+	// maxTime and maxMemory limit the KDF cost allowed when decrypting
+	maxTime   = 16
+	maxMemory = 1024 * 1024
+)
+
+var (
+	magic        = []byte("SYGT")
+	headerLength = len(magic) + 3 + 4 + 4 + 1 + saltLength
+)
+
+// KDFParams are argon2id parameters used to derive the encryption key from the passphrase
+type KDFParams struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+var DefaultKDFParams = KDFParams{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+}
+
+// Encryption encrypts topology with AEAD using a key derived from the passphrase.
+// Ciphertext starts with a header that identifies format version, algorithm and key derivation:
+// magic | version | algorithm | kdf | time | memory | threads | salt | nonce | ciphertext
+//
+// Ciphertext without the header is decrypted with the legacy AES-CTR encryption
+// that uses the passphrase directly as the AES key.
+type Encryption struct {
+	passphrase []byte
+	algorithm  Algorithm
+	kdfParams  KDFParams
+}
+
+func NewEncryption(passphrase []byte, algorithm Algorithm, kdfParams KDFParams) (*Encryption, error) {
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("empty passphrase")
+	}
+	if algorithm != AESGCM && algorithm != XChaCha20Poly1305 {
+		return nil, fmt.Errorf("unsupported algorithm %d", algorithm)
+	}
+
+	return &Encryption{
+		passphrase: passphrase,
+		algorithm:  algorithm,
+		kdfParams:  kdfParams,
+	}, nil
+}
+
+// Encrypt encrypts data and returns header, nonce and sealed data
+func (e *Encryption) Encrypt(data []byte) ([]byte, error) {
+	salt := make([]byte, saltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &bytes.Buffer{}
+	header.Write(magic)
+	header.Write([]byte{formatVersion, byte(e.algorithm), argon2idKDF})
+	_ = binary.Write(header, binary.BigEndian, e.kdfParams.Time)
+	_ = binary.Write(header, binary.BigEndian, e.kdfParams.Memory)
+	header.WriteByte(e.kdfParams.Threads)
+	header.Write(salt)
+
+	aead, err := newAEAD(e.algorithm, deriveKey(e.passphrase, salt, e.kdfParams))
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	ct := bytes.NewBuffer(append([]byte{}, header.Bytes()...))
+	ct.Write(nonce)
+	ct.Write(aead.Seal(nil, nonce, data, header.Bytes()))
+	return ct.Bytes(), nil
+}
+
+// Decrypt decrypts data encrypted with the versioned format and falls back to
+// the legacy AES-CTR format for ciphertext without the header
+func (e *Encryption) Decrypt(ct []byte) ([]byte, error) {
+	if !bytes.HasPrefix(ct, magic) {
+		legacy, err := NewAESEncryption(e.passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("legacy topology encryption: %w", err)
+		}
+		return legacy.Decrypt(ct)
+	}
+
+	if len(ct) < headerLength {
+		return nil, fmt.Errorf("ciphertext shorter than header")
+	}
+	header := ct[:headerLength]
+	version, algorithm, kdf := header[4], Algorithm(header[5]), header[6]
+	if version != formatVersion {
+		return nil, fmt.Errorf("unsupported format version %d", version)
+	}
+	if kdf != argon2idKDF {
+		return nil, fmt.Errorf("unsupported key derivation %d", kdf)
+	}
+	params := KDFParams{
+		Time:    binary.BigEndian.Uint32(header[7:11]),
+		Memory:  binary.BigEndian.Uint32(header[11:15]),
+		Threads: header[15],
+	}
+	if params.Time == 0 || params.Time > maxTime || params.Threads == 0 || params.Memory > maxMemory {
+		return nil, fmt.Errorf("invalid key derivation parameters %+v", params)
+	}
+
+	aead, err := newAEAD(algorithm, deriveKey(e.passphrase, header[16:], params))
+	if err != nil {
+		return nil, err
+	}
+	body := ct[headerLength:]
+	if len(body) < aead.NonceSize()+aead.Overhead() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce := body[:aead.NonceSize()]
+	pt, err := aead.Open(nil, nonce, body[aead.NonceSize():], header)
+	if err != nil {
+		return nil, fmt.Errorf("failed decrypting topology: %w", err)
+	}
+	return pt, nil
+}
+
+func deriveKey(passphrase []byte, salt []byte, params KDFParams) []byte {
+	return argon2.IDKey(passphrase, salt, params.Time, params.Memory, params.Threads, keyLength)
+}
+
+func newAEAD(algorithm Algorithm, key []byte) (cipher.AEAD, error) {
+	switch algorithm {
+	case AESGCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case XChaCha20Poly1305:
+		return chacha20poly1305.NewX(key)
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %d", algorithm)
+	}
+}
+
+// AESEncryption is the legacy unauthenticated topology encryption
+// that uses AES in CTR mode
 type AESEncryption struct {
 	block cipher.Block
 }
@@ -25,12 +193,16 @@ func NewAESEncryption(key []byte) (*AESEncryption, error) {
 	}, nil
 }
 
-func (ae *AESEncryption) Decrypt(ct []byte) []byte {
+func (ae *AESEncryption) Decrypt(ct []byte) ([]byte, error) {
+	if len(ct) < aes.BlockSize {
+		return nil, fmt.Errorf("ciphertext shorter than IV")
+	}
+
 	iv := ct[:aes.BlockSize]
 	stream := cipher.NewCTR(ae.block, iv)
 	dst := make([]byte, len(ct[aes.BlockSize:]))
 	stream.XORKeyStream(dst, ct[aes.BlockSize:])
-	return dst
+	return dst, nil
 }
 
 // Encrypt is a function that encrypts provided bytes with AES in CTR mode
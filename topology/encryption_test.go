@@ -4,6 +4,7 @@
 package topology_test
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"testing"
 
@@ -42,7 +43,8 @@ func (s *AESEncryptionTestSuite) Test_EncrDecr() {
 	ct, err := s.aesEncryption.Encrypt(pt)
 	s.Nil(err)
 
-	resultingPt := s.aesEncryption.Decrypt(ct)
+	resultingPt, err := s.aesEncryption.Decrypt(ct)
+	s.Nil(err)
 
 	decryptedTopology := topology.RawTopology{}
 
@@ -51,3 +53,108 @@ func (s *AESEncryptionTestSuite) Test_EncrDecr() {
 
 	s.Equal(expectedTopology, decryptedTopology)
 }
+
+func (s *AESEncryptionTestSuite) Test_DecryptShortCiphertext() {
+	_, err := s.aesEncryption.Decrypt([]byte{1, 2, 3})
+
+	s.NotNil(err)
+}
+
+var testKDFParams = topology.KDFParams{
+	Time:    1,
+	Memory:  1024,
+	Threads: 1,
+}
+
+type EncryptionTestSuite struct {
+	suite.Suite
+}
+
+func TestRunEncryptionTestSuite(t *testing.T) {
+	suite.Run(t, new(EncryptionTestSuite))
+}
+
+func (s *EncryptionTestSuite) Test_EncrDecr() {
+	for _, algorithm := range []topology.Algorithm{topology.AESGCM, topology.XChaCha20Poly1305} {
+		encryption, err := topology.NewEncryption([]byte("passphrase"), algorithm, testKDFParams)
+		s.Nil(err)
+
+		ct, err := encryption.Encrypt([]byte("topology"))
+		s.Nil(err)
+		s.Equal(ct[:4], []byte("SYGT"))
+		s.Equal(ct[5], byte(algorithm))
+
+		pt, err := encryption.Decrypt(ct)
+		s.Nil(err)
+		s.Equal(pt, []byte("topology"))
+	}
+}
+
+func (s *EncryptionTestSuite) Test_DecryptWithDifferentAlgorithm() {
+	chacha, _ := topology.NewEncryption([]byte("passphrase"), topology.XChaCha20Poly1305, testKDFParams)
+	gcm, _ := topology.NewEncryption([]byte("passphrase"), topology.AESGCM, testKDFParams)
+	ct, err := chacha.Encrypt([]byte("topology"))
+	s.Nil(err)
+
+	pt, err := gcm.Decrypt(ct)
+
+	s.Nil(err)
+	s.Equal(pt, []byte("topology"))
+}
+
+func (s *EncryptionTestSuite) Test_WrongPassphrase() {
+	encryption, _ := topology.NewEncryption([]byte("passphrase"), topology.AESGCM, testKDFParams)
+	ct, err := encryption.Encrypt([]byte("topology"))
+	s.Nil(err)
+	wrongEncryption, _ := topology.NewEncryption([]byte("wrong"), topology.AESGCM, testKDFParams)
+
+	_, err = wrongEncryption.Decrypt(ct)
+
+	s.NotNil(err)
+}
+
+func (s *EncryptionTestSuite) Test_TamperedCiphertext() {
+	encryption, _ := topology.NewEncryption([]byte("passphrase"), topology.AESGCM, testKDFParams)
+	ct, err := encryption.Encrypt([]byte("topology"))
+	s.Nil(err)
+
+	ct[len(ct)-1] ^= 1
+	_, err = encryption.Decrypt(ct)
+	s.NotNil(err)
+
+	ct[len(ct)-1] ^= 1
+	ct[20] ^= 1
+	_, err = encryption.Decrypt(ct)
+	s.NotNil(err)
+}
+
+func (s *EncryptionTestSuite) Test_ExcessiveKDFParams() {
+	encryption, _ := topology.NewEncryption([]byte("passphrase"), topology.AESGCM, testKDFParams)
+	ct, err := encryption.Encrypt([]byte("topology"))
+	s.Nil(err)
+
+	ct[7] ^= 1
+	_, err = encryption.Decrypt(ct)
+
+	s.NotNil(err)
+}
+
+func (s *EncryptionTestSuite) Test_TruncatedCiphertext() {
+	encryption, _ := topology.NewEncryption([]byte("passphrase"), topology.AESGCM, testKDFParams)
+
+	_, err := encryption.Decrypt([]byte("SYGT"))
+	s.NotNil(err)
+
+	_, err = encryption.Decrypt([]byte{})
+	s.NotNil(err)
+}
+
+func (s *EncryptionTestSuite) Test_LegacyCiphertext() {
+	encryption, _ := topology.NewEncryption([]byte("qwertyuiopasdfgh"), topology.AESGCM, testKDFParams)
+	ct, _ := hex.DecodeString("f533758136cd1f62c3c7fd96b41d439ce3c899b0e705ecebd567275e4447683f")
+
+	pt, err := encryption.Decrypt(ct)
+
+	s.Nil(err)
+	s.Contains(string(pt), `"peers"`)
+}
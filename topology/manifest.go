@@ -0,0 +1,140 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package topology
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TopologyManifest is a versioned topology that is valid only inside
+// its validity window
+type TopologyManifest struct {
+	Version   uint64      `json:"version"`
+	NotBefore int64       `json:"notBefore"`
+	NotAfter  int64       `json:"notAfter"`
+	Topology  RawTopology `json:"topology"`
+}
+
+// SignedManifest is a topology manifest signed by admin keys.
+// Signatures are made over the keccak256 hash of compacted manifest JSON.
+type SignedManifest struct {
+	Manifest   json.RawMessage `json:"manifest"`
+	Signatures []string        `json:"signatures"`
+}
+
+// SignManifest signs manifest bytes with the admin key and returns hex encoded signature
+func SignManifest(manifest []byte, key *ecdsa.PrivateKey) (string, error) {
+	hash, err := manifestHash(manifest)
+	if err != nil {
+		return "", err
+	}
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		return "", err
+	}
+	return hexutil.Encode(sig), nil
+}
+
+type ManifestVerifier struct {
+	admins    map[common.Address]bool
+	threshold int
+}
+
+// NewManifestVerifier creates a verifier that requires manifests to be signed by at least threshold
+// of the provided admin addresses
+func NewManifestVerifier(admins []common.Address, threshold int) (*ManifestVerifier, error) {
+	adminSet := make(map[common.Address]bool)
+	for _, admin := range admins {
+		adminSet[admin] = true
+	}
+	if threshold < 1 || threshold > len(adminSet) {
+		return nil, fmt.Errorf("admin threshold %d has to be between 1 and %d", threshold, len(adminSet))
+	}
+
+	return &ManifestVerifier{
+		admins:    adminSet,
+		threshold: threshold,
+	}, nil
+}
+
+// Verify checks that the manifest is signed by enough distinct admins and that
+// the current time is inside the manifest validity window
+func (v *ManifestVerifier) Verify(signedManifest *SignedManifest, now time.Time) (*TopologyManifest, error) {
+	hash, err := manifestHash(signedManifest.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	signers := make(map[common.Address]bool)
+	for _, signature := range signedManifest.Signatures {
+		sig, err := hexutil.Decode(signature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature %s: %w", signature, err)
+		}
+		pubKey, err := crypto.SigToPub(hash, sig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature %s: %w", signature, err)
+		}
+
+		signer := crypto.PubkeyToAddress(*pubKey)
+		if v.admins[signer] {
+			signers[signer] = true
+		}
+	}
+	if len(signers) < v.threshold {
+		return nil, fmt.Errorf("manifest signed by %d admins, expected at least %d", len(signers), v.threshold)
+	}
+
+	manifest := &TopologyManifest{}
+	err = json.Unmarshal(signedManifest.Manifest, manifest)
+	if err != nil {
+		return nil, err
+	}
+	if now.Before(time.Unix(manifest.NotBefore, 0)) || !now.Before(time.Unix(manifest.NotAfter, 0)) {
+		return nil, fmt.Errorf(
+			"manifest version %d valid from %s until %s",
+			manifest.Version, time.Unix(manifest.NotBefore, 0), time.Unix(manifest.NotAfter, 0),
+		)
+	}
+	return manifest, nil
+}
+
+// manifestHash hashes compacted manifest so that signatures survive
+// re-encoding of the signed manifest
+func manifestHash(manifest []byte) ([]byte, error) {
+	compacted := &bytes.Buffer{}
+	err := json.Compact(compacted, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	return crypto.Keccak256(compacted.Bytes()), nil
+}
+
+// ParseAdminKeys parses comma separated admin addresses and admin threshold
+// from the topology configuration
+func ParseAdminKeys(adminKeys string, adminThreshold string) ([]common.Address, int, error) {
+	var admins []common.Address
+	for _, key := range strings.Split(adminKeys, ",") {
+		key = strings.TrimSpace(key)
+		if !common.IsHexAddress(key) {
+			return nil, 0, fmt.Errorf("invalid admin address %s", key)
+		}
+		admins = append(admins, common.HexToAddress(key))
+	}
+
+	threshold, err := strconv.Atoi(adminThreshold)
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to parse admin threshold: %w", err)
+	}
+	return admins, threshold, nil
+}
@@ -0,0 +1,229 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package topology_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/topology"
+	mock_topology "github.com/ChainSafe/sygma-relayer/topology/mock"
+)
+
+var rawTopology = topology.RawTopology{
+	Peers: []topology.RawPeer{
+		{PeerAddress: "/dns4/relayer1/tcp/9000/p2p/QmcvEg7jGvuxdsUFRUiE4VdrL2P1Yeju5L83BsJvvXz7zX"},
+		{PeerAddress: "/dns4/relayer2/tcp/9001/p2p/QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT"},
+	},
+	Threshold: "1",
+}
+
+type ManifestVerifierTestSuite struct {
+	suite.Suite
+
+	keys     []*ecdsa.PrivateKey
+	verifier *topology.ManifestVerifier
+	manifest []byte
+}
+
+func TestRunManifestVerifierTestSuite(t *testing.T) {
+	suite.Run(t, new(ManifestVerifierTestSuite))
+}
+
+func (s *ManifestVerifierTestSuite) SetupTest() {
+	s.keys = make([]*ecdsa.PrivateKey, 3)
+	admins := make([]common.Address, 3)
+	for i := range s.keys {
+		s.keys[i], _ = crypto.GenerateKey()
+		admins[i] = crypto.PubkeyToAddress(s.keys[i].PublicKey)
+	}
+	s.verifier, _ = topology.NewManifestVerifier(admins, 2)
+	s.manifest, _ = json.Marshal(topology.TopologyManifest{
+		Version:   5,
+		NotBefore: 1000,
+		NotAfter:  2000,
+		Topology:  rawTopology,
+	})
+}
+
+func (s *ManifestVerifierTestSuite) sign(keys ...*ecdsa.PrivateKey) *topology.SignedManifest {
+	signedManifest := &topology.SignedManifest{Manifest: s.manifest}
+	for _, key := range keys {
+		sig, err := topology.SignManifest(s.manifest, key)
+		s.Nil(err)
+		signedManifest.Signatures = append(signedManifest.Signatures, sig)
+	}
+	return signedManifest
+}
+
+func (s *ManifestVerifierTestSuite) Test_NewManifestVerifier_InvalidThreshold() {
+	_, err := topology.NewManifestVerifier([]common.Address{{1}, {1}}, 2)
+
+	s.NotNil(err)
+}
+
+func (s *ManifestVerifierTestSuite) Test_Verify_ValidManifest() {
+	manifest, err := s.verifier.Verify(s.sign(s.keys[0], s.keys[2]), time.Unix(1500, 0))
+
+	s.Nil(err)
+	s.Equal(manifest.Version, uint64(5))
+	s.Equal(manifest.Topology, rawTopology)
+}
+
+func (s *ManifestVerifierTestSuite) Test_Verify_DuplicateSignatures() {
+	_, err := s.verifier.Verify(s.sign(s.keys[0], s.keys[0]), time.Unix(1500, 0))
+
+	s.NotNil(err)
+}
+
+func (s *ManifestVerifierTestSuite) Test_Verify_UnknownSigner() {
+	key, _ := crypto.GenerateKey()
+
+	_, err := s.verifier.Verify(s.sign(s.keys[0], key), time.Unix(1500, 0))
+
+	s.NotNil(err)
+}
+
+func (s *ManifestVerifierTestSuite) Test_Verify_TamperedManifest() {
+	signedManifest := s.sign(s.keys[0], s.keys[1])
+	signedManifest.Manifest = []byte(strings.Replace(string(s.manifest), `"version":5`, `"version":6`, 1))
+
+	_, err := s.verifier.Verify(signedManifest, time.Unix(1500, 0))
+
+	s.NotNil(err)
+}
+
+func (s *ManifestVerifierTestSuite) Test_Verify_OutsideValidityWindow() {
+	_, err := s.verifier.Verify(s.sign(s.keys[0], s.keys[1]), time.Unix(999, 0))
+	s.NotNil(err)
+
+	_, err = s.verifier.Verify(s.sign(s.keys[0], s.keys[1]), time.Unix(2000, 0))
+	s.NotNil(err)
+}
+
+func (s *ManifestVerifierTestSuite) Test_Verify_ReencodedManifest() {
+	signedManifest := s.sign(s.keys[0], s.keys[1])
+	indented := &bytes.Buffer{}
+	err := json.Indent(indented, signedManifest.Manifest, "", "  ")
+	s.Nil(err)
+	signedManifest.Manifest = indented.Bytes()
+
+	_, err = s.verifier.Verify(signedManifest, time.Unix(1500, 0))
+
+	s.Nil(err)
+}
+
+func (s *ManifestVerifierTestSuite) Test_ParseAdminKeys() {
+	admins, threshold, err := topology.ParseAdminKeys("0x0000000000000000000000000000000000000001, 0x0000000000000000000000000000000000000002", "2")
+
+	s.Nil(err)
+	s.Equal(admins, []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")})
+	s.Equal(threshold, 2)
+
+	_, _, err = topology.ParseAdminKeys("invalid", "2")
+	s.NotNil(err)
+}
+
+type SignedTopologyProviderTestSuite struct {
+	suite.Suite
+
+	fetcher *mock_topology.MockFetcher
+	key     *ecdsa.PrivateKey
+	config  relayer.TopologyConfiguration
+}
+
+func TestRunSignedTopologyProviderTestSuite(t *testing.T) {
+	suite.Run(t, new(SignedTopologyProviderTestSuite))
+}
+
+func (s *SignedTopologyProviderTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.fetcher = mock_topology.NewMockFetcher(ctrl)
+	s.key, _ = crypto.GenerateKey()
+	s.config = relayer.TopologyConfiguration{
+		Url:            "test.url",
+		EncryptionKey:  "qwertyuiopasdfgh",
+		AdminKeys:      crypto.PubkeyToAddress(s.key.PublicKey).Hex(),
+		AdminThreshold: "1",
+	}
+}
+
+func (s *SignedTopologyProviderTestSuite) serve(data interface{}) {
+	plaintext, err := json.Marshal(data)
+	s.Nil(err)
+	encryption, _ := topology.NewEncryption([]byte(s.config.EncryptionKey), topology.XChaCha20Poly1305, testKDFParams)
+	ct, err := encryption.Encrypt(plaintext)
+	s.Nil(err)
+
+	resp := &http.Response{}
+	resp.Body = io.NopCloser(strings.NewReader(hex.EncodeToString(ct)))
+	s.fetcher.EXPECT().Get("test.url").Return(resp, nil)
+}
+
+func (s *SignedTopologyProviderTestSuite) Test_InvalidAdminThreshold() {
+	s.config.AdminThreshold = "2"
+
+	_, err := topology.NewNetworkTopologyProvider(s.config, s.fetcher)
+
+	s.NotNil(err)
+}
+
+func (s *SignedTopologyProviderTestSuite) Test_SignedManifest() {
+	manifest, _ := json.Marshal(topology.TopologyManifest{
+		Version:   3,
+		NotBefore: time.Now().Add(-time.Hour).Unix(),
+		NotAfter:  time.Now().Add(time.Hour).Unix(),
+		Topology:  rawTopology,
+	})
+	sig, _ := topology.SignManifest(manifest, s.key)
+	s.serve(topology.SignedManifest{Manifest: manifest, Signatures: []string{sig}})
+	topologyProvider, _ := topology.NewNetworkTopologyProvider(s.config, s.fetcher)
+
+	tp, err := topologyProvider.NetworkTopology("")
+
+	expectedTopology, _ := topology.ProcessRawTopology(&rawTopology)
+	expectedTopology.Version = 3
+	s.Nil(err)
+	s.Equal(tp, expectedTopology)
+}
+
+func (s *SignedTopologyProviderTestSuite) Test_UnsignedTopology() {
+	s.serve(rawTopology)
+	topologyProvider, _ := topology.NewNetworkTopologyProvider(s.config, s.fetcher)
+
+	_, err := topologyProvider.NetworkTopology("")
+
+	s.NotNil(err)
+}
+
+func (s *SignedTopologyProviderTestSuite) Test_ExpiredManifest() {
+	manifest, _ := json.Marshal(topology.TopologyManifest{
+		Version:   3,
+		NotBefore: time.Now().Add(-2 * time.Hour).Unix(),
+		NotAfter:  time.Now().Add(-time.Hour).Unix(),
+		Topology:  rawTopology,
+	})
+	sig, _ := topology.SignManifest(manifest, s.key)
+	s.serve(topology.SignedManifest{Manifest: manifest, Signatures: []string{sig}})
+	topologyProvider, _ := topology.NewNetworkTopologyProvider(s.config, s.fetcher)
+
+	_, err := topologyProvider.NetworkTopology("")
+
+	s.NotNil(err)
+	s.Contains(err.Error(), fmt.Sprintf("version %d", 3))
+}
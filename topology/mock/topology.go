@@ -74,11 +74,12 @@ func (m *MockDecrypter) EXPECT() *MockDecrypterMockRecorder {
 }
 
 // Decrypt mocks base method.
-func (m *MockDecrypter) Decrypt(data []byte) []byte {
+func (m *MockDecrypter) Decrypt(data []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Decrypt", data)
 	ret0, _ := ret[0].([]byte)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Decrypt indicates an expected call of Decrypt.
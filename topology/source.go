@@ -0,0 +1,199 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package topology
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+)
+
+// FetchTimeout is the maximum time a single topology source is allowed to take
+const FetchTimeout = 30 * time.Second
+
+const registryABI = `[{"inputs":[],"name":"topology","outputs":[{"internalType":"bytes","name":"","type":"bytes"}],"stateMutability":"view","type":"function"}]`
+
+// Source returns encrypted topology from a single location
+type Source interface {
+	Fetch() ([]byte, error)
+	String() string
+}
+
+// NewSources creates topology sources from the configuration in the order they should be tried:
+// local file, HTTP url and mirrors, IPFS gateways and on-chain registry
+func NewSources(config relayer.TopologyConfiguration, fetcher Fetcher) ([]Source, error) {
+	var sources []Source
+	if config.File != "" {
+		sources = append(sources, NewFileSource(config.File))
+	}
+	for _, url := range append([]string{config.Url}, splitList(config.Mirrors)...) {
+		if url != "" {
+			sources = append(sources, NewHTTPSource(url, fetcher))
+		}
+	}
+	if config.IpfsCid != "" {
+		gateways := splitList(config.IpfsGateways)
+		if len(gateways) == 0 {
+			return nil, fmt.Errorf("ipfs gateways not provided for cid %s", config.IpfsCid)
+		}
+		for _, gateway := range gateways {
+			sources = append(sources, NewIPFSSource(gateway, config.IpfsCid, fetcher))
+		}
+	}
+	if config.RegistryAddress != "" {
+		if !common.IsHexAddress(config.RegistryAddress) {
+			return nil, fmt.Errorf("invalid registry address %s", config.RegistryAddress)
+		}
+		client, err := ethclient.Dial(config.RegistryEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, NewRegistrySource(client, common.HexToAddress(config.RegistryAddress)))
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no topology source configured")
+	}
+	return sources, nil
+}
+
+type FileSource struct {
+	path string
+}
+
+// NewFileSource creates a source that reads hex encoded encrypted topology from a local file
+func NewFileSource(path string) *FileSource {
+	return &FileSource{
+		path: path,
+	}
+}
+
+func (s *FileSource) Fetch() ([]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeHex(data)
+}
+
+func (s *FileSource) String() string {
+	return fmt.Sprintf("file %s", s.path)
+}
+
+type HTTPSource struct {
+	url     string
+	fetcher Fetcher
+}
+
+// NewHTTPSource creates a source that downloads hex encoded encrypted topology from the url
+func NewHTTPSource(url string, fetcher Fetcher) *HTTPSource {
+	return &HTTPSource{
+		url:     url,
+		fetcher: fetcher,
+	}
+}
+
+// NewIPFSSource creates a source that downloads hex encoded encrypted topology
+// with the CID through the IPFS gateway
+func NewIPFSSource(gateway string, cid string, fetcher Fetcher) *HTTPSource {
+	return NewHTTPSource(fmt.Sprintf("%s/ipfs/%s", strings.TrimSuffix(gateway, "/"), cid), fetcher)
+}
+
+func (s *HTTPSource) Fetch() ([]byte, error) {
+	resp, err := s.fetcher.Get(s.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("received status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeHex(body)
+}
+
+func (s *HTTPSource) String() string {
+	return fmt.Sprintf("URL %s", s.url)
+}
+
+type ContractCaller interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+type RegistrySource struct {
+	caller  ContractCaller
+	address common.Address
+	abi     abi.ABI
+}
+
+// NewRegistrySource creates a source that reads encrypted topology bytes
+// from the topology() function of the registry contract
+func NewRegistrySource(caller ContractCaller, address common.Address) *RegistrySource {
+	a, _ := abi.JSON(strings.NewReader(registryABI))
+	return &RegistrySource{
+		caller:  caller,
+		address: address,
+		abi:     a,
+	}
+}
+
+func (s *RegistrySource) Fetch() ([]byte, error) {
+	input, err := s.abi.Pack("topology")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), FetchTimeout)
+	defer cancel()
+	output, err := s.caller.CallContract(ctx, ethereum.CallMsg{To: &s.address, Data: input}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.abi.Unpack("topology", output)
+	if err != nil {
+		return nil, err
+	}
+	ct, ok := res[0].([]byte)
+	if !ok || len(ct) == 0 {
+		return nil, fmt.Errorf("registry returned no topology")
+	}
+	return ct, nil
+}
+
+func (s *RegistrySource) String() string {
+	return fmt.Sprintf("registry %s", s.address)
+}
+
+func decodeHex(data []byte) ([]byte, error) {
+	return hex.DecodeString(strings.TrimSpace(string(data)))
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
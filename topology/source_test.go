@@ -0,0 +1,207 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package topology_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/topology"
+	mock_topology "github.com/ChainSafe/sygma-relayer/topology/mock"
+)
+
+func response(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+type SourceTestSuite struct {
+	suite.Suite
+
+	fetcher *mock_topology.MockFetcher
+	caller  *mock_topology.MockContractCaller
+}
+
+func TestRunSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(SourceTestSuite))
+}
+
+func (s *SourceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.fetcher = mock_topology.NewMockFetcher(ctrl)
+	s.caller = mock_topology.NewMockContractCaller(ctrl)
+}
+
+func (s *SourceTestSuite) Test_FileSource() {
+	path := fmt.Sprintf("%s/topology", s.T().TempDir())
+	err := os.WriteFile(path, []byte("0102ff\n"), 0600)
+	s.Nil(err)
+
+	ct, err := topology.NewFileSource(path).Fetch()
+
+	s.Nil(err)
+	s.Equal(ct, []byte{1, 2, 255})
+}
+
+func (s *SourceTestSuite) Test_FileSource_MissingFile() {
+	_, err := topology.NewFileSource("missing").Fetch()
+
+	s.NotNil(err)
+}
+
+func (s *SourceTestSuite) Test_HTTPSource_ErrorStatusCode() {
+	s.fetcher.EXPECT().Get("test.url").Return(response(http.StatusNotFound, "not found"), nil)
+
+	_, err := topology.NewHTTPSource("test.url", s.fetcher).Fetch()
+
+	s.NotNil(err)
+}
+
+func (s *SourceTestSuite) Test_IPFSSource() {
+	s.fetcher.EXPECT().Get("https://gateway.com/ipfs/cid").Return(response(http.StatusOK, "0102"), nil)
+
+	ct, err := topology.NewIPFSSource("https://gateway.com/", "cid", s.fetcher).Fetch()
+
+	s.Nil(err)
+	s.Equal(ct, []byte{1, 2})
+}
+
+func (s *SourceTestSuite) Test_RegistrySource() {
+	a, _ := abi.JSON(strings.NewReader(`[{"inputs":[],"name":"topology","outputs":[{"internalType":"bytes","name":"","type":"bytes"}],"stateMutability":"view","type":"function"}]`))
+	output, _ := a.Methods["topology"].Outputs.Pack([]byte{1, 2})
+	address := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	s.caller.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Nil()).DoAndReturn(
+		func(_ interface{}, call ethereum.CallMsg, _ interface{}) ([]byte, error) {
+			s.Equal(*call.To, address)
+			s.Equal(call.Data, a.Methods["topology"].ID)
+			return output, nil
+		})
+
+	ct, err := topology.NewRegistrySource(s.caller, address).Fetch()
+
+	s.Nil(err)
+	s.Equal(ct, []byte{1, 2})
+}
+
+func (s *SourceTestSuite) Test_RegistrySource_EmptyTopology() {
+	a, _ := abi.JSON(strings.NewReader(`[{"inputs":[],"name":"topology","outputs":[{"internalType":"bytes","name":"","type":"bytes"}],"stateMutability":"view","type":"function"}]`))
+	output, _ := a.Methods["topology"].Outputs.Pack([]byte{})
+	s.caller.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Nil()).Return(output, nil)
+
+	_, err := topology.NewRegistrySource(s.caller, common.Address{}).Fetch()
+
+	s.NotNil(err)
+}
+
+func (s *SourceTestSuite) Test_NewSources_Order() {
+	sources, err := topology.NewSources(relayer.TopologyConfiguration{
+		File:         "topology",
+		Url:          "https://url.com",
+		Mirrors:      "https://mirror1.com, https://mirror2.com",
+		IpfsCid:      "cid",
+		IpfsGateways: "https://gateway.com",
+	}, s.fetcher)
+
+	s.Nil(err)
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.String()
+	}
+	s.Equal(names, []string{
+		"file topology",
+		"URL https://url.com",
+		"URL https://mirror1.com",
+		"URL https://mirror2.com",
+		"URL https://gateway.com/ipfs/cid",
+	})
+}
+
+func (s *SourceTestSuite) Test_NewSources_NoSources() {
+	_, err := topology.NewSources(relayer.TopologyConfiguration{}, s.fetcher)
+
+	s.NotNil(err)
+}
+
+func (s *SourceTestSuite) Test_NewSources_MissingGateways() {
+	_, err := topology.NewSources(relayer.TopologyConfiguration{IpfsCid: "cid"}, s.fetcher)
+
+	s.NotNil(err)
+}
+
+type TopologyFallbackTestSuite struct {
+	suite.Suite
+
+	fetcher  *mock_topology.MockFetcher
+	config   relayer.TopologyConfiguration
+	topology string
+	hash     string
+}
+
+func TestRunTopologyFallbackTestSuite(t *testing.T) {
+	suite.Run(t, new(TopologyFallbackTestSuite))
+}
+
+func (s *TopologyFallbackTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.fetcher = mock_topology.NewMockFetcher(ctrl)
+	s.config = relayer.TopologyConfiguration{
+		EncryptionKey: "qwertyuiopasdfgh",
+		Url:           "https://url.com",
+		Mirrors:       "https://mirror1.com,https://mirror2.com",
+	}
+
+	encryption, _ := topology.NewEncryption([]byte(s.config.EncryptionKey), topology.AESGCM, testKDFParams)
+	ct, _ := encryption.Encrypt([]byte(`{"peers":[{"peerAddress":"/dns4/relayer1/tcp/9000/p2p/QmcvEg7jGvuxdsUFRUiE4VdrL2P1Yeju5L83BsJvvXz7zX"}],"threshold":"1"}`))
+	s.topology = hex.EncodeToString(ct)
+	h := sha256.Sum256(ct)
+	s.hash = hex.EncodeToString(h[:])
+}
+
+func (s *TopologyFallbackTestSuite) Test_FallbackToMirror() {
+	s.fetcher.EXPECT().Get("https://url.com").Return(nil, fmt.Errorf("error"))
+	s.fetcher.EXPECT().Get("https://mirror1.com").Return(response(http.StatusOK, "00"+s.topology), nil)
+	s.fetcher.EXPECT().Get("https://mirror2.com").Return(response(http.StatusOK, s.topology), nil)
+	topologyProvider, _ := topology.NewNetworkTopologyProvider(s.config, s.fetcher)
+
+	tp, err := topologyProvider.NetworkTopology(s.hash)
+
+	s.Nil(err)
+	s.Equal(tp.Threshold, 1)
+}
+
+func (s *TopologyFallbackTestSuite) Test_FirstMatchingSource() {
+	s.fetcher.EXPECT().Get("https://url.com").Return(response(http.StatusOK, s.topology), nil)
+	topologyProvider, _ := topology.NewNetworkTopologyProvider(s.config, s.fetcher)
+
+	tp, err := topologyProvider.NetworkTopology(s.hash)
+
+	s.Nil(err)
+	s.Equal(tp.Threshold, 1)
+}
+
+func (s *TopologyFallbackTestSuite) Test_AllSourcesFail() {
+	s.fetcher.EXPECT().Get("https://url.com").Return(nil, fmt.Errorf("error"))
+	s.fetcher.EXPECT().Get("https://mirror1.com").Return(response(http.StatusBadGateway, ""), nil)
+	s.fetcher.EXPECT().Get("https://mirror2.com").Return(response(http.StatusOK, s.topology), nil)
+	topologyProvider, _ := topology.NewNetworkTopologyProvider(s.config, s.fetcher)
+
+	_, err := topologyProvider.NetworkTopology("invalid")
+
+	s.NotNil(err)
+}
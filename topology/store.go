@@ -5,6 +5,7 @@ package topology
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"sync"
 )
@@ -20,11 +21,17 @@ func NewTopologyStore(filePath string) *TopologyStore {
 	}
 }
 
-// StoreTopology stores topology into a file
+// StoreTopology stores topology into a file and rejects topologies
+// older than the currently stored one
 func (ts *TopologyStore) StoreTopology(topology *NetworkTopology) error {
 	ts.mu.Lock()
 	defer ts.mu.Unlock()
 
+	current, err := ts.topology()
+	if err == nil && topology.Version < current.Version {
+		return fmt.Errorf("topology version %d older than current version %d", topology.Version, current.Version)
+	}
+
 	f, err := os.OpenFile(ts.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
 	if err != nil {
 
@@ -45,6 +52,10 @@ func (ts *TopologyStore) StoreTopology(topology *NetworkTopology) error {
 func (ts *TopologyStore) Topology() (*NetworkTopology, error) {
 	ts.mu.Lock()
 	defer ts.mu.Unlock()
+	return ts.topology()
+}
+
+func (ts *TopologyStore) topology() (*NetworkTopology, error) {
 	t := &NetworkTopology{}
 	tb, err := os.ReadFile(ts.path)
 	if err != nil {
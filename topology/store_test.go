@@ -58,3 +58,27 @@ func (s *TopologyStoreTestSuite) Test_StoreAndRetrieveTopology() {
 
 	s.True(reflect.DeepEqual(networkTopology, storedTopology))
 }
+
+func (s *TopologyStoreTestSuite) Test_StoreOlderTopology_Rejected() {
+	networkTopology, err := topology.ProcessRawTopology(&topology.RawTopology{
+		Peers: []topology.RawPeer{
+			{PeerAddress: "/dns4/relayer1/tcp/9000/p2p/QmcvEg7jGvuxdsUFRUiE4VdrL2P1Yeju5L83BsJvvXz7zX"},
+		},
+		Threshold: "1",
+	})
+	s.Nil(err)
+	networkTopology.Version = 2
+	err = s.topologyStore.StoreTopology(networkTopology)
+	s.Nil(err)
+
+	networkTopology.Version = 1
+	err = s.topologyStore.StoreTopology(networkTopology)
+	s.NotNil(err)
+
+	networkTopology.Version = 3
+	err = s.topologyStore.StoreTopology(networkTopology)
+	s.Nil(err)
+	storedTopology, err := s.topologyStore.Topology()
+	s.Nil(err)
+	s.Equal(storedTopology.Version, uint64(3))
+}
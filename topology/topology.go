@@ -8,19 +8,23 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ChainSafe/sygma-relayer/config/relayer"
 	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/rs/zerolog/log"
 )
 
 type NetworkTopology struct {
 	Peers     []*peer.AddrInfo
 	Threshold int
+	// Version is the version of the signed manifest the topology was read from
+	Version uint64
 }
 
 func (nt NetworkTopology) IsAllowedPeer(peer peer.ID) bool {
@@ -38,15 +42,70 @@ type RawTopology struct {
 	Threshold string    `mapstructure:"Threshold" json:"threshold"`
 }
 
+// RawPeer is a topology entry of a single relayer. PeerAddress and PeerAddresses are
+// multiaddrs that include the peer ID, while DNSNames are "host:port" pairs resolved
+// by libp2p when dialing.
 type RawPeer struct {
-	PeerAddress string `mapstructure:"PeerAddress" json:"peerAddress"`
+	PeerAddress   string   `mapstructure:"PeerAddress" json:"peerAddress,omitempty"`
+	PeerAddresses []string `mapstructure:"PeerAddresses" json:"peerAddresses,omitempty"`
+	DNSNames      []string `mapstructure:"DNSNames" json:"dnsNames,omitempty"`
 }
+
+// AddrInfo merges all addresses of the peer into a single peer address info
+func (p RawPeer) AddrInfo() (*peer.AddrInfo, error) {
+	addresses := p.PeerAddresses
+	if p.PeerAddress != "" {
+		addresses = append([]string{p.PeerAddress}, addresses...)
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("peer has no peer address")
+	}
+
+	var addrInfo *peer.AddrInfo
+	for _, address := range addresses {
+		info, err := peer.AddrInfoFromString(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer address %s: %w", address, err)
+		}
+		if addrInfo == nil {
+			addrInfo = info
+			continue
+		}
+		if info.ID != addrInfo.ID {
+			return nil, fmt.Errorf("peer address %s does not match peer ID %s", address, addrInfo.ID)
+		}
+		addrInfo.Addrs = appendAddrs(addrInfo.Addrs, info.Addrs...)
+	}
+
+	for _, name := range p.DNSNames {
+		host, port, err := net.SplitHostPort(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dns name %s: %w", name, err)
+		}
+		addr, err := ma.NewMultiaddr(fmt.Sprintf("/dns/%s/tcp/%s", host, port))
+		if err != nil {
+			return nil, fmt.Errorf("invalid dns name %s: %w", name, err)
+		}
+		addrInfo.Addrs = appendAddrs(addrInfo.Addrs, addr)
+	}
+	return addrInfo, nil
+}
+
+func appendAddrs(addrs []ma.Multiaddr, newAddrs ...ma.Multiaddr) []ma.Multiaddr {
+	for _, newAddr := range newAddrs {
+		if !ma.Contains(addrs, newAddr) {
+			addrs = append(addrs, newAddr)
+		}
+	}
+	return addrs
+}
+
 type Fetcher interface {
 	Get(url string) (*http.Response, error)
 }
 
 type Decrypter interface {
-	Decrypt(data []byte) []byte
+	Decrypt(data []byte) ([]byte, error)
 }
 
 type NetworkTopologyProvider interface {
@@ -56,37 +115,61 @@ type NetworkTopologyProvider interface {
 }
 
 func NewNetworkTopologyProvider(config relayer.TopologyConfiguration, fetcher Fetcher) (NetworkTopologyProvider, error) {
-	decrypter, err := NewAESEncryption([]byte(config.EncryptionKey))
+	decrypter, err := NewEncryption([]byte(config.EncryptionKey), AESGCM, DefaultKDFParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var verifier *ManifestVerifier
+	if config.AdminKeys != "" {
+		admins, threshold, err := ParseAdminKeys(config.AdminKeys, config.AdminThreshold)
+		if err != nil {
+			return nil, err
+		}
+		verifier, err = NewManifestVerifier(admins, threshold)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sources, err := NewSources(config, fetcher)
 	if err != nil {
 		return nil, err
 	}
 
 	return &TopologyProvider{
 		decrypter: decrypter,
-		url:       config.Url,
-		fetcher:   fetcher,
+		verifier:  verifier,
+		sources:   sources,
 	}, nil
 }
 
+// TopologyProvider reads topology from sources in order and returns
+// the first topology matching the expected hash
 type TopologyProvider struct {
-	url       string
+	sources   []Source
 	decrypter Decrypter
-	fetcher   Fetcher
+	verifier  *ManifestVerifier
 }
 
 func (t *TopologyProvider) NetworkTopology(hash string) (*NetworkTopology, error) {
-	log.Info().Msgf("Reading topology from URL: %s", t.url)
+	var errs []string
+	for _, source := range t.sources {
+		log.Info().Msgf("Reading topology from %s", source)
 
-	resp, err := t.fetcher.Get(t.url)
-	if err != nil {
-		return nil, err
-	}
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+		topology, err := t.readTopology(source, hash)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed reading topology from %s", source)
+			errs = append(errs, fmt.Sprintf("%s: %s", source, err))
+			continue
+		}
+		return topology, nil
 	}
-	response := strings.TrimSuffix(string(body), "\n")
-	ct, err := hex.DecodeString(response)
+	return nil, fmt.Errorf("no topology source returned valid topology: %s", strings.Join(errs, "; "))
+}
+
+func (t *TopologyProvider) readTopology(source Source, hash string) (*NetworkTopology, error) {
+	ct, err := source.Fetch()
 	if err != nil {
 		return nil, err
 	}
@@ -97,22 +180,42 @@ func (t *TopologyProvider) NetworkTopology(hash string) (*NetworkTopology, error
 		return nil, fmt.Errorf("topology hash %s not matching expected hash %s", string(eh), hash)
 	}
 
-	unecryptedBody := t.decrypter.Decrypt(ct)
-	rawTopology := &RawTopology{}
-	err = json.Unmarshal(unecryptedBody, rawTopology)
+	unecryptedBody, err := t.decrypter.Decrypt(ct)
 	if err != nil {
 		return nil, err
 	}
+	if t.verifier == nil {
+		rawTopology := &RawTopology{}
+		err = json.Unmarshal(unecryptedBody, rawTopology)
+		if err != nil {
+			return nil, err
+		}
+		return ProcessRawTopology(rawTopology)
+	}
 
-	return ProcessRawTopology(rawTopology)
+	signedManifest := &SignedManifest{}
+	err = json.Unmarshal(unecryptedBody, signedManifest)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := t.verifier.Verify(signedManifest, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	topology, err := ProcessRawTopology(&manifest.Topology)
+	if err != nil {
+		return nil, err
+	}
+	topology.Version = manifest.Version
+	return topology, nil
 }
 
 func ProcessRawTopology(rawTopology *RawTopology) (*NetworkTopology, error) {
 	var peers []*peer.AddrInfo
 	for _, p := range rawTopology.Peers {
-		addrInfo, err := peer.AddrInfoFromString(p.PeerAddress)
+		addrInfo, err := p.AddrInfo()
 		if err != nil {
-			return nil, fmt.Errorf("invalid peer address %s: %w", p.PeerAddress, err)
+			return nil, err
 		}
 		peers = append(peers, addrInfo)
 	}
@@ -4,6 +4,7 @@
 package topology_test
 
 import (
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -81,6 +82,56 @@ func (s *TopologyTestSuite) Test_ProcessRawTopology_InvalidThreshold() {
 	s.Nil(err)
 }
 
+func (s *TopologyTestSuite) Test_ProcessRawTopology_MultipleAddresses() {
+	topology, err := topology.ProcessRawTopology(&topology.RawTopology{
+		Peers: []topology.RawPeer{
+			{
+				PeerAddress: "/dns4/relayer2/tcp/9001/p2p/QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT",
+				PeerAddresses: []string{
+					"/ip4/10.0.0.2/tcp/9001/p2p/QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT",
+					"/dns4/relayer2/tcp/9001/p2p/QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT",
+				},
+				DNSNames: []string{"relayer2.sygma.io:9001"},
+			},
+			{PeerAddresses: []string{"/dns4/relayer3/tcp/9002/p2p/QmYAYuLUPNwYEBYJaKHcE7NKjUhiUV8txx2xDXHvcYa1xK"}},
+		},
+		Threshold: "1",
+	})
+
+	s.Nil(err)
+	s.Equal(len(topology.Peers), 2)
+	s.Equal(fmt.Sprint(topology.Peers[0].Addrs), "[/dns4/relayer2/tcp/9001 /ip4/10.0.0.2/tcp/9001 /dns/relayer2.sygma.io/tcp/9001]")
+	s.Equal(fmt.Sprint(topology.Peers[1].Addrs), "[/dns4/relayer3/tcp/9002]")
+}
+
+func (s *TopologyTestSuite) Test_ProcessRawTopology_MismatchedPeerID() {
+	_, err := topology.ProcessRawTopology(&topology.RawTopology{
+		Peers: []topology.RawPeer{
+			{
+				PeerAddress:   "/dns4/relayer2/tcp/9001/p2p/QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT",
+				PeerAddresses: []string{"/dns4/relayer3/tcp/9002/p2p/QmYAYuLUPNwYEBYJaKHcE7NKjUhiUV8txx2xDXHvcYa1xK"},
+			},
+		},
+		Threshold: "1",
+	})
+
+	s.NotNil(err)
+}
+
+func (s *TopologyTestSuite) Test_ProcessRawTopology_InvalidDNSName() {
+	_, err := topology.ProcessRawTopology(&topology.RawTopology{
+		Peers: []topology.RawPeer{
+			{
+				PeerAddress: "/dns4/relayer2/tcp/9001/p2p/QmeTuMtdpPB7zKDgmobEwSvxodrf5aFVSmBXX3SQJVjJaT",
+				DNSNames:    []string{"relayer2.sygma.io"},
+			},
+		},
+		Threshold: "1",
+	})
+
+	s.NotNil(err)
+}
+
 type NetworkTopologyTestSuite struct {
 	suite.Suite
 }
@@ -218,3 +269,21 @@ func (s *TopologyProviderTestSuite) Test_ValidHash() {
 	s.Nil(err)
 	s.Equal(rawTp, tp)
 }
+
+func (s *TopologyProviderTestSuite) Test_TamperedTopology() {
+	encryption, _ := topology.NewEncryption([]byte("qwertyuiopasdfgh"), topology.AESGCM, testKDFParams)
+	ct, _ := encryption.Encrypt([]byte(`{"peers":[],"threshold":"2"}`))
+	ct[len(ct)-1] ^= 1
+	resp := &http.Response{}
+	resp.Body = io.NopCloser(strings.NewReader(hex.EncodeToString(ct)))
+	s.fetcher.EXPECT().Get("test.url").Return(resp, nil)
+	topologyConfiguration := relayer.TopologyConfiguration{
+		Url:           "test.url",
+		EncryptionKey: "qwertyuiopasdfgh",
+	}
+	topologyProvider, _ := topology.NewNetworkTopologyProvider(topologyConfiguration, s.fetcher)
+
+	_, err := topologyProvider.NetworkTopology("")
+
+	s.NotNil(err)
+}
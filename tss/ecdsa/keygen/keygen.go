@@ -141,6 +141,7 @@ func (k *Keygen) processEndMessage(ctx context.Context, endChn chan keygen.Local
 				k.Log.Info().Msgf("Generated key share for address: %s", crypto.PubkeyToAddress(*key.ECDSAPub.ToBtcecPubKey().ToECDSA()))
 
 				keyshare := keyshare.NewECDSAKeyshare(key, k.threshold, k.Peers)
+				keyshare.SessionID = k.SessionID()
 				err := k.storer.StoreKeyshare(keyshare)
 				if err != nil {
 					return err
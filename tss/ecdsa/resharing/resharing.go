@@ -218,6 +218,7 @@ func (r *Resharing) processEndMessage(ctx context.Context, endChn chan keygen.Lo
 				r.Log.Info().Msg("Successfully reshared key")
 
 				keyshare := keyshare.NewECDSAKeyshare(key, r.newThreshold, r.Peers)
+				keyshare.SessionID = r.SessionID()
 				err := r.storer.StoreKeyshare(keyshare)
 				return err
 			}
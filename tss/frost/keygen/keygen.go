@@ -135,7 +135,9 @@ func (k *Keygen) processEndMessage(ctx context.Context) error {
 				}
 				taprootConfig := result.(*frost.TaprootConfig)
 
-				err = k.storer.StoreKeyshare(keyshare.NewFrostKeyshare(taprootConfig, k.threshold, k.Peers))
+				frostKeyshare := keyshare.NewFrostKeyshare(taprootConfig, k.threshold, k.Peers)
+				frostKeyshare.SessionID = k.SessionID()
+				err = k.storer.StoreKeyshare(frostKeyshare)
 				if err != nil {
 					return err
 				}
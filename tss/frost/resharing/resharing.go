@@ -4,28 +4,44 @@
 package resharing
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
 
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/keyshare"
 	"github.com/ChainSafe/sygma-relayer/tss/frost/common"
-	"github.com/binance-chain/tss-lib/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/util"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
 	"github.com/sourcegraph/conc/pool"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
-	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
 	"github.com/taurusgroup/multi-party-sig/pkg/taproot"
 	"github.com/taurusgroup/multi-party-sig/protocols/frost"
 )
 
 type startParams struct {
-	PublicKey          taproot.PublicKey
-	VerificationShares map[party.ID]*curve.Secp256k1Point
+	OldThreshold       int                 `json:"oldThreshold"`
+	OldSubset          []peer.ID           `json:"oldSubset"`
+	PublicKey          taproot.PublicKey   `json:"publicKey"`
+	VerificationShares map[party.ID][]byte `json:"verificationShares"`
 }
+
+// reshareMessage is sent by each old committee peer to each new committee peer.
+// Share is the evaluation of the dealers polynomial for the receiving peer and
+// Commitment is the polynomial in the exponent used to verify the share.
+type reshareMessage struct {
+	Commitment []byte `json:"commitment"`
+	Share      []byte `json:"share"`
+}
+
 type FrostKeyshareStorer interface {
 	GetKeyshare() (keyshare.FrostKeyshare, error)
 	StoreKeyshare(keyshare keyshare.FrostKeyshare) error
@@ -33,12 +49,23 @@ type FrostKeyshareStorer interface {
 	UnlockKeyshare()
 }
 
+// Resharing redistributes the FROST key from the old committee to the peers from the peerstore.
+// Each old committee peer from the subset shares its lagrange weighted keyshare with a new polynomial
+// of the new threshold degree, which allows changing the threshold and adding or removing peers while
+// only threshold+1 peers of the old committee have to be present.
 type Resharing struct {
 	common.BaseFrostTss
 	key            keyshare.FrostKeyshare
 	subscriptionID comm.SubscriptionID
 	storer         FrostKeyshareStorer
 	newThreshold   int
+
+	publicKey          taproot.PublicKey
+	verificationShares map[party.ID]*curve.Secp256k1Point
+	oldSubset          party.IDSlice
+	committee          peer.IDSlice
+	shares             map[party.ID]curve.Scalar
+	commitments        map[party.ID]*polynomial.Exponent
 }
 
 func NewResharing(
@@ -53,17 +80,8 @@ func NewResharing(
 	key, err := storer.GetKeyshare()
 	if err != nil {
 		// empty key for parties that don't have one
-		key = keyshare.FrostKeyshare{
-			Key: &frost.TaprootConfig{
-				Threshold:          threshold,
-				PublicKey:          nil,
-				PrivateShare:       &curve.Secp256k1Scalar{},
-				VerificationShares: make(map[party.ID]*curve.Secp256k1Point),
-				ID:                 party.ID(host.ID().Pretty()),
-			},
-		}
+		key = keyshare.FrostKeyshare{}
 	}
-	key.Key.Threshold = threshold
 
 	return &Resharing{
 		BaseFrostTss: common.BaseFrostTss{
@@ -73,11 +91,12 @@ func NewResharing(
 			SID:           sessionID,
 			Log:           log.With().Str("SessionID", sessionID).Str("Process", "resharing").Logger(),
 			Cancel:        func() {},
-			Done:          make(chan bool),
 		},
 		key:          key,
 		storer:       storer,
 		newThreshold: threshold,
+		shares:       make(map[party.ID]curve.Scalar),
+		commitments:  make(map[party.ID]*polynomial.Exponent),
 	}
 }
 
@@ -90,43 +109,24 @@ func (r *Resharing) Run(
 	params []byte,
 ) error {
 	ctx, r.Cancel = context.WithCancel(ctx)
-	var err error
 
-	outChn := make(chan tss.Message)
-	msgChn := make(chan *comm.WrappedMessage)
-	r.subscriptionID = r.Communication.Subscribe(r.SessionID(), comm.TssReshareMsg, msgChn)
 	startParams, err := r.unmarshallStartParams(params)
 	if err != nil {
 		return err
 	}
-	// initialize verification shares for the new relayer
-	if len(r.key.Key.VerificationShares) == 0 {
-		r.key.Key.VerificationShares = startParams.VerificationShares
-		r.key.Key.PublicKey = startParams.PublicKey
-	}
-
-	// Add a new verification share for each party that does not already have one
-	partyIds := common.PartyIDSFromPeers(append(r.Host.Peerstore().Peers(), r.Host.ID()))
-	group := curve.Secp256k1{}
-	for _, k := range partyIds {
-		if r.key.Key.VerificationShares[k] == nil {
-			r.key.Key.VerificationShares[k] = group.NewPoint().(*curve.Secp256k1Point)
-		}
-	}
-
-	r.Handler, err = protocol.NewMultiHandler(
-		frost.RefreshTaproot(
-			r.key.Key,
-			common.PartyIDSFromPeers(append(r.Host.Peerstore().Peers(), r.Host.ID()))),
-		[]byte(r.SessionID()))
+	err = r.initialize(startParams)
 	if err != nil {
 		return err
 	}
 
+	msgChn := make(chan *comm.WrappedMessage)
+	r.subscriptionID = r.Communication.Subscribe(r.SessionID(), comm.TssReshareMsg, msgChn)
+
 	p := pool.New().WithContext(ctx).WithCancelOnError()
-	p.Go(func(ctx context.Context) error { return r.ProcessInboundMessages(ctx, msgChn) })
-	p.Go(func(ctx context.Context) error { return r.processEndMessage(ctx) })
-	p.Go(func(ctx context.Context) error { return r.ProcessOutboundMessages(ctx, outChn, comm.TssReshareMsg) })
+	p.Go(func(ctx context.Context) error { return r.processInboundMessages(ctx, msgChn) })
+	if r.oldSubset.Contains(r.partyID()) {
+		p.Go(func(ctx context.Context) error { return r.deal(ctx, msgChn) })
+	}
 
 	r.Log.Info().Msgf("Started resharing process")
 	return p.Wait()
@@ -140,19 +140,39 @@ func (r *Resharing) Stop() {
 	r.Cancel()
 }
 
-// Ready returns true if all parties from peerstore are ready
+// Ready returns true if all parties from peerstore are ready.
+// Old committee peers that are no longer in the peerstore are not waited for, but
+// at least threshold+1 old committee peers have to be ready to reshare the key.
 func (r *Resharing) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
-	return len(readyPeers) == len(r.Host.Peerstore().Peers()), nil
+	if len(readyPeers) != len(r.Host.Peerstore().Peers()) {
+		return false, nil
+	}
+
+	oldSubset := r.readyOldPeers(readyPeers)
+	if len(oldSubset) < r.key.Threshold+1 {
+		return false, fmt.Errorf("%d old committee peers ready, %d required to reshare key", len(oldSubset), r.key.Threshold+1)
+	}
+	return true, nil
 }
 
+// ValidCoordinators returns only peers that have a valid keyshare from the previous resharing
+// inside host peerstore
 func (r *Resharing) ValidCoordinators() []peer.ID {
-	return r.key.Peers
+	return r.readyOldPeers(r.Host.Peerstore().Peers())
 }
 
+// StartParams returns threshold, public key and the old peer subset to share with new parties.
 func (r *Resharing) StartParams(readyPeers []peer.ID) []byte {
+	verificationShares := make(map[party.ID][]byte)
+	for id, share := range r.key.Key.VerificationShares {
+		verificationShares[id], _ = share.MarshalBinary()
+	}
+
 	startParams := &startParams{
+		OldThreshold:       r.key.Threshold,
+		OldSubset:          r.readyOldPeers(readyPeers),
 		PublicKey:          r.key.Key.PublicKey,
-		VerificationShares: r.key.Key.VerificationShares,
+		VerificationShares: verificationShares,
 	}
 	paramBytes, _ := json.Marshal(startParams)
 	return paramBytes
@@ -165,32 +185,161 @@ func (r *Resharing) unmarshallStartParams(paramBytes []byte) (startParams, error
 		return startParams, err
 	}
 
+	err = r.validateStartParams(startParams)
+	if err != nil {
+		return startParams, err
+	}
+
 	return startParams, nil
 }
 
-func (r *Resharing) Retryable() bool {
-	return false
+func (r *Resharing) validateStartParams(params startParams) error {
+	if params.OldThreshold <= 0 {
+		return errors.New("threshold too small")
+	}
+	if len(params.OldSubset) < params.OldThreshold+1 {
+		return errors.New("threshold bigger then subset")
+	}
+	if r.newThreshold <= 0 || len(r.committeePeers()) < r.newThreshold+1 {
+		return errors.New("new threshold bigger then committee")
+	}
+
+	// if relayer is already part of the old committee, check that start params
+	// match the saved keyshare
+	if r.key.Key == nil {
+		return nil
+	}
+	if params.OldThreshold != r.key.Threshold {
+		return errors.New("invalid threshold in start params")
+	}
+	if !bytes.Equal(r.key.Key.PublicKey, params.PublicKey) {
+		return errors.New("invalid public key in start params")
+	}
+	for _, peer := range params.OldSubset {
+		if !util.IsParticipant(peer, r.key.Peers) || !util.IsParticipant(peer, r.Peers) {
+			return errors.New("invalid peers subset in start params")
+		}
+	}
+	return nil
 }
 
-// processEndMessage waits for the final message with generated key share and stores it locally.
-func (r *Resharing) processEndMessage(ctx context.Context) error {
+// initialize sets the public key and verification shares of the old committee,
+// preferring the locally saved keyshare over the start params
+func (r *Resharing) initialize(params startParams) error {
+	r.publicKey = params.PublicKey
+	r.verificationShares = make(map[party.ID]*curve.Secp256k1Point)
+	if r.key.Key != nil {
+		r.verificationShares = r.key.Key.VerificationShares
+	} else {
+		for id, shareBytes := range params.VerificationShares {
+			share := curve.Secp256k1{}.NewPoint().(*curve.Secp256k1Point)
+			err := share.UnmarshalBinary(shareBytes)
+			if err != nil {
+				return err
+			}
+			r.verificationShares[id] = share
+		}
+	}
+
+	_, err := curve.Secp256k1{}.LiftX(r.publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key in start params: %w", err)
+	}
+
+	r.oldSubset = party.NewIDSlice(common.PartyIDSFromPeers(params.OldSubset))
+	for _, id := range r.oldSubset {
+		if r.verificationShares[id] == nil {
+			return fmt.Errorf("missing verification share of peer %s", id)
+		}
+	}
+	r.committee = r.committeePeers()
+	return nil
+}
+
+// deal sends each new committee peer its share of the lagrange weighted keyshare
+// of this relayer together with the commitment to the sharing polynomial
+func (r *Resharing) deal(ctx context.Context, msgChn chan *comm.WrappedMessage) error {
+	// delay sending messages until everyone is ready to accept them
+	select {
+	case <-time.After(common.STARTUP_PAUSE):
+	case <-ctx.Done():
+		return nil
+	}
+
+	group := curve.Secp256k1{}
+	lagrange := polynomial.LagrangeSingle(group, r.oldSubset, r.partyID())
+	secret := group.NewScalar().Set(lagrange).Mul(r.key.Key.PrivateShare)
+	f := polynomial.NewPolynomial(group, r.newThreshold, secret)
+	commitment, err := polynomial.NewPolynomialExponent(f).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range r.committee {
+		share, err := f.Evaluate(party.ID(p.String()).Scalar(group)).MarshalBinary()
+		if err != nil {
+			return err
+		}
+		msgBytes, err := json.Marshal(reshareMessage{
+			Commitment: commitment,
+			Share:      share,
+		})
+		if err != nil {
+			return err
+		}
+
+		if p == r.Host.ID() {
+			select {
+			case msgChn <- &comm.WrappedMessage{From: p, Payload: msgBytes}:
+				continue
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		r.Log.Debug().Msgf("sending resharing message to %s", p)
+		err = r.Communication.Broadcast(peer.IDSlice{p}, msgBytes, comm.TssReshareMsg, r.SessionID())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processInboundMessages verifies shares received from the old committee and
+// stores the new keyshare once shares from the whole old subset are received.
+func (r *Resharing) processInboundMessages(ctx context.Context, msgChn chan *comm.WrappedMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s", string(debug.Stack()))
+		}
+	}()
 
 	for {
 		select {
-		case <-r.Done:
+		case wMsg := <-msgChn:
 			{
-				result, err := r.Handler.Result()
+				r.Log.Debug().Msgf("processed inbound message from %s", wMsg.From)
+
+				dealer := party.ID(wMsg.From.String())
+				if !r.oldSubset.Contains(dealer) || r.commitments[dealer] != nil {
+					continue
+				}
+
+				err := r.storeMessage(dealer, wMsg.Payload)
 				if err != nil {
 					return err
 				}
-				taprootConfig := result.(*frost.TaprootConfig)
+				if len(r.commitments) < len(r.oldSubset) {
+					continue
+				}
 
-				err = r.storer.StoreKeyshare(keyshare.NewFrostKeyshare(taprootConfig, r.newThreshold, r.Peers))
+				err = r.storeKeyshare()
 				if err != nil {
 					return err
 				}
 
-				r.Log.Info().Msgf("Refreshed key")
+				r.Log.Info().Msgf("Reshared key")
 				r.Cancel()
 				return nil
 			}
@@ -199,3 +348,109 @@ func (r *Resharing) processEndMessage(ctx context.Context) error {
 		}
 	}
 }
+
+// storeMessage verifies that the commitment matches the dealers old verification share
+// and that the share is the evaluation of the committed polynomial
+func (r *Resharing) storeMessage(dealer party.ID, msgBytes []byte) error {
+	var msg reshareMessage
+	err := json.Unmarshal(msgBytes, &msg)
+	if err != nil {
+		return err
+	}
+
+	group := curve.Secp256k1{}
+	commitment := polynomial.EmptyExponent(group)
+	err = commitment.UnmarshalBinary(msg.Commitment)
+	if err != nil {
+		return err
+	}
+	share := group.NewScalar()
+	err = share.UnmarshalBinary(msg.Share)
+	if err != nil {
+		return err
+	}
+
+	if commitment.Degree() != r.newThreshold {
+		return fmt.Errorf("invalid commitment degree from peer %s", dealer)
+	}
+	lagrange := polynomial.LagrangeSingle(group, r.oldSubset, dealer)
+	if !commitment.Constant().Equal(lagrange.Act(r.verificationShares[dealer])) {
+		return fmt.Errorf("commitment from peer %s does not match its verification share", dealer)
+	}
+	if !share.ActOnBase().Equal(commitment.Evaluate(r.partyID().Scalar(group))) {
+		return fmt.Errorf("invalid share from peer %s", dealer)
+	}
+
+	r.shares[dealer] = share
+	r.commitments[dealer] = commitment
+	return nil
+}
+
+// storeKeyshare sums received shares into the new keyshare and calculates
+// verification shares of the new committee
+func (r *Resharing) storeKeyshare() error {
+	group := curve.Secp256k1{}
+	privateShare := group.NewScalar()
+	commitments := make([]*polynomial.Exponent, 0, len(r.commitments))
+	for dealer, share := range r.shares {
+		privateShare.Add(share)
+		commitments = append(commitments, r.commitments[dealer])
+	}
+	summed, err := polynomial.Sum(commitments)
+	if err != nil {
+		return err
+	}
+
+	publicKey, _ := group.LiftX(r.publicKey)
+	if !summed.Constant().Equal(publicKey) {
+		return errors.New("reshared key does not match the public key")
+	}
+	verificationShares := make(map[party.ID]*curve.Secp256k1Point)
+	for _, p := range r.committee {
+		id := party.ID(p.String())
+		verificationShares[id] = summed.Evaluate(id.Scalar(group)).(*curve.Secp256k1Point)
+	}
+	if !privateShare.ActOnBase().Equal(verificationShares[r.partyID()]) {
+		return errors.New("reshared key does not match the verification share")
+	}
+
+	taprootConfig := &frost.TaprootConfig{
+		ID:                 r.partyID(),
+		Threshold:          r.newThreshold,
+		PrivateShare:       privateShare.(*curve.Secp256k1Scalar),
+		PublicKey:          r.publicKey,
+		VerificationShares: verificationShares,
+	}
+	frostKeyshare := keyshare.NewFrostKeyshare(taprootConfig, r.newThreshold, r.Peers)
+	frostKeyshare.SessionID = r.SessionID()
+	return r.storer.StoreKeyshare(frostKeyshare)
+}
+
+// readyOldPeers returns peers from the old committee that are included in peers
+func (r *Resharing) readyOldPeers(peers []peer.ID) []peer.ID {
+	oldPeers := make(peer.IDSlice, 0)
+	for _, p := range r.key.Peers {
+		if util.IsParticipant(p, peers) {
+			oldPeers = append(oldPeers, p)
+		}
+	}
+	return oldPeers
+}
+
+// committeePeers returns peers from the peerstore that receive the new keyshare
+func (r *Resharing) committeePeers() peer.IDSlice {
+	committee := make(peer.IDSlice, len(r.Peers))
+	copy(committee, r.Peers)
+	if !util.IsParticipant(r.Host.ID(), committee) {
+		committee = append(committee, r.Host.ID())
+	}
+	return committee
+}
+
+func (r *Resharing) partyID() party.ID {
+	return party.ID(r.Host.ID().String())
+}
+
+func (r *Resharing) Retryable() bool {
+	return false
+}
@@ -6,6 +6,7 @@ package resharing_test
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/ChainSafe/sygma-relayer/comm"
@@ -20,6 +21,9 @@ import (
 	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/sourcegraph/conc/pool"
 	"github.com/stretchr/testify/suite"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
 )
 
 type ResharingTestSuite struct {
@@ -30,13 +34,9 @@ func TestRunResharingTestSuite(t *testing.T) {
 	suite.Run(t, new(ResharingTestSuite))
 }
 
-func (s *ResharingTestSuite) Test_ValidResharingProcess_OldAndNewSubset() {
-	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
-	coordinators := []*tss.Coordinator{}
-	processes := []tss.TssProcess{}
-
+func (s *ResharingTestSuite) newHosts(indexes ...int) []host.Host {
 	hosts := []host.Host{}
-	for i := 0; i < s.PartyNumber+1; i++ {
+	for _, i := range indexes {
 		host, _ := tsstest.NewHost(i)
 		hosts = append(hosts, host)
 	}
@@ -45,20 +45,35 @@ func (s *ResharingTestSuite) Test_ValidResharingProcess_OldAndNewSubset() {
 			host.Peerstore().AddAddr(peer.ID(), peer.Addrs()[0], peerstore.PermanentAddrTTL)
 		}
 	}
+	return hosts
+}
+
+// reshare runs resharing with the test keyshares of hosts and returns the stored keyshares
+func (s *ResharingTestSuite) reshare(hosts []host.Host, indexes []int, threshold int) ([]keyshare.FrostKeyshare, error) {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
 
+	lock := sync.Mutex{}
+	keyshares := []keyshare.FrostKeyshare{}
 	for i, host := range hosts {
 		communication := tsstest.TestCommunication{
 			Host:          host,
 			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
 		}
 		communicationMap[host.ID()] = &communication
-		storer := keyshare.NewFrostKeyshareStore(fmt.Sprintf("../../test/keyshares/%d-frost.keyshare", i))
+		storer := keyshare.NewFrostKeyshareStore(fmt.Sprintf("../../test/keyshares/%d-frost.keyshare", indexes[i]), nil)
 		share, err := storer.GetKeyshare()
 		s.MockFrostStorer.EXPECT().LockKeyshare()
 		s.MockFrostStorer.EXPECT().UnlockKeyshare()
 		s.MockFrostStorer.EXPECT().GetKeyshare().Return(share, err)
-		s.MockFrostStorer.EXPECT().StoreKeyshare(gomock.Any()).Return(nil)
-		resharing := resharing.NewResharing("resharing2", 1, host, &communication, s.MockFrostStorer)
+		s.MockFrostStorer.EXPECT().StoreKeyshare(gomock.Any()).DoAndReturn(func(key keyshare.FrostKeyshare) error {
+			lock.Lock()
+			defer lock.Unlock()
+			keyshares = append(keyshares, key)
+			return nil
+		}).AnyTimes()
+		resharing := resharing.NewResharing("resharing2", threshold, host, &communication, s.MockFrostStorer)
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
 		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
 		processes = append(processes, resharing)
@@ -68,58 +83,111 @@ func (s *ResharingTestSuite) Test_ValidResharingProcess_OldAndNewSubset() {
 	resultChn := make(chan interface{})
 	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
 	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
 		pool.Go(func(ctx context.Context) error {
-			return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, resultChn)
+			return coordinator.Execute(ctx, []tss.TssProcess{process}, resultChn)
 		})
 	}
 
 	err := pool.Wait()
-	s.Nil(err)
+	return keyshares, err
 }
 
-func (s *ResharingTestSuite) Test_ValidResharingProcess_RemovePeer() {
-	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
-	coordinators := []*tss.Coordinator{}
-	processes := []tss.TssProcess{}
+// verifyKeyshares checks that every keyshare matches its verification share and
+// that threshold+1 verification shares interpolate to the original public key
+func (s *ResharingTestSuite) verifyKeyshares(keyshares []keyshare.FrostKeyshare, partyNumber int, threshold int) {
+	original, err := keyshare.NewFrostKeyshareStore("../../test/keyshares/0-frost.keyshare", nil).GetKeyshare()
+	s.Nil(err)
+	s.Equal(len(keyshares), partyNumber)
 
-	hosts := []host.Host{}
-	for i := 0; i < s.PartyNumber-1; i++ {
-		host, _ := tsstest.NewHost(i)
-		hosts = append(hosts, host)
-	}
-	for _, host := range hosts {
-		for _, peer := range hosts {
-			host.Peerstore().AddAddr(peer.ID(), peer.Addrs()[0], peerstore.PermanentAddrTTL)
-		}
+	group := curve.Secp256k1{}
+	for _, key := range keyshares {
+		s.Equal(key.PublicKey(), original.PublicKey())
+		s.Equal(key.Threshold, threshold)
+		s.Equal(len(key.Key.VerificationShares), partyNumber)
+		s.True(key.Key.PrivateShare.ActOnBase().Equal(key.Key.VerificationShares[key.Key.ID]))
 	}
 
-	for i, host := range hosts {
-		communication := tsstest.TestCommunication{
-			Host:          host,
-			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+	key := keyshares[0].Key
+	signers := make([]party.ID, 0)
+	for id := range key.VerificationShares {
+		signers = append(signers, id)
+		if len(signers) == threshold+1 {
+			break
 		}
-		communicationMap[host.ID()] = &communication
-		storer := keyshare.NewFrostKeyshareStore(fmt.Sprintf("../../test/keyshares/%d-frost.keyshare", i))
-		share, err := storer.GetKeyshare()
-		s.MockFrostStorer.EXPECT().LockKeyshare()
-		s.MockFrostStorer.EXPECT().UnlockKeyshare()
-		s.MockFrostStorer.EXPECT().GetKeyshare().Return(share, err)
-		s.MockFrostStorer.EXPECT().StoreKeyshare(gomock.Any()).Return(nil)
-		resharing := resharing.NewResharing("resharing2", 1, host, &communication, s.MockFrostStorer)
-		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
-		processes = append(processes, resharing)
 	}
-	tsstest.SetupCommunication(communicationMap)
-
-	resultChn := make(chan interface{})
-	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
-	for i, coordinator := range coordinators {
-		pool.Go(func(ctx context.Context) error {
-			return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, resultChn)
-		})
+	publicKey := group.NewPoint()
+	for id, lagrange := range polynomial.Lagrange(group, signers) {
+		publicKey = publicKey.Add(lagrange.Act(key.VerificationShares[id]))
 	}
+	expectedPublicKey, err := group.LiftX(key.PublicKey)
+	s.Nil(err)
+	s.True(publicKey.Equal(expectedPublicKey))
+}
+
+func (s *ResharingTestSuite) Test_ValidResharingProcess_OldAndNewSubset() {
+	hosts := s.newHosts(0, 1, 2, 3)
+
+	keyshares, err := s.reshare(hosts, []int{0, 1, 2, 3}, 1)
 
-	err := pool.Wait()
 	s.Nil(err)
+	s.verifyKeyshares(keyshares, 4, 1)
+}
+
+func (s *ResharingTestSuite) Test_ValidResharingProcess_RemovePeer() {
+	hosts := s.newHosts(0, 1)
+
+	keyshares, err := s.reshare(hosts, []int{0, 1}, 1)
+
+	s.Nil(err)
+	s.verifyKeyshares(keyshares, 2, 1)
+}
+
+func (s *ResharingTestSuite) Test_ValidResharingProcess_ChangeThreshold() {
+	hosts := s.newHosts(0, 1, 2, 3)
+
+	keyshares, err := s.reshare(hosts, []int{0, 1, 2, 3}, 2)
+
+	s.Nil(err)
+	s.verifyKeyshares(keyshares, 4, 2)
+}
+
+func (s *ResharingTestSuite) Test_ValidResharingProcess_ReplacePeer() {
+	hosts := s.newHosts(0, 2, 3)
+
+	keyshares, err := s.reshare(hosts, []int{0, 2, 3}, 2)
+
+	s.Nil(err)
+	s.verifyKeyshares(keyshares, 3, 2)
+}
+
+func (s *ResharingTestSuite) Test_Ready_NotEnoughOldPeers() {
+	hosts := s.newHosts(0, 3)
+	storer := keyshare.NewFrostKeyshareStore("../../test/keyshares/0-frost.keyshare", nil)
+	share, err := storer.GetKeyshare()
+	s.MockFrostStorer.EXPECT().LockKeyshare()
+	s.MockFrostStorer.EXPECT().GetKeyshare().Return(share, err)
+	resharing := resharing.NewResharing("resharing2", 1, hosts[0], nil, s.MockFrostStorer)
+
+	ready, err := resharing.Ready([]peer.ID{hosts[0].ID()}, []peer.ID{})
+	s.Nil(err)
+	s.False(ready)
+
+	ready, err = resharing.Ready([]peer.ID{hosts[0].ID(), hosts[1].ID()}, []peer.ID{})
+	s.NotNil(err)
+	s.False(ready)
+}
+
+func (s *ResharingTestSuite) Test_ValidCoordinators_ExcludesRemovedPeers() {
+	hosts := s.newHosts(0, 1, 3)
+	storer := keyshare.NewFrostKeyshareStore("../../test/keyshares/0-frost.keyshare", nil)
+	share, err := storer.GetKeyshare()
+	s.MockFrostStorer.EXPECT().LockKeyshare()
+	s.MockFrostStorer.EXPECT().GetKeyshare().Return(share, err)
+	resharing := resharing.NewResharing("resharing2", 1, hosts[0], nil, s.MockFrostStorer)
+
+	coordinators := resharing.ValidCoordinators()
+
+	s.ElementsMatch(coordinators, []peer.ID{hosts[0].ID(), hosts[1].ID()})
 }
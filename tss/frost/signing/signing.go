@@ -14,7 +14,6 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
 	"github.com/sourcegraph/conc/pool"
-	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
 	"github.com/taurusgroup/multi-party-sig/pkg/taproot"
 	"github.com/taurusgroup/multi-party-sig/protocols/frost"
@@ -64,17 +63,7 @@ func NewSigning(
 		return nil, err
 	}
 
-	tweakBytes, err := hex.DecodeString(tweak)
-	if err != nil {
-		return nil, err
-	}
-
-	h := &curve.Secp256k1Scalar{}
-	err = h.UnmarshalBinary(tweakBytes)
-	if err != nil {
-		return nil, err
-	}
-	key.Key, err = key.Key.Derive(h, nil)
+	key, err = key.Tweak(tweak)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,238 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./tss/refresh/refresh.go
+
+// Package mock_refresh is a generated GoMock package.
+package mock_refresh
+
+import (
+	reflect "reflect"
+
+	keyshare "github.com/ChainSafe/sygma-relayer/keyshare"
+	topology "github.com/ChainSafe/sygma-relayer/topology"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockECDSAKeyshareStorer is a mock of ECDSAKeyshareStorer interface.
+type MockECDSAKeyshareStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockECDSAKeyshareStorerMockRecorder
+}
+
+// MockECDSAKeyshareStorerMockRecorder is the mock recorder for MockECDSAKeyshareStorer.
+type MockECDSAKeyshareStorerMockRecorder struct {
+	mock *MockECDSAKeyshareStorer
+}
+
+// NewMockECDSAKeyshareStorer creates a new mock instance.
+func NewMockECDSAKeyshareStorer(ctrl *gomock.Controller) *MockECDSAKeyshareStorer {
+	mock := &MockECDSAKeyshareStorer{ctrl: ctrl}
+	mock.recorder = &MockECDSAKeyshareStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockECDSAKeyshareStorer) EXPECT() *MockECDSAKeyshareStorerMockRecorder {
+	return m.recorder
+}
+
+// GetKeyshare mocks base method.
+func (m *MockECDSAKeyshareStorer) GetKeyshare() (keyshare.ECDSAKeyshare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyshare")
+	ret0, _ := ret[0].(keyshare.ECDSAKeyshare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyshare indicates an expected call of GetKeyshare.
+func (mr *MockECDSAKeyshareStorerMockRecorder) GetKeyshare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyshare", reflect.TypeOf((*MockECDSAKeyshareStorer)(nil).GetKeyshare))
+}
+
+// LockKeyshare mocks base method.
+func (m *MockECDSAKeyshareStorer) LockKeyshare() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LockKeyshare")
+}
+
+// LockKeyshare indicates an expected call of LockKeyshare.
+func (mr *MockECDSAKeyshareStorerMockRecorder) LockKeyshare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockKeyshare", reflect.TypeOf((*MockECDSAKeyshareStorer)(nil).LockKeyshare))
+}
+
+// StoreKeyshare mocks base method.
+func (m *MockECDSAKeyshareStorer) StoreKeyshare(keyshare keyshare.ECDSAKeyshare) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreKeyshare", keyshare)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreKeyshare indicates an expected call of StoreKeyshare.
+func (mr *MockECDSAKeyshareStorerMockRecorder) StoreKeyshare(keyshare interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreKeyshare", reflect.TypeOf((*MockECDSAKeyshareStorer)(nil).StoreKeyshare), keyshare)
+}
+
+// UnlockKeyshare mocks base method.
+func (m *MockECDSAKeyshareStorer) UnlockKeyshare() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnlockKeyshare")
+}
+
+// UnlockKeyshare indicates an expected call of UnlockKeyshare.
+func (mr *MockECDSAKeyshareStorerMockRecorder) UnlockKeyshare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockKeyshare", reflect.TypeOf((*MockECDSAKeyshareStorer)(nil).UnlockKeyshare))
+}
+
+// MockFrostKeyshareStorer is a mock of FrostKeyshareStorer interface.
+type MockFrostKeyshareStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockFrostKeyshareStorerMockRecorder
+}
+
+// MockFrostKeyshareStorerMockRecorder is the mock recorder for MockFrostKeyshareStorer.
+type MockFrostKeyshareStorerMockRecorder struct {
+	mock *MockFrostKeyshareStorer
+}
+
+// NewMockFrostKeyshareStorer creates a new mock instance.
+func NewMockFrostKeyshareStorer(ctrl *gomock.Controller) *MockFrostKeyshareStorer {
+	mock := &MockFrostKeyshareStorer{ctrl: ctrl}
+	mock.recorder = &MockFrostKeyshareStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFrostKeyshareStorer) EXPECT() *MockFrostKeyshareStorerMockRecorder {
+	return m.recorder
+}
+
+// GetKeyshare mocks base method.
+func (m *MockFrostKeyshareStorer) GetKeyshare() (keyshare.FrostKeyshare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyshare")
+	ret0, _ := ret[0].(keyshare.FrostKeyshare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyshare indicates an expected call of GetKeyshare.
+func (mr *MockFrostKeyshareStorerMockRecorder) GetKeyshare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyshare", reflect.TypeOf((*MockFrostKeyshareStorer)(nil).GetKeyshare))
+}
+
+// LockKeyshare mocks base method.
+func (m *MockFrostKeyshareStorer) LockKeyshare() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LockKeyshare")
+}
+
+// LockKeyshare indicates an expected call of LockKeyshare.
+func (mr *MockFrostKeyshareStorerMockRecorder) LockKeyshare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockKeyshare", reflect.TypeOf((*MockFrostKeyshareStorer)(nil).LockKeyshare))
+}
+
+// StoreKeyshare mocks base method.
+func (m *MockFrostKeyshareStorer) StoreKeyshare(keyshare keyshare.FrostKeyshare) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreKeyshare", keyshare)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreKeyshare indicates an expected call of StoreKeyshare.
+func (mr *MockFrostKeyshareStorerMockRecorder) StoreKeyshare(keyshare interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreKeyshare", reflect.TypeOf((*MockFrostKeyshareStorer)(nil).StoreKeyshare), keyshare)
+}
+
+// UnlockKeyshare mocks base method.
+func (m *MockFrostKeyshareStorer) UnlockKeyshare() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnlockKeyshare")
+}
+
+// UnlockKeyshare indicates an expected call of UnlockKeyshare.
+func (mr *MockFrostKeyshareStorerMockRecorder) UnlockKeyshare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockKeyshare", reflect.TypeOf((*MockFrostKeyshareStorer)(nil).UnlockKeyshare))
+}
+
+// MockTopologyStorer is a mock of TopologyStorer interface.
+type MockTopologyStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTopologyStorerMockRecorder
+}
+
+// MockTopologyStorerMockRecorder is the mock recorder for MockTopologyStorer.
+type MockTopologyStorerMockRecorder struct {
+	mock *MockTopologyStorer
+}
+
+// NewMockTopologyStorer creates a new mock instance.
+func NewMockTopologyStorer(ctrl *gomock.Controller) *MockTopologyStorer {
+	mock := &MockTopologyStorer{ctrl: ctrl}
+	mock.recorder = &MockTopologyStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTopologyStorer) EXPECT() *MockTopologyStorerMockRecorder {
+	return m.recorder
+}
+
+// Topology mocks base method.
+func (m *MockTopologyStorer) Topology() (*topology.NetworkTopology, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Topology")
+	ret0, _ := ret[0].(*topology.NetworkTopology)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Topology indicates an expected call of Topology.
+func (mr *MockTopologyStorerMockRecorder) Topology() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Topology", reflect.TypeOf((*MockTopologyStorer)(nil).Topology))
+}
+
+// MockRefreshMetrics is a mock of RefreshMetrics interface.
+type MockRefreshMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshMetricsMockRecorder
+}
+
+// MockRefreshMetricsMockRecorder is the mock recorder for MockRefreshMetrics.
+type MockRefreshMetricsMockRecorder struct {
+	mock *MockRefreshMetrics
+}
+
+// NewMockRefreshMetrics creates a new mock instance.
+func NewMockRefreshMetrics(ctrl *gomock.Controller) *MockRefreshMetrics {
+	mock := &MockRefreshMetrics{ctrl: ctrl}
+	mock.recorder = &MockRefreshMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshMetrics) EXPECT() *MockRefreshMetricsMockRecorder {
+	return m.recorder
+}
+
+// TrackKeyRefreshStatus mocks base method.
+func (m *MockRefreshMetrics) TrackKeyRefreshStatus(keyType string, failed bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackKeyRefreshStatus", keyType, failed)
+}
+
+// TrackKeyRefreshStatus indicates an expected call of TrackKeyRefreshStatus.
+func (mr *MockRefreshMetricsMockRecorder) TrackKeyRefreshStatus(keyType, failed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackKeyRefreshStatus", reflect.TypeOf((*MockRefreshMetrics)(nil).TrackKeyRefreshStatus), keyType, failed)
+}
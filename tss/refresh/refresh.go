@@ -0,0 +1,231 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package refresh
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/topology"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/resharing"
+	frostResharing "github.com/ChainSafe/sygma-relayer/tss/frost/resharing"
+)
+
+type ECDSAKeyshareStorer interface {
+	resharing.SaveDataStorer
+}
+
+type FrostKeyshareStorer interface {
+	frostResharing.FrostKeyshareStorer
+}
+
+type TopologyStorer interface {
+	Topology() (*topology.NetworkTopology, error)
+}
+
+type RefreshMetrics interface {
+	TrackKeyRefreshStatus(keyType string, failed bool)
+}
+
+const (
+	ecdsaKeyType = "ecdsa"
+	frostKeyType = "frost"
+)
+
+// Refresher proactively reshares keyshares to the same peers and threshold
+// so that previously leaked shares become useless while the public keys stay unchanged
+type Refresher struct {
+	coordinator   *tss.Coordinator
+	host          host.Host
+	communication comm.Communication
+	ecdsaStorer   ECDSAKeyshareStorer
+	frostStorer   FrostKeyshareStorer
+	topologyStore TopologyStorer
+	metrics       RefreshMetrics
+
+	lock     sync.RWMutex
+	failures map[string]error
+}
+
+func NewRefresher(
+	coordinator *tss.Coordinator,
+	host host.Host,
+	communication comm.Communication,
+	ecdsaStorer ECDSAKeyshareStorer,
+	frostStorer FrostKeyshareStorer,
+	topologyStore TopologyStorer,
+	metrics RefreshMetrics,
+) *Refresher {
+	return &Refresher{
+		coordinator:   coordinator,
+		host:          host,
+		communication: communication,
+		ecdsaStorer:   ecdsaStorer,
+		frostStorer:   frostStorer,
+		topologyStore: topologyStore,
+		metrics:       metrics,
+		failures:      make(map[string]error),
+	}
+}
+
+// Health returns an error if a refresh changed the public key of a keyshare.
+// The keyshare is not rolled back locally because the rest of the committee would keep
+// the refreshed generation. The failure is reported until the relayers restore
+// the previous generation with the keyshare restore command and restart.
+func (r *Refresher) Health() error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for _, keyType := range []string{ecdsaKeyType, frostKeyType} {
+		if err, ok := r.failures[keyType]; ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// Refresh refreshes ECDSA and FROST keyshares one after another.
+// FROST keyshare is refreshed even if the ECDSA refresh fails.
+func (r *Refresher) Refresh(sessionID string) error {
+	ecdsaErr := r.RefreshECDSA(sessionID)
+	if ecdsaErr != nil {
+		log.Err(ecdsaErr).Str("SessionID", sessionID).Msgf("Failed executing ecdsa proactive key refresh")
+	}
+	frostErr := r.RefreshFrost(r.frostSessionID(sessionID))
+	if frostErr != nil {
+		log.Err(frostErr).Str("SessionID", r.frostSessionID(sessionID)).Msgf("Failed executing frost proactive key refresh")
+	}
+
+	if ecdsaErr != nil {
+		return ecdsaErr
+	}
+	return frostErr
+}
+
+// RefreshECDSA reshares the ECDSA keyshare to the current peers with the same threshold.
+// Refresh is skipped if the relayer has no keyshare or the peers changed since the last
+// keygen or resharing, as those are handled by the refresh event.
+func (r *Refresher) RefreshECDSA(sessionID string) error {
+	key, err := r.ecdsaStorer.GetKeyshare()
+	if err != nil {
+		log.Debug().Err(err).Msgf("Skipping ecdsa proactive key refresh, relayer has no keyshare")
+		return nil
+	}
+	if err := r.failure(ecdsaKeyType); err != nil {
+		log.Warn().Err(err).Msgf("Skipping ecdsa proactive key refresh, previous refresh failed")
+		return nil
+	}
+	if !r.isCurrentCommittee(key.Peers) {
+		log.Warn().Msgf("Skipping ecdsa proactive key refresh, keyshare peers differ from the topology")
+		return nil
+	}
+
+	publicKey := key.PublicKey()
+	process := resharing.NewResharing(sessionID, key.Threshold, r.host, r.communication, r.ecdsaStorer)
+	err = r.coordinator.Execute(context.Background(), []tss.TssProcess{process}, make(chan interface{}, 1))
+	if err != nil {
+		return err
+	}
+
+	key, err = r.ecdsaStorer.GetKeyshare()
+	if err != nil {
+		return err
+	}
+	if key.PublicKey() != publicKey {
+		return r.fail(ecdsaKeyType, publicKey, key.PublicKey())
+	}
+	log.Info().Str("SessionID", sessionID).Msgf("Refreshed ecdsa keyshare of %s", publicKey)
+	return nil
+}
+
+// RefreshFrost reshares the FROST keyshare to the current peers with the same threshold.
+// Refresh is skipped if the relayer has no keyshare or the peers changed since the last
+// keygen or resharing, as those are handled by the refresh event.
+func (r *Refresher) RefreshFrost(sessionID string) error {
+	key, err := r.frostStorer.GetKeyshare()
+	if err != nil {
+		log.Debug().Err(err).Msgf("Skipping frost proactive key refresh, relayer has no keyshare")
+		return nil
+	}
+	if err := r.failure(frostKeyType); err != nil {
+		log.Warn().Err(err).Msgf("Skipping frost proactive key refresh, previous refresh failed")
+		return nil
+	}
+	if !r.isCurrentCommittee(key.Peers) {
+		log.Warn().Msgf("Skipping frost proactive key refresh, keyshare peers differ from the topology")
+		return nil
+	}
+
+	publicKey := key.PublicKey()
+	process := frostResharing.NewResharing(sessionID, key.Threshold, r.host, r.communication, r.frostStorer)
+	err = r.coordinator.Execute(context.Background(), []tss.TssProcess{process}, make(chan interface{}, 1))
+	if err != nil {
+		return err
+	}
+
+	key, err = r.frostStorer.GetKeyshare()
+	if err != nil {
+		return err
+	}
+	if key.PublicKey() != publicKey {
+		return r.fail(frostKeyType, publicKey, key.PublicKey())
+	}
+	log.Info().Str("SessionID", sessionID).Msgf("Refreshed frost keyshare of %s", publicKey)
+	return nil
+}
+
+// fail records the refresh of the key type as failed because the refresh changed the public key
+func (r *Refresher) fail(keyType string, publicKey string, newPublicKey string) error {
+	err := fmt.Errorf(
+		"%s refresh changed public key from %s to %s, restore the previous keyshare generation on all relayers",
+		keyType, publicKey, newPublicKey)
+
+	r.lock.Lock()
+	r.failures[keyType] = err
+	r.lock.Unlock()
+	r.metrics.TrackKeyRefreshStatus(keyType, true)
+	return err
+}
+
+func (r *Refresher) failure(keyType string) error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.failures[keyType]
+}
+
+// isCurrentCommittee returns true if keyshare peers are the same as peers from the stored topology
+func (r *Refresher) isCurrentCommittee(peers []peer.ID) bool {
+	networkTopology, err := r.topologyStore.Topology()
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed loading topology")
+		return false
+	}
+	if len(peers) != len(networkTopology.Peers) {
+		return false
+	}
+
+	for _, p := range peers {
+		found := false
+		for _, c := range networkTopology.Peers {
+			if p == c.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Refresher) frostSessionID(sessionID string) string {
+	return fmt.Sprintf("frost-%s", sessionID)
+}
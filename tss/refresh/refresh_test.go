@@ -0,0 +1,198 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package refresh_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/topology"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/refresh"
+	mock_refresh "github.com/ChainSafe/sygma-relayer/tss/refresh/mock"
+	tsstest "github.com/ChainSafe/sygma-relayer/tss/test"
+)
+
+type RefresherTestSuite struct {
+	suite.Suite
+	hosts             []host.Host
+	ecdsaStores       []*keyshare.ECDSAKeyshareStore
+	frostStores       []*keyshare.FrostKeyshareStore
+	refreshers        []*refresh.Refresher
+	mockTopologyStore *mock_refresh.MockTopologyStorer
+	mockMetrics       *mock_refresh.MockRefreshMetrics
+}
+
+func TestRunRefresherTestSuite(t *testing.T) {
+	suite.Run(t, new(RefresherTestSuite))
+}
+
+func (s *RefresherTestSuite) SetupTest() {
+	s.hosts = []host.Host{}
+	for i := 0; i < 3; i++ {
+		host, err := newHost(i)
+		s.Require().Nil(err)
+		s.hosts = append(s.hosts, host)
+	}
+	for _, host := range s.hosts {
+		for _, peer := range s.hosts {
+			host.Peerstore().AddAddr(peer.ID(), peer.Addrs()[0], peerstore.PermanentAddrTTL)
+		}
+	}
+	bullyConfig := relayer.BullyConfig{
+		PingWaitTime:     1 * time.Second,
+		PingBackOff:      1 * time.Second,
+		PingInterval:     1 * time.Second,
+		ElectionWaitTime: 2 * time.Second,
+		BullyWaitTime:    25 * time.Second,
+	}
+
+	ctrl := gomock.NewController(s.T())
+	s.mockTopologyStore = mock_refresh.NewMockTopologyStorer(ctrl)
+	s.mockMetrics = mock_refresh.NewMockRefreshMetrics(ctrl)
+
+	dir := s.T().TempDir()
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	s.ecdsaStores = []*keyshare.ECDSAKeyshareStore{}
+	s.frostStores = []*keyshare.FrostKeyshareStore{}
+	s.refreshers = []*refresh.Refresher{}
+	for i, host := range s.hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+
+		ecdsaStore := keyshare.NewECDSAKeyshareStore(s.copyKeyshare(dir, fmt.Sprintf("%d.keyshare", i)), nil)
+		frostStore := keyshare.NewFrostKeyshareStore(s.copyKeyshare(dir, fmt.Sprintf("%d-frost.keyshare", i)), nil)
+		s.ecdsaStores = append(s.ecdsaStores, ecdsaStore)
+		s.frostStores = append(s.frostStores, frostStore)
+
+		electorFactory := elector.NewCoordinatorElectorFactory(host, bullyConfig)
+		coordinator := tss.NewCoordinator(host, &communication, electorFactory)
+		s.refreshers = append(s.refreshers, refresh.NewRefresher(coordinator, host, &communication, ecdsaStore, frostStore, s.mockTopologyStore, s.mockMetrics))
+	}
+	tsstest.SetupCommunication(communicationMap)
+}
+
+func (s *RefresherTestSuite) topology(hosts []host.Host) *topology.NetworkTopology {
+	peers := []*peer.AddrInfo{}
+	for _, host := range hosts {
+		peers = append(peers, &peer.AddrInfo{ID: host.ID(), Addrs: host.Addrs()})
+	}
+	return &topology.NetworkTopology{Peers: peers, Threshold: 1}
+}
+
+func newHost(i int) (host.Host, error) {
+	privBytes, err := os.ReadFile(fmt.Sprintf("../test/pks/%d.pk", i))
+	if err != nil {
+		return nil, err
+	}
+	priv, err := crypto.UnmarshalPrivateKey(privBytes)
+	if err != nil {
+		return nil, err
+	}
+	return libp2p.New(libp2p.Identity(priv), libp2p.DisableRelay())
+}
+
+func (s *RefresherTestSuite) copyKeyshare(dir string, name string) string {
+	data, err := os.ReadFile(fmt.Sprintf("../test/keyshares/%s", name))
+	s.Require().Nil(err)
+	path := fmt.Sprintf("%s/%s", dir, name)
+	s.Require().Nil(os.WriteFile(path, data, 0600))
+	return path
+}
+
+func (s *RefresherTestSuite) Test_RefreshKeepsPublicKeys() {
+	s.mockTopologyStore.EXPECT().Topology().Return(s.topology(s.hosts), nil).AnyTimes()
+	ecdsaKey, _ := s.ecdsaStores[0].GetKeyshare()
+	frostKey, _ := s.frostStores[0].GetKeyshare()
+
+	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
+	for _, refresher := range s.refreshers {
+		refresher := refresher
+		pool.Go(func(ctx context.Context) error {
+			return refresher.Refresh("proactive-refresh-1")
+		})
+	}
+	err := pool.Wait()
+	s.Nil(err)
+
+	for i := range s.refreshers {
+		refreshedECDSAKey, err := s.ecdsaStores[i].GetKeyshare()
+		s.Nil(err)
+		s.Equal(refreshedECDSAKey.PublicKey(), ecdsaKey.PublicKey())
+		s.Equal(refreshedECDSAKey.SessionID, "proactive-refresh-1")
+		s.Equal(refreshedECDSAKey.Threshold, ecdsaKey.Threshold)
+
+		refreshedFrostKey, err := s.frostStores[i].GetKeyshare()
+		s.Nil(err)
+		s.Equal(refreshedFrostKey.PublicKey(), frostKey.PublicKey())
+		s.Equal(refreshedFrostKey.SessionID, "frost-proactive-refresh-1")
+
+		generations, err := s.ecdsaStores[i].Generations()
+		s.Nil(err)
+		s.Equal(len(generations), 1)
+
+		s.Nil(s.refreshers[i].Health())
+	}
+}
+
+func (s *RefresherTestSuite) Test_SkipsKeyshareOfDifferentCommittee() {
+	s.mockTopologyStore.EXPECT().Topology().Return(s.topology(s.hosts[:2]), nil).AnyTimes()
+	ecdsaKey, _ := s.ecdsaStores[0].GetKeyshare()
+	frostKey, _ := s.frostStores[0].GetKeyshare()
+
+	err := s.refreshers[0].Refresh("proactive-refresh-1")
+
+	s.Nil(err)
+	s.Nil(s.refreshers[0].Health())
+	unchangedECDSAKey, _ := s.ecdsaStores[0].GetKeyshare()
+	s.Equal(unchangedECDSAKey.SessionID, ecdsaKey.SessionID)
+	unchangedFrostKey, _ := s.frostStores[0].GetKeyshare()
+	s.Equal(unchangedFrostKey.SessionID, frostKey.SessionID)
+}
+
+func (s *RefresherTestSuite) Test_SkipsKeyshareWithoutTopology() {
+	s.mockTopologyStore.EXPECT().Topology().Return(nil, fmt.Errorf("error")).AnyTimes()
+	ecdsaKey, _ := s.ecdsaStores[0].GetKeyshare()
+
+	err := s.refreshers[0].Refresh("proactive-refresh-1")
+
+	s.Nil(err)
+	unchangedECDSAKey, _ := s.ecdsaStores[0].GetKeyshare()
+	s.Equal(unchangedECDSAKey.SessionID, ecdsaKey.SessionID)
+}
+
+func (s *RefresherTestSuite) Test_SkipsRelayerWithoutKeyshare() {
+	refresher := refresh.NewRefresher(
+		nil,
+		s.hosts[0],
+		nil,
+		keyshare.NewECDSAKeyshareStore(fmt.Sprintf("%s/missing.keyshare", s.T().TempDir()), nil),
+		keyshare.NewFrostKeyshareStore(fmt.Sprintf("%s/missing-frost.keyshare", s.T().TempDir()), nil),
+		s.mockTopologyStore,
+		s.mockMetrics,
+	)
+
+	err := refresher.Refresh("proactive-refresh-1")
+
+	s.Nil(err)
+}